@@ -0,0 +1,303 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/location"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	estimatedHourlyCost  = "estimated_hourly_cost"
+	estimatedMonthlyCost = "estimated_monthly_cost"
+
+	// hoursPerMonth is the number of hours SoftLayer bills per month for
+	// hourly resources, used to project a monthly figure for hourly orders.
+	hoursPerMonth = 730
+)
+
+func dataSourceIBMClassicOrderEstimate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMClassicOrderEstimateRead,
+
+		Schema: map[string]*schema.Schema{
+			"package_key_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Key name of the product package to order from",
+			},
+			"datacenter": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Datacenter the order is placed in",
+			},
+			"item_key_names": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Key names of the package items to price",
+			},
+			"complex_type": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SoftLayer order container type, for example SoftLayer_Container_Product_Order_Hardware_Server",
+			},
+			"quantity": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Number of units to order",
+			},
+			"hourly_billing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Price the order with hourly billing",
+			},
+			estimatedHourlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated hourly cost of the order, including tax",
+			},
+			estimatedMonthlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated monthly cost of the order, including tax",
+			},
+			"estimated_setup_cost": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated one-time setup cost of the order, including tax",
+			},
+			"prices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Prices quoted for each item in the order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Price ID",
+						},
+						"item_key_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Key name of the priced item",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the priced item",
+						},
+						"hourly_recurring_fee": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Hourly recurring fee of the item",
+						},
+						"recurring_fee": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Monthly recurring fee of the item",
+						},
+						"setup_fee": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "One-time setup fee of the item",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMClassicOrderEstimateRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+
+	packageKeyName := d.Get("package_key_name").(string)
+	datacenter := d.Get("datacenter").(string)
+
+	pkg, err := product.GetPackageByKeyName(sess, packageKeyName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving product package %s: %s", packageKeyName, err)
+	}
+
+	dc, err := location.GetDatacenterByName(sess, datacenter)
+	if err != nil {
+		return fmt.Errorf("No data centers matching %s could be found", datacenter)
+	}
+
+	items, err := product.GetPackageProducts(sess, *pkg.Id, productItemMaskWithPriceLocationGroupID)
+	if err != nil {
+		return fmt.Errorf("Error retrieving items of product package %s: %s", packageKeyName, err)
+	}
+
+	prices := []datatypes.Product_Item_Price{}
+	for _, keyName := range expandStringList(d.Get("item_key_names").([]interface{})) {
+		price, err := getStandardItemPrice(items, keyName)
+		if err != nil {
+			return err
+		}
+		prices = append(prices, price)
+	}
+
+	order := datatypes.Container_Product_Order{
+		PackageId:        pkg.Id,
+		Location:         sl.String(strconv.Itoa(*dc.Id)),
+		Prices:           prices,
+		Quantity:         sl.Int(d.Get("quantity").(int)),
+		UseHourlyPricing: sl.Bool(d.Get("hourly_billing").(bool)),
+	}
+	if complexType, ok := d.GetOk("complex_type"); ok {
+		order.ComplexType = sl.String(complexType.(string))
+	}
+
+	verified, err := services.GetProductOrderService(sess.SetRetries(0)).VerifyOrder(&order)
+	if err != nil {
+		return fmt.Errorf("Error verifying order for product package %s: %s", packageKeyName, err)
+	}
+
+	hourly, monthly := classicOrderCost(verified)
+	d.SetId(time.Now().UTC().String())
+	d.Set(estimatedHourlyCost, hourly)
+	d.Set(estimatedMonthlyCost, monthly)
+	d.Set("estimated_setup_cost", slFloat64Value(verified.PostTaxSetup))
+	d.Set("prices", flattenClassicOrderPrices(verified.Prices))
+
+	return nil
+}
+
+// getStandardItemPrice returns the standard price of the item with the given
+// key name, i.e. the price that is not restricted to a location group.
+func getStandardItemPrice(items []datatypes.Product_Item, keyName string) (datatypes.Product_Item_Price, error) {
+	for _, item := range items {
+		if item.KeyName == nil || *item.KeyName != keyName {
+			continue
+		}
+		for _, price := range item.Prices {
+			if price.LocationGroupId == nil {
+				return datatypes.Product_Item_Price{Id: price.Id}, nil
+			}
+		}
+		return datatypes.Product_Item_Price{}, fmt.Errorf("No standard price found for item %s", keyName)
+	}
+	return datatypes.Product_Item_Price{}, fmt.Errorf("No item found with key name %s", keyName)
+}
+
+func flattenClassicOrderPrices(prices []datatypes.Product_Item_Price) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(prices))
+	for _, price := range prices {
+		p := map[string]interface{}{
+			"id":                   sl.Get(price.Id, 0),
+			"hourly_recurring_fee": slFloat64Value(price.HourlyRecurringFee),
+			"recurring_fee":        slFloat64Value(price.RecurringFee),
+			"setup_fee":            slFloat64Value(price.SetupFee),
+		}
+		if price.Item != nil {
+			p["item_key_name"] = sl.Get(price.Item.KeyName, "")
+			p["description"] = sl.Get(price.Item.Description, "")
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// classicOrderCost returns the hourly and monthly cost, including tax, of an
+// order verified by SoftLayer_Product_Order::verifyOrder. Orders with several
+// containers are summed, and hourly orders are projected over hoursPerMonth.
+func classicOrderCost(order datatypes.Container_Product_Order) (float64, float64) {
+	if len(order.OrderContainers) > 0 && order.PostTaxRecurring == nil {
+		var hourly, monthly float64
+		for _, container := range order.OrderContainers {
+			h, m := classicOrderCost(container)
+			hourly += h
+			monthly += m
+		}
+		return hourly, monthly
+	}
+	hourly := slFloat64Value(order.PostTaxRecurringHourly)
+	monthly := slFloat64Value(order.PostTaxRecurringMonthly)
+	if monthly == 0 && hourly > 0 {
+		monthly = hourly * hoursPerMonth
+	}
+	return hourly, monthly
+}
+
+func slFloat64Value(v *datatypes.Float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return float64(*v)
+}
+
+// verifyClassicOrderCost runs SoftLayer_Product_Order::verifyOrder on the
+// given order container and returns its hourly and monthly cost.
+func verifyClassicOrderCost(sess *session.Session, order interface{}) (float64, float64, error) {
+	verified, err := services.GetProductOrderService(sess.SetRetries(0)).VerifyOrder(order)
+	if err != nil {
+		return 0, 0, err
+	}
+	hourly, monthly := classicOrderCost(verified)
+	return hourly, monthly, nil
+}
+
+// resourceClassicOrderEstimateCustomizeDiff sets estimated_hourly_cost and
+// estimated_monthly_cost on the plan of a classic resource from the order
+// returned by buildOrder, which is built from the dependsOn attributes. An
+// existing resource is estimated again when any of them changes, so the plan
+// shows the cost of the resource after the update. The estimate is skipped
+// when any of the dependsOn attributes is not known until apply, and a failed
+// verification only logs a warning since the order is verified again when it
+// is placed.
+func resourceClassicOrderEstimateCustomizeDiff(diff *schema.ResourceDiff, meta interface{}, dependsOn []string, buildOrder func(sess *session.Session) (interface{}, error)) error {
+	if diff.Id() != "" && !classicOrderEstimateHasChange(diff, dependsOn) {
+		return nil
+	}
+	for _, key := range dependsOn {
+		if !diff.NewValueKnown(key) {
+			log.Printf("[DEBUG] Skipping order cost estimate as %s is not known until apply", key)
+			return nil
+		}
+	}
+
+	sess := meta.(ClientSession).SoftLayerSession()
+	order, err := buildOrder(sess)
+	if err != nil {
+		log.Printf("[WARN] Unable to build order for cost estimate: %s", err)
+		return nil
+	}
+	if order == nil {
+		return nil
+	}
+	hourly, monthly, err := verifyClassicOrderCost(sess, order)
+	if err != nil {
+		log.Printf("[WARN] Unable to estimate order cost: %s", err)
+		return nil
+	}
+	if err := diff.SetNew(estimatedHourlyCost, hourly); err != nil {
+		return err
+	}
+	return diff.SetNew(estimatedMonthlyCost, monthly)
+}
+
+func classicOrderEstimateHasChange(diff *schema.ResourceDiff, dependsOn []string) bool {
+	for _, key := range dependsOn {
+		if diff.HasChange(key) {
+			return true
+		}
+	}
+	return false
+}
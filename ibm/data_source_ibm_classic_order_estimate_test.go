@@ -0,0 +1,72 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"gotest.tools/assert"
+)
+
+func TestAccIBMClassicOrderEstimateDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMClassicOrderEstimateDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_classic_order_estimate.estimate", "estimated_hourly_cost"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_classic_order_estimate.estimate", "estimated_monthly_cost"),
+					resource.TestCheckResourceAttr(
+						"data.ibm_classic_order_estimate.estimate", "prices.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestClassicOrderCost(t *testing.T) {
+	hourly := datatypes.Float64(0.05)
+	monthly := datatypes.Float64(25)
+	recurring := datatypes.Float64(0.05)
+
+	h, m := classicOrderCost(datatypes.Container_Product_Order{
+		PostTaxRecurring:       &recurring,
+		PostTaxRecurringHourly: &hourly,
+	})
+	assert.Equal(t, 0.05, h)
+	assert.Assert(t, math.Abs(0.05*hoursPerMonth-m) < 0.0001)
+
+	h, m = classicOrderCost(datatypes.Container_Product_Order{
+		PostTaxRecurring:        &monthly,
+		PostTaxRecurringMonthly: &monthly,
+	})
+	assert.Equal(t, 0.0, h)
+	assert.Equal(t, 25.0, m)
+
+	h, m = classicOrderCost(datatypes.Container_Product_Order{
+		OrderContainers: []datatypes.Container_Product_Order{
+			{PostTaxRecurringMonthly: &monthly},
+			{PostTaxRecurringMonthly: &monthly},
+		},
+	})
+	assert.Equal(t, 0.0, h)
+	assert.Equal(t, 50.0, m)
+}
+
+const testAccCheckIBMClassicOrderEstimateDataSourceConfig = `
+data "ibm_classic_order_estimate" "estimate" {
+  package_key_name = "ADDITIONAL_SERVICES_NETWORK_VLAN"
+  datacenter       = "dal09"
+  complex_type     = "SoftLayer_Container_Product_Order_Network_Vlan"
+  hourly_billing   = false
+  item_key_names   = ["PUBLIC_NETWORK_VLAN"]
+}
+`
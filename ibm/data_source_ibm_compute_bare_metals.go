@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const bareMetalsMask = "id,hostname,domain,globalIdentifier,primaryIpAddress,primaryBackendIpAddress," +
+	"datacenter[name],hardwareStatus[status],tagReferences[tag[name]]," +
+	"primaryNetworkComponent[networkVlan[id]],primaryBackendNetworkComponent[networkVlan[id]]"
+
+func dataSourceIBMComputeBareMetals() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMComputeBareMetalsRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return bare metal servers in this datacenter",
+			},
+			"hostname_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return bare metal servers whose hostname matches this regular expression",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Only return bare metal servers with any of these tags",
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return bare metal servers attached to this VLAN",
+			},
+			"power_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"on", "off"}, false),
+				Description:  "Only return bare metal servers in this power state",
+			},
+			"bare_metals": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Bare metal servers matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the bare metal server",
+						},
+						"global_identifier": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The unique global identifier of the bare metal server",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname of the bare metal server",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The domain of the bare metal server",
+						},
+						"datacenter": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Datacenter in which the bare metal server is deployed",
+						},
+						"public_ipv4_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public IPv4 address of the bare metal server",
+						},
+						"private_ipv4_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The private IPv4 address of the bare metal server",
+						},
+						"public_vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The public VLAN of the bare metal server",
+						},
+						"private_vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The private VLAN of the bare metal server",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hardware status of the bare metal server",
+						},
+						"tags": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "Tags of the bare metal server",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMComputeBareMetalsRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+
+	bms := []datatypes.Hardware{}
+	for offset := 0; ; offset += classicInventoryPageSize {
+		page, err := services.GetAccountService(sess).
+			Filter(filter.Build(classicInventoryFilters(d, "hardware")...)).
+			Mask(bareMetalsMask).
+			Limit(classicInventoryPageSize).
+			Offset(offset).
+			GetHardware()
+		if err != nil {
+			return fmt.Errorf("Error retrieving bare metal servers: %s", err)
+		}
+		bms = append(bms, page...)
+		if len(page) < classicInventoryPageSize {
+			break
+		}
+	}
+
+	hostnameRegex, err := classicInventoryHostnameRegex(d)
+	if err != nil {
+		return err
+	}
+	powerState := d.Get("power_state").(string)
+
+	bareMetals := make([]map[string]interface{}, 0, len(bms))
+	for _, bm := range bms {
+		if hostnameRegex != nil && (bm.Hostname == nil || !hostnameRegex.MatchString(*bm.Hostname)) {
+			continue
+		}
+		// The power state of hardware is not an object property, it has to be asked per server.
+		if powerState != "" {
+			state, err := services.GetHardwareServerService(sess).Id(*bm.Id).GetServerPowerState()
+			if err != nil {
+				return fmt.Errorf("Error retrieving power state of bare metal server %d: %s", *bm.Id, err)
+			}
+			if state != powerState {
+				continue
+			}
+		}
+		bareMetals = append(bareMetals, flattenBareMetalInventory(bm))
+	}
+
+	d.SetId(time.Now().UTC().String())
+	d.Set("bare_metals", bareMetals)
+
+	return nil
+}
+
+func flattenBareMetalInventory(bm datatypes.Hardware) map[string]interface{} {
+	bareMetal := map[string]interface{}{
+		"id":                   *bm.Id,
+		"global_identifier":    sl.Get(bm.GlobalIdentifier, ""),
+		"hostname":             sl.Get(bm.Hostname, ""),
+		"domain":               sl.Get(bm.Domain, ""),
+		"public_ipv4_address":  sl.Get(bm.PrimaryIpAddress, ""),
+		"private_ipv4_address": sl.Get(bm.PrimaryBackendIpAddress, ""),
+		"tags":                 schema.NewSet(schema.HashString, flattenClassicTagReferences(bm.TagReferences)),
+	}
+	if bm.Datacenter != nil {
+		bareMetal["datacenter"] = sl.Get(bm.Datacenter.Name, "")
+	}
+	if bm.HardwareStatus != nil {
+		bareMetal["status"] = sl.Get(bm.HardwareStatus.Status, "")
+	}
+	if bm.PrimaryNetworkComponent != nil && bm.PrimaryNetworkComponent.NetworkVlan != nil {
+		bareMetal["public_vlan_id"] = sl.Get(bm.PrimaryNetworkComponent.NetworkVlan.Id, 0)
+	}
+	if bm.PrimaryBackendNetworkComponent != nil && bm.PrimaryBackendNetworkComponent.NetworkVlan != nil {
+		bareMetal["private_vlan_id"] = sl.Get(bm.PrimaryBackendNetworkComponent.NetworkVlan.Id, 0)
+	}
+	return bareMetal
+}
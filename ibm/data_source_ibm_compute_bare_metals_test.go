@@ -0,0 +1,59 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMComputeBareMetalsDataSource_basic(t *testing.T) {
+	configName := "data.ibm_compute_bare_metals.tf-bms-ds-acc-test"
+	hostname := acctest.RandString(16)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMComputeBareMetalsDataSourceConfigBasic(hostname),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						configName, "bare_metals.#", "1"),
+					resource.TestCheckResourceAttr(
+						configName, "bare_metals.0.hostname", hostname),
+					resource.TestCheckResourceAttr(
+						configName, "bare_metals.0.datacenter", "dal01"),
+					resource.TestCheckResourceAttrPair(
+						configName, "bare_metals.0.id", "ibm_compute_bare_metal.terraform-acceptance-test-1", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMComputeBareMetalsDataSourceConfigBasic(hostname string) string {
+	return fmt.Sprintf(`
+		resource "ibm_compute_bare_metal" "terraform-acceptance-test-1" {
+			hostname               = "%[1]s"
+			domain                 = "terraformuat.ibm.com"
+			os_reference_code      = "UBUNTU_16_64"
+			datacenter             = "dal01"
+			network_speed          = 100
+			hourly_billing         = true
+			private_network_only   = false
+			fixed_config_preset    = "S1270_32GB_1X1TBSATA_NORAID"
+			tags                   = ["%[1]s"]
+			}
+			data "ibm_compute_bare_metals" "tf-bms-ds-acc-test" {
+				datacenter     = ibm_compute_bare_metal.terraform-acceptance-test-1.datacenter
+				hostname_regex = "^%[1]s$"
+				tags           = ibm_compute_bare_metal.terraform-acceptance-test-1.tags
+				power_state    = "on"
+			}`, hostname)
+
+}
@@ -0,0 +1,242 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/filter"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const virtualGuestsMask = "id,hostname,domain,primaryIpAddress,primaryBackendIpAddress," +
+	"datacenter[name],powerState[keyName],status[keyName],tagReferences[tag[name]]," +
+	"primaryNetworkComponent[networkVlan[id]],primaryBackendNetworkComponent[networkVlan[id]]"
+
+// classicInventoryPageSize is the number of objects requested per page of the virtual guest and hardware lists.
+const classicInventoryPageSize = 100
+
+func dataSourceIBMComputeVmInstances() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMComputeVmInstancesRead,
+
+		Schema: map[string]*schema.Schema{
+			"datacenter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return virtual guests in this datacenter",
+			},
+			"hostname_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				Description:  "Only return virtual guests whose hostname matches this regular expression",
+			},
+			"tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Only return virtual guests with any of these tags",
+			},
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Only return virtual guests attached to this VLAN",
+			},
+			"power_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"RUNNING", "HALTED", "PAUSED"}, false),
+				Description:  "Only return virtual guests in this power state",
+			},
+			"instances": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Virtual guests matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the virtual guest",
+						},
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname of the virtual guest",
+						},
+						"domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The domain of the virtual guest",
+						},
+						"datacenter": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Datacenter in which the virtual guest is deployed",
+						},
+						"ipv4_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The public IPv4 address of the virtual guest",
+						},
+						"ipv4_address_private": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The private IPv4 address of the virtual guest",
+						},
+						"public_vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The public VLAN of the virtual guest",
+						},
+						"private_vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The private VLAN of the virtual guest",
+						},
+						"power_state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The current power state of the virtual guest",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the virtual guest",
+						},
+						"tags": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Set:         schema.HashString,
+							Description: "Tags of the virtual guest",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMComputeVmInstancesRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+
+	filters := classicInventoryFilters(d, "virtualGuests")
+	if powerState, ok := d.GetOk("power_state"); ok {
+		filters = append(filters, filter.Path("virtualGuests.powerState.keyName").Eq(powerState.(string)))
+	}
+
+	vgs := []datatypes.Virtual_Guest{}
+	for offset := 0; ; offset += classicInventoryPageSize {
+		page, err := services.GetAccountService(sess).
+			Filter(filter.Build(filters...)).
+			Mask(virtualGuestsMask).
+			Limit(classicInventoryPageSize).
+			Offset(offset).
+			GetVirtualGuests()
+		if err != nil {
+			return fmt.Errorf("Error retrieving virtual guests: %s", err)
+		}
+		vgs = append(vgs, page...)
+		if len(page) < classicInventoryPageSize {
+			break
+		}
+	}
+
+	hostnameRegex, err := classicInventoryHostnameRegex(d)
+	if err != nil {
+		return err
+	}
+
+	instances := make([]map[string]interface{}, 0, len(vgs))
+	for _, vg := range vgs {
+		if hostnameRegex != nil && (vg.Hostname == nil || !hostnameRegex.MatchString(*vg.Hostname)) {
+			continue
+		}
+		instances = append(instances, flattenVirtualGuestInventory(vg))
+	}
+
+	d.SetId(time.Now().UTC().String())
+	d.Set("instances", instances)
+
+	return nil
+}
+
+// classicInventoryFilters builds the object filters shared by the virtual guest and hardware lists.
+// prefix is the account relational property, e.g. virtualGuests or hardware. The lists are ordered
+// by ID, since SoftLayer does not keep the order between pages otherwise.
+func classicInventoryFilters(d *schema.ResourceData, prefix string) []filter.Filter {
+	filters := []filter.Filter{
+		{Path: prefix + ".id", Op: "orderBy", Opts: map[string]interface{}{"sort": []string{"ASC"}}},
+	}
+	if datacenter, ok := d.GetOk("datacenter"); ok {
+		filters = append(filters, filter.Path(prefix+".datacenter.name").Eq(datacenter.(string)))
+	}
+	if vlanID, ok := d.GetOk("vlan_id"); ok {
+		filters = append(filters, filter.Path(prefix+".networkVlans.id").Eq(vlanID.(int)))
+	}
+	if tags, ok := d.GetOk("tags"); ok {
+		filters = append(filters, filter.Path(prefix+".tagReferences.tag.name").In(tags.(*schema.Set).List()...))
+	}
+	return filters
+}
+
+// classicInventoryHostnameRegex compiles hostname_regex. Object filters have no regular
+// expression operation, so the expression is matched against the filtered result.
+func classicInventoryHostnameRegex(d *schema.ResourceData) (*regexp.Regexp, error) {
+	hostnameRegex, ok := d.GetOk("hostname_regex")
+	if !ok {
+		return nil, nil
+	}
+	r, err := regexp.Compile(hostnameRegex.(string))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid hostname_regex %q: %s", hostnameRegex.(string), err)
+	}
+	return r, nil
+}
+
+func flattenClassicTagReferences(tagReferences []datatypes.Tag_Reference) []interface{} {
+	tags := make([]interface{}, 0, len(tagReferences))
+	for _, tagReference := range tagReferences {
+		if tagReference.Tag != nil && tagReference.Tag.Name != nil {
+			tags = append(tags, *tagReference.Tag.Name)
+		}
+	}
+	return tags
+}
+
+func flattenVirtualGuestInventory(vg datatypes.Virtual_Guest) map[string]interface{} {
+	instance := map[string]interface{}{
+		"id":                   *vg.Id,
+		"hostname":             sl.Get(vg.Hostname, ""),
+		"domain":               sl.Get(vg.Domain, ""),
+		"ipv4_address":         sl.Get(vg.PrimaryIpAddress, ""),
+		"ipv4_address_private": sl.Get(vg.PrimaryBackendIpAddress, ""),
+		"tags":                 schema.NewSet(schema.HashString, flattenClassicTagReferences(vg.TagReferences)),
+	}
+	if vg.Datacenter != nil {
+		instance["datacenter"] = sl.Get(vg.Datacenter.Name, "")
+	}
+	if vg.PowerState != nil {
+		instance["power_state"] = sl.Get(vg.PowerState.KeyName, "")
+	}
+	if vg.Status != nil {
+		instance["status"] = sl.Get(vg.Status.KeyName, "")
+	}
+	if vg.PrimaryNetworkComponent != nil && vg.PrimaryNetworkComponent.NetworkVlan != nil {
+		instance["public_vlan_id"] = sl.Get(vg.PrimaryNetworkComponent.NetworkVlan.Id, 0)
+	}
+	if vg.PrimaryBackendNetworkComponent != nil && vg.PrimaryBackendNetworkComponent.NetworkVlan != nil {
+		instance["private_vlan_id"] = sl.Get(vg.PrimaryBackendNetworkComponent.NetworkVlan.Id, 0)
+	}
+	return instance
+}
@@ -0,0 +1,71 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/filter"
+	"gotest.tools/assert"
+)
+
+func TestAccIBMComputeVmInstancesDataSource_basic(t *testing.T) {
+	hostname := acctest.RandString(16)
+	domain := "dss.terraform.ibm.com"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMComputeVmInstancesDataSourceConfigBasic(hostname, domain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_compute_vm_instances.tf-vgs-ds-acc-test", "instances.#", "1"),
+					resource.TestCheckResourceAttr("data.ibm_compute_vm_instances.tf-vgs-ds-acc-test", "instances.0.hostname", hostname),
+					resource.TestCheckResourceAttr("data.ibm_compute_vm_instances.tf-vgs-ds-acc-test", "instances.0.datacenter", "dal06"),
+					resource.TestCheckResourceAttr("data.ibm_compute_vm_instances.tf-vgs-ds-acc-test", "instances.0.power_state", "RUNNING"),
+					resource.TestCheckResourceAttrPair("data.ibm_compute_vm_instances.tf-vgs-ds-acc-test", "instances.0.id",
+						"ibm_compute_vm_instance.tf-vgs-acc-test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestClassicInventoryFilters(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceIBMComputeVmInstances().Schema, map[string]interface{}{
+		"datacenter": "dal06",
+	})
+
+	assert.Equal(t, filter.Build(classicInventoryFilters(d, "virtualGuests")...),
+		`{"virtualGuests":{"datacenter":{"name":{"operation":"dal06"}},"id":{"operation":"orderBy","options":[{"name":"sort","value":["ASC"]}]}}}`)
+}
+
+func testAccCheckIBMComputeVmInstancesDataSourceConfigBasic(hostname, domain string) string {
+	return fmt.Sprintf(`
+resource "ibm_compute_vm_instance" "tf-vgs-acc-test" {
+    hostname = "%[1]s"
+    domain = "%[2]s"
+    os_reference_code = "DEBIAN_9_64"
+    datacenter = "dal06"
+    network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    tags = ["%[1]s"]
+    local_disk = false
+}
+data "ibm_compute_vm_instances" "tf-vgs-ds-acc-test" {
+    datacenter     = ibm_compute_vm_instance.tf-vgs-acc-test.datacenter
+    hostname_regex = "^%[1]s$"
+    tags           = ibm_compute_vm_instance.tf-vgs-acc-test.tags
+    power_state    = "RUNNING"
+}`, hostname, domain)
+}
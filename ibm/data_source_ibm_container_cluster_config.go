@@ -4,14 +4,22 @@
 package ibm
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"log"
+	"net/url"
+	"path"
 	"path/filepath"
+	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	homedir "github.com/mitchellh/go-homedir"
 
 	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
 	"github.com/IBM-Cloud/bluemix-go/helpers"
 )
 
@@ -79,6 +87,31 @@ func dataSourceIBMContainerClusterConfig() *schema.Resource {
 				Optional:    true,
 				Default:     false,
 			},
+			"in_memory": {
+				Description:   "If set to true, the cluster config is returned in memory and nothing is written to disk",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"config_dir", "network"},
+			},
+			"endpoint_type": {
+				Description:  "The endpoint of the cluster API server returned in host: public, private or vpe",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateAllowedStringValue([]string{"public", "private", "vpe"}),
+			},
+			"exec_command": {
+				Description: "The command the exec plugin runs to get a cluster token, exec is only returned when it is set",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"exec_args": {
+				Description:  "The arguments of exec_command",
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				RequiredWith: []string{"exec_command"},
+			},
 			"config_file_path": {
 				Description: "The absolute path to the kubernetes config yml file ",
 				Type:        schema.TypeString,
@@ -110,6 +143,33 @@ func dataSourceIBMContainerClusterConfig() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"exec": {
+				Description: "Exec plugin config that refreshes the cluster token through IAM",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"command": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"args": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"env": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -126,6 +186,23 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 	configDir := d.Get("config_dir").(string)
 	network := d.Get("network").(bool)
 
+	if d.Get("in_memory").(bool) {
+		targetEnv, err := getClusterTargetHeader(d, meta)
+		if err != nil {
+			return err
+		}
+		clusterKeyDetails, err := getClusterConfigInMemory(meta, name, admin, targetEnv)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the cluster config [%s]: %s", name, err)
+		}
+		d.Set("admin_key", clusterKeyDetails.AdminKey)
+		d.Set("admin_certificate", clusterKeyDetails.Admin)
+		d.Set("ca_certificate", clusterKeyDetails.ClusterCACertificate)
+		d.Set("host", clusterKeyDetails.Host)
+		d.Set("token", clusterKeyDetails.Token)
+		return setClusterConfigEndpoint(d, meta, name)
+	}
+
 	if len(configDir) == 0 {
 		configDir, err = homedir.Dir()
 		if err != nil {
@@ -177,7 +254,191 @@ func dataSourceIBMContainerClusterConfigRead(d *schema.ResourceData, meta interf
 		}
 	}
 
-	d.SetId(name)
 	d.Set("config_dir", configDir)
+	return setClusterConfigEndpoint(d, meta, name)
+}
+
+// setClusterConfigEndpoint sets the host of the selected endpoint type and the exec plugin config
+func setClusterConfigEndpoint(d *schema.ResourceData, meta interface{}, name string) error {
+	if endpointType, ok := d.GetOk("endpoint_type"); ok {
+		csClient, err := meta.(ClientSession).VpcContainerAPI()
+		if err != nil {
+			return err
+		}
+		targetEnv, err := getVpcClusterTargetHeader(d, meta)
+		if err != nil {
+			return err
+		}
+		cls, err := csClient.Clusters().GetCluster(name, targetEnv)
+		if err != nil {
+			return fmt.Errorf("Error retrieving cluster [%s]: %s", name, err)
+		}
+		host, err := clusterEndpointHost(cls, endpointType.(string))
+		if err != nil {
+			return err
+		}
+		d.Set("host", host)
+	}
+
+	exec, err := clusterExecConfig(meta, d.Get("exec_command").(string), expandStringList(d.Get("exec_args").([]interface{})))
+	if err != nil {
+		return err
+	}
+	d.Set("exec", exec)
+	d.SetId(name)
 	return nil
 }
+
+// getClusterConfigInMemory downloads the cluster config zip and reads it without writing it to disk
+func getClusterConfigInMemory(meta interface{}, name string, admin bool, target v1.ClusterTargetHeader) (v1.ClusterKeyInfo, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return v1.ClusterKeyInfo{}, err
+	}
+	rawURL := fmt.Sprintf("/v1/clusters/%s/config", name)
+	if admin {
+		rawURL += "/admin"
+	}
+	var config bytes.Buffer
+	if _, err := restClient.Get(rawURL, &config, target.ToMap()); err != nil {
+		return v1.ClusterKeyInfo{}, err
+	}
+	return parseClusterConfigZip(config.Bytes())
+}
+
+type clusterKubeConfig struct {
+	Clusters []struct {
+		Cluster struct {
+			Server string `json:"server"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Users []struct {
+		User struct {
+			Token        string `json:"token"`
+			AuthProvider struct {
+				Config struct {
+					IDToken string `json:"id-token"`
+				} `json:"config"`
+			} `json:"auth-provider"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+func parseClusterConfigZip(data []byte) (v1.ClusterKeyInfo, error) {
+	clusterKey := v1.ClusterKeyInfo{}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return clusterKey, err
+	}
+
+	var kubeConfig []byte
+	for _, f := range archive.File {
+		fileName := path.Base(f.Name)
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return clusterKey, err
+		}
+		content, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return clusterKey, err
+		}
+		switch {
+		case fileName == "admin-key.pem":
+			clusterKey.AdminKey = string(content)
+		case fileName == "admin.pem":
+			clusterKey.Admin = string(content)
+		case strings.HasPrefix(fileName, "ca-") && strings.HasSuffix(fileName, ".pem"):
+			clusterKey.ClusterCACertificate = string(content)
+		case strings.HasSuffix(fileName, ".yml"):
+			kubeConfig = content
+		}
+	}
+	if kubeConfig == nil {
+		return clusterKey, fmt.Errorf("Unable to locate kube config in zip archive")
+	}
+
+	var config clusterKubeConfig
+	if err := yaml.Unmarshal(kubeConfig, &config); err != nil {
+		return clusterKey, fmt.Errorf("Error parsing the kube config: %s", err)
+	}
+	if len(config.Clusters) != 0 {
+		clusterKey.Host = config.Clusters[0].Cluster.Server
+	}
+	if len(config.Users) != 0 {
+		clusterKey.Token = config.Users[0].User.AuthProvider.Config.IDToken
+		if clusterKey.Token == "" {
+			clusterKey.Token = config.Users[0].User.Token
+		}
+	}
+	return clusterKey, nil
+}
+
+// clusterEndpointHost returns the URL of the cluster API server for the endpoint type. The
+// virtual private endpoint of a VPC cluster is served on the port of the private service endpoint.
+func clusterEndpointHost(cls *v2.ClusterInfo, endpointType string) (string, error) {
+	switch endpointType {
+	case "public":
+		if !cls.ServiceEndpoints.PublicServiceEndpointEnabled || cls.ServiceEndpoints.PublicServiceEndpointURL == "" {
+			return "", fmt.Errorf("The public service endpoint of cluster [%s] is not enabled", cls.Name)
+		}
+		return cls.ServiceEndpoints.PublicServiceEndpointURL, nil
+	case "private":
+		if !cls.ServiceEndpoints.PrivateServiceEndpointEnabled || cls.ServiceEndpoints.PrivateServiceEndpointURL == "" {
+			return "", fmt.Errorf("The private service endpoint of cluster [%s] is not enabled", cls.Name)
+		}
+		return cls.ServiceEndpoints.PrivateServiceEndpointURL, nil
+	case "vpe":
+		if !strings.HasPrefix(cls.Provider, "vpc") {
+			return "", fmt.Errorf("The virtual private endpoint is only available for VPC clusters, cluster [%s] is %s", cls.Name, cls.Provider)
+		}
+		privateURL, err := url.Parse(cls.ServiceEndpoints.PrivateServiceEndpointURL)
+		if err != nil || privateURL.Hostname() == "" {
+			return "", fmt.Errorf("The private service endpoint of cluster [%s] is not available", cls.Name)
+		}
+		// c100.private.us-south.containers.cloud.ibm.com is served as <cluster ID>.vpe.private.us-south.containers.cloud.ibm.com
+		domain := privateURL.Hostname()
+		if i := strings.Index(domain, "."); i >= 0 {
+			domain = domain[i+1:]
+		}
+		host := fmt.Sprintf("%s.vpe.%s", cls.ID, domain)
+		if port := privateURL.Port(); port != "" {
+			host = fmt.Sprintf("%s:%s", host, port)
+		}
+		return fmt.Sprintf("%s://%s", privateURL.Scheme, host), nil
+	}
+	return "", fmt.Errorf("Unsupported endpoint type %s", endpointType)
+}
+
+const kubeExecCredentialAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+// clusterExecConfig returns the exec plugin config that runs command to get a cluster token. The
+// command gets its credential from the environment it runs in, such as IC_API_KEY, IBMCLOUD_API_KEY
+// or a trusted profile, so that no credential is stored in the state. It gets the IAM endpoint of
+// the provider session in IBMCLOUD_IAM_API_ENDPOINT.
+func clusterExecConfig(meta interface{}, command string, args []string) ([]map[string]interface{}, error) {
+	if command == "" {
+		return []map[string]interface{}{}, nil
+	}
+	sess, err := meta.(ClientSession).BluemixSession()
+	if err != nil {
+		return nil, err
+	}
+	var iamEndpoint string
+	if sess.Config.TokenProviderEndpoint != nil {
+		iamEndpoint = *sess.Config.TokenProviderEndpoint
+	} else if iamEndpoint, err = sess.Config.EndpointLocator.IAMEndpoint(); err != nil {
+		return nil, err
+	}
+	return []map[string]interface{}{
+		{
+			"api_version": kubeExecCredentialAPIVersion,
+			"command":     command,
+			"args":        args,
+			"env":         map[string]string{"IBMCLOUD_IAM_API_ENDPOINT": iamEndpoint},
+		},
+	}, nil
+}
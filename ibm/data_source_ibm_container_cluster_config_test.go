@@ -4,12 +4,16 @@
 package ibm
 
 import (
+	"archive/zip"
+	"bytes"
 	"fmt"
 	"testing"
 
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/mitchellh/go-homedir"
+	"gotest.tools/assert"
 )
 
 func TestAccIBMContainer_ClusterConfigDataSourceBasic(t *testing.T) {
@@ -58,6 +62,35 @@ func TestAccIBMContainer_ClusterConfigCalicoDataSourceBasic(t *testing.T) {
 	})
 }
 
+func TestAccIBMContainer_ClusterConfigInMemoryDataSourceBasic(t *testing.T) {
+	clusterName := fmt.Sprintf("tf-cluster-config-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerClusterInMemoryConfigDataSource(clusterName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "config_file_path", ""),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "host"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "ca_certificate"),
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "exec.0.command", "ibm-kube-token"),
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "exec.0.args.1", clusterName),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "exec.0.env.IBMCLOUD_IAM_API_ENDPOINT"),
+					resource.TestCheckNoResourceAttr(
+						"data.ibm_container_cluster_config.testacc_ds_cluster", "exec.0.env.IC_API_KEY"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckIBMContainerClusterDataSourceConfig(clustername string) string {
 	return fmt.Sprintf(`
 resource "ibm_container_cluster" "testacc_cluster" {
@@ -92,3 +125,95 @@ data "ibm_container_cluster_config" "testacc_ds_cluster" {
   network         = true
 }`, clustername, datacenter, machineType, publicVlanID, privateVlanID)
 }
+
+func testAccCheckIBMContainerClusterInMemoryConfigDataSource(clustername string) string {
+	return fmt.Sprintf(`
+resource "ibm_container_cluster" "testacc_cluster" {
+  name            = "%s"
+  datacenter      = "%s"
+  machine_type    = "%s"
+  hardware        = "shared"
+  wait_till       = "MasterNodeReady"
+  public_vlan_id  = "%s"
+  private_vlan_id = "%s"
+}
+
+data "ibm_container_cluster_config" "testacc_ds_cluster" {
+  cluster_name_id = ibm_container_cluster.testacc_cluster.id
+  in_memory       = true
+  endpoint_type   = "public"
+  exec_command    = "ibm-kube-token"
+  exec_args       = ["--cluster", ibm_container_cluster.testacc_cluster.name]
+}`, clustername, datacenter, machineType, publicVlanID, privateVlanID)
+}
+
+func TestParseClusterConfigZip(t *testing.T) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	files := map[string]string{
+		"kubeConfig123/ca-dal10-mycluster.pem": "CA",
+		"kubeConfig123/admin.pem":              "CERT",
+		"kubeConfig123/admin-key.pem":          "KEY",
+		"kubeConfig123/kube-config-dal10-mycluster.yml": `
+apiVersion: v1
+clusters:
+- name: mycluster
+  cluster:
+    certificate-authority: ca-dal10-mycluster.pem
+    server: https://c100.us-south.containers.cloud.ibm.com:30245
+users:
+- name: user
+  user:
+    auth-provider:
+      name: oidc
+      config:
+        id-token: TOKEN
+`,
+	}
+	for name, content := range files {
+		w, err := archive.Create(name)
+		assert.NilError(t, err)
+		w.Write([]byte(content))
+	}
+	assert.NilError(t, archive.Close())
+
+	config, err := parseClusterConfigZip(buf.Bytes())
+	assert.NilError(t, err)
+	assert.Equal(t, "CA", config.ClusterCACertificate)
+	assert.Equal(t, "CERT", config.Admin)
+	assert.Equal(t, "KEY", config.AdminKey)
+	assert.Equal(t, "https://c100.us-south.containers.cloud.ibm.com:30245", config.Host)
+	assert.Equal(t, "TOKEN", config.Token)
+	assert.Equal(t, config.FilePath, "")
+}
+
+func TestClusterEndpointHost(t *testing.T) {
+	cls := &v2.ClusterInfo{
+		ID:       "c0a1b2",
+		Name:     "mycluster",
+		Provider: "vpc-gen2",
+		ServiceEndpoints: v2.Endpoints{
+			PublicServiceEndpointEnabled:  true,
+			PublicServiceEndpointURL:      "https://c100.us-south.containers.cloud.ibm.com:30245",
+			PrivateServiceEndpointEnabled: true,
+			PrivateServiceEndpointURL:     "https://c100.private.us-south.containers.cloud.ibm.com:30245",
+		},
+	}
+
+	host, err := clusterEndpointHost(cls, "public")
+	assert.NilError(t, err)
+	assert.Equal(t, "https://c100.us-south.containers.cloud.ibm.com:30245", host)
+	host, err = clusterEndpointHost(cls, "private")
+	assert.NilError(t, err)
+	assert.Equal(t, "https://c100.private.us-south.containers.cloud.ibm.com:30245", host)
+	host, err = clusterEndpointHost(cls, "vpe")
+	assert.NilError(t, err)
+	assert.Equal(t, "https://c0a1b2.vpe.private.us-south.containers.cloud.ibm.com:30245", host)
+
+	cls.Provider = "classic"
+	_, err = clusterEndpointHost(cls, "vpe")
+	assert.Assert(t, err != nil)
+	cls.ServiceEndpoints.PublicServiceEndpointEnabled = false
+	_, err = clusterEndpointHost(cls, "public")
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,79 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIBMContainerDedicatedHost() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMContainerDedicatedHostRead,
+
+		Schema: map[string]*schema.Schema{
+			"host_pool_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the dedicated host pool",
+			},
+			"host_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the dedicated host",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the resource group.",
+			},
+			"flavor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The flavor of the dedicated host",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The zone of the dedicated host",
+			},
+			"placement_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "New workers can be placed on the dedicated host",
+			},
+			"life_cycle": dedicatedHostLifecycleSchema(),
+			"resources":  dedicatedHostResourcesSchema(),
+			"workers":    dedicatedHostWorkersSchema(),
+		},
+	}
+}
+
+func dataSourceIBMContainerDedicatedHostRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	hostPoolID := d.Get("host_pool_id").(string)
+	hostID := d.Get("host_id").(string)
+	host, err := getDedicatedHost(restClient, hostPoolID, hostID, targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving dedicated host (%s) of dedicated host pool (%s): %s", hostID, hostPoolID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", hostPoolID, host.ID))
+	d.Set("flavor", host.Flavor)
+	d.Set("zone", host.Zone)
+	d.Set("placement_enabled", host.PlacementEnabled)
+	d.Set("life_cycle", flattenDedicatedHostLifecycle(host.Lifecycle))
+	d.Set("resources", flattenDedicatedHostResources(host.Resources))
+	d.Set("workers", flattenDedicatedHostWorkers(host.Workers))
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIBMContainerDedicatedHostPool() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMContainerDedicatedHostPoolRead,
+
+		Schema: map[string]*schema.Schema{
+			"host_pool_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The ID of the dedicated host pool",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the resource group.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the dedicated host pool",
+			},
+			"flavor_class": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The flavor class of the dedicated hosts in the pool",
+			},
+			"metro": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The metro of the dedicated host pool",
+			},
+			"host_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of dedicated hosts in the pool",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the dedicated host pool",
+			},
+			"zones":        dedicatedHostPoolZonesSchema(),
+			"worker_pools": dedicatedHostPoolWorkerPoolsSchema(),
+		},
+	}
+}
+
+func dataSourceIBMContainerDedicatedHostPoolRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	hostPoolID := d.Get("host_pool_id").(string)
+	hostPool, err := getDedicatedHostPool(restClient, hostPoolID, targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving dedicated host pool (%s): %s", hostPoolID, err)
+	}
+
+	d.SetId(hostPool.ID)
+	d.Set("name", hostPool.Name)
+	d.Set("flavor_class", hostPool.FlavorClass)
+	d.Set("metro", hostPool.Metro)
+	d.Set("host_count", hostPool.HostCount)
+	d.Set("state", hostPool.State)
+	d.Set("zones", flattenDedicatedHostPoolZones(hostPool.Zones))
+	d.Set("worker_pools", flattenDedicatedHostPoolWorkerPools(hostPool.WorkerPools))
+	return nil
+}
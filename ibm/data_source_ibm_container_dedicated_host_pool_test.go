@@ -0,0 +1,38 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMContainerDedicatedHostPoolDataSource_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf-hostpool-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerDedicatedHostPoolDataSource(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_dedicated_host_pool.pool", "name", name),
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_dedicated_host_pool.pool", "metro", "fra"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerDedicatedHostPoolDataSource(name string) string {
+	return testAccCheckIBMContainerDedicatedHostPoolBasic(name) + `
+data "ibm_container_dedicated_host_pool" "pool" {
+  host_pool_id = ibm_container_dedicated_host_pool.pool.id
+}`
+}
@@ -0,0 +1,39 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMContainerDedicatedHostDataSource_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf-host-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerDedicatedHostDataSource(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ibm_container_dedicated_host.host", "zone", "eu-de-1"),
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_dedicated_host.host", "resources.0.capacity.0.vcpu"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerDedicatedHostDataSource(name string) string {
+	return testAccCheckIBMContainerDedicatedHostBasic(name, true) + `
+data "ibm_container_dedicated_host" "host" {
+  host_pool_id = ibm_container_dedicated_host.host.host_pool_id
+  host_id      = ibm_container_dedicated_host.host.host_id
+}`
+}
@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIBMContainerNlbDNS() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMContainerNlbDNSRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cluster Name or ID",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the resource group.",
+			},
+			"nlb_config": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The network load balancer subdomains of the cluster",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"nlb_host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subdomain",
+						},
+						"nlb_ips": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The network load balancer IPs registered with the subdomain",
+						},
+						"lb_hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The VPC load balancer hostname registered with the subdomain",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the subdomain",
+						},
+						"dns_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The DNS provider of the subdomain",
+						},
+						"secret_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the TLS secret of the subdomain",
+						},
+						"secret_namespace": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The namespace of the TLS secret of the subdomain",
+						},
+						"secret_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the TLS secret of the subdomain",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMContainerNlbDNSRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getWorkerPoolTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	cluster := d.Get("cluster").(string)
+	entries, err := getNlbDNSList(restClient, cluster, targetEnv.ToMap())
+	if err != nil {
+		return err
+	}
+
+	d.SetId(cluster)
+	d.Set("nlb_config", flattenNlbDNSList(entries))
+	return nil
+}
+
+func flattenNlbDNSList(entries []nlbDNSListEntry) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, map[string]interface{}{
+			"nlb_host":         entry.Nlb.NlbSubdomain,
+			"nlb_ips":          entry.Nlb.NlbIPArray,
+			"lb_hostname":      entry.Nlb.LBHostname,
+			"type":             entry.Nlb.Type,
+			"dns_type":         entry.Nlb.DNSType,
+			"secret_name":      entry.SecretName,
+			"secret_namespace": entry.Nlb.SecretNamespace,
+			"secret_status":    entry.SecretStatus,
+		})
+	}
+	return result
+}
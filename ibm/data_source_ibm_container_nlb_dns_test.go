@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMContainerNlbDNSDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerNlbDNSDataSource(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_nlb_dns.dns", "nlb_config.0.nlb_host"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerNlbDNSDataSource() string {
+	return fmt.Sprintf(`
+data "ibm_container_nlb_dns" "dns" {
+  cluster = "%s"
+}`, clusterNameOrID)
+}
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIBMContainerStorageAttachment() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMContainerStorageAttachmentRead,
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Cluster name or ID",
+			},
+			"worker": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the worker",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the resource group.",
+			},
+			"volume_attachments": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The VPC block volumes attached to the worker",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"volume_attachment_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the volume attachment",
+						},
+						"volume_attachment_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the volume attachment",
+						},
+						"volume": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the VPC block volume",
+						},
+						"volume_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the VPC block volume",
+						},
+						"device_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the device of the volume on the worker",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the volume attachment",
+						},
+						"volume_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the volume attachment",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMContainerStorageAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	cluster := d.Get("cluster").(string)
+	worker := d.Get("worker").(string)
+	attachments, err := getStorageAttachments(restClient, cluster, worker, targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving the volume attachments of worker (%s) of cluster (%s): %s", worker, cluster, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", cluster, worker))
+	d.Set("volume_attachments", flattenStorageAttachments(attachments))
+	return nil
+}
+
+func flattenStorageAttachments(attachments []storageAttachment) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(attachments))
+	for _, attachment := range attachments {
+		result = append(result, map[string]interface{}{
+			"volume_attachment_id":   attachment.ID,
+			"volume_attachment_name": attachment.Name,
+			"volume":                 attachment.Volume.ID,
+			"volume_name":            attachment.Volume.Name,
+			"device_id":              attachment.Device.ID,
+			"status":                 attachment.Status,
+			"volume_type":            attachment.Type,
+		})
+	}
+	return result
+}
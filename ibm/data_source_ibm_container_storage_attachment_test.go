@@ -0,0 +1,37 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMContainerStorageAttachmentDataSource_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf-volume-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerStorageAttachmentDataSource(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"data.ibm_container_storage_attachment.attachments", "volume_attachments.0.volume_attachment_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerStorageAttachmentDataSource(name string) string {
+	return testAccCheckIBMContainerStorageAttachmentBasic(name) + `
+data "ibm_container_storage_attachment" "attachments" {
+  cluster = ibm_container_storage_attachment.attachment.cluster
+  worker  = ibm_container_storage_attachment.attachment.worker
+}`
+}
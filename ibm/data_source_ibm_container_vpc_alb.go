@@ -58,6 +58,10 @@ func dataSourceIBMContainerVPCClusterALB() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
@@ -87,6 +91,7 @@ func dataSourceIBMContainerVpcALBRead(d *schema.ResourceData, meta interface{})
 	d.Set("status", albConfig.Status)
 	d.Set("state", albConfig.State)
 	d.Set("load_balancer_hostname", albConfig.LoadBalancerHostname)
+	d.Set("version", albConfig.AlbBuild)
 	d.SetId(albID)
 	return nil
 }
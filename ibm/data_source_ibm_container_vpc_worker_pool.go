@@ -4,6 +4,8 @@
 package ibm
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -63,6 +65,11 @@ func dataSourceIBMContainerVpcClusterWorkerPool() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"host_pool_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the dedicated host pool that the workers of the worker pool are placed on",
+			},
 		},
 	}
 }
@@ -103,6 +110,11 @@ func dataSourceIBMContainerVpcClusterWorkerPoolRead(d *schema.ResourceData, meta
 	d.Set("cluster", clusterName)
 	d.Set("vpc_id", workerPool.VpcID)
 	d.Set("isolation", workerPool.Isolation)
+	vpcConfig, err := getVpcWorkerPoolConfig(meta, clusterName, workerPoolName, targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving the dedicated host pool of worker pool (%s) of cluster (%s): %s", workerPoolName, clusterName, err)
+	}
+	d.Set("host_pool_id", vpcConfig.HostPoolID)
 	d.Set("resource_group_id", targetEnv.ResourceGroup)
 	d.SetId(workerPool.ID)
 	return nil
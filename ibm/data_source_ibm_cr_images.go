@@ -0,0 +1,161 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	registryv1 "github.com/IBM-Cloud/bluemix-go/api/container/registryv1"
+)
+
+func dataIBMContainerRegistryImages() *schema.Resource {
+	return &schema.Resource{
+		Read: dataIBMContainerRegistryImagesRead,
+
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List the images of the namespace only",
+			},
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "List the images of the repository only, for example us.icr.io/namespace/repository",
+			},
+			"include_ibm": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Include the public images of IBM",
+			},
+			"include_private": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Include the private images of the account",
+			},
+			"images": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Container Registry Images",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Repository of the image",
+						},
+						"digest": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Digest of the image",
+						},
+						"tags": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Tags of the image",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Size of the image in bytes",
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Created Date",
+						},
+						"security_status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Vulnerability Advisor status of the image",
+						},
+						"issue_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of security issues of the image",
+						},
+						"exempt_issue_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of exempted security issues of the image",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataIBMContainerRegistryImagesRead(d *schema.ResourceData, meta interface{}) error {
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		return err
+	}
+	accountID := userDetails.userAccount
+
+	crClient, err := meta.(ClientSession).ContainerRegistryAPI()
+	if err != nil {
+		return err
+	}
+	target := registryv1.ImageTargetHeader{
+		AccountID: accountID,
+	}
+
+	params := registryv1.DefaultGetImageRequest()
+	params.IncludeIBM = d.Get("include_ibm").(bool)
+	params.IncludePrivate = d.Get("include_private").(bool)
+	params.Namespace = d.Get("namespace").(string)
+	params.Repository = d.Get("repository").(string)
+
+	response, err := crClient.Images().GetImages(*params, target)
+	if err != nil {
+		return fmt.Errorf("Error listing the container registry images: %s", err)
+	}
+
+	d.Set("images", flattenCrImages(*response))
+	d.SetId(time.Now().UTC().String())
+	return nil
+}
+
+// flattenCrImages lists an image once for each repository that it is pushed to
+func flattenCrImages(response registryv1.GetImagesResponse) []map[string]interface{} {
+	images := []map[string]interface{}{}
+	for _, img := range response {
+		for _, repoDigest := range img.RepoDigests {
+			parts := strings.SplitN(repoDigest, "@", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			name, digest := parts[0], parts[1]
+			tags := []string{}
+			// Tags are either plain or full references, which can belong to another repository
+			for _, tag := range img.DigestTags[digest] {
+				if strings.HasPrefix(tag, name+":") {
+					tag = strings.TrimPrefix(tag, name+":")
+				} else if strings.Contains(tag, "/") {
+					continue
+				}
+				tags = append(tags, tag)
+			}
+			image := map[string]interface{}{}
+			image["name"] = name
+			image["digest"] = digest
+			image["tags"] = tags
+			image["size"] = int(img.Size)
+			image["created"] = time.Unix(int64(img.Created), 0).UTC().Format(time.RFC3339)
+			image["security_status"] = img.Vulnerable
+			image["issue_count"] = img.IssueCount
+			image["exempt_issue_count"] = img.ExemptIssueCount
+			images = append(images, image)
+		}
+	}
+	return images
+}
@@ -0,0 +1,61 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+
+	registryv1 "github.com/IBM-Cloud/bluemix-go/api/container/registryv1"
+)
+
+func TestAccIBMCrImagesDataSourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCrImagesDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ibm_cr_images.images", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCrImagesDataSourceConfig() string {
+	return `
+	data "ibm_cr_images" "images" {}
+`
+}
+
+func TestFlattenCrImages(t *testing.T) {
+	response := registryv1.GetImagesResponse{}
+	err := json.Unmarshal([]byte(`[{
+		"Id": "sha256:1111",
+		"RepoDigests": ["us.icr.io/ns/app@sha256:aaaa", "us.icr.io/ns/copy@sha256:aaaa"],
+		"DigestTags": {"sha256:aaaa": ["us.icr.io/ns/app:1.0", "us.icr.io/ns/app:latest", "us.icr.io/ns/copy:2.0"]},
+		"Created": 1600000000,
+		"Size": 1024,
+		"Vulnerable": "OK",
+		"IssueCount": 0
+	}]`), &response)
+	assert.NilError(t, err)
+
+	images := flattenCrImages(response)
+	assert.Assert(t, is.Len(images, 2))
+	assert.Equal(t, "us.icr.io/ns/app", images[0]["name"])
+	assert.Equal(t, "sha256:aaaa", images[0]["digest"])
+	assert.DeepEqual(t, []string{"1.0", "latest"}, images[0]["tags"])
+	assert.Equal(t, 1024, images[0]["size"])
+	assert.Equal(t, "2020-09-13T12:26:40Z", images[0]["created"])
+	assert.Equal(t, "OK", images[0]["security_status"])
+	assert.Equal(t, "us.icr.io/ns/copy", images[1]["name"])
+	assert.DeepEqual(t, []string{"2.0"}, images[1]["tags"])
+}
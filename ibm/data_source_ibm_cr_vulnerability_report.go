@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	registryv1 "github.com/IBM-Cloud/bluemix-go/api/container/registryv1"
+)
+
+const (
+	crVulnerabilityStatusOK          = "OK"
+	crVulnerabilityStatusFail        = "FAIL"
+	crVulnerabilityStatusIncomplete  = "INCOMPLETE"
+	crVulnerabilityStatusUnsupported = "UNSUPPORTED"
+)
+
+func dataIBMContainerRegistryVulnerabilityReport() *schema.Resource {
+	return &schema.Resource{
+		Read: dataIBMContainerRegistryVulnerabilityReportRead,
+
+		Schema: map[string]*schema.Schema{
+			"image": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Image with digest, for example us.icr.io/namespace/repository@sha256:digest",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Vulnerability Advisor status of the image: OK, FAIL, INCOMPLETE or UNSUPPORTED",
+			},
+			"complete": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The scan of the image is complete",
+			},
+			"os_supported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The operating system of the image is supported by Vulnerability Advisor",
+			},
+			"crawled_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the scan",
+			},
+			"total_packages": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of scanned packages",
+			},
+			"vulnerable_packages": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of packages with vulnerabilities",
+			},
+			"configuration_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of configuration issues",
+			},
+			"vulnerabilities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Vulnerabilities of the packages of the image",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"package_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the vulnerable package",
+						},
+						"cve_ids": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "CVE IDs of the vulnerability",
+						},
+						"summary": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Summary of the vulnerability",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL of the security notice",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataIBMContainerRegistryVulnerabilityReportRead(d *schema.ResourceData, meta interface{}) error {
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		return err
+	}
+	accountID := userDetails.userAccount
+
+	crClient, err := meta.(ClientSession).ContainerRegistryAPI()
+	if err != nil {
+		return err
+	}
+	target := registryv1.ImageTargetHeader{
+		AccountID: accountID,
+	}
+
+	image := d.Get("image").(string)
+	report, err := crClient.Images().ImageVulnerabilities(image, *registryv1.DefaultImageVulnerabilitiesRequest(), target)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the vulnerability report of image (%s): %s", image, err)
+	}
+
+	d.SetId(image)
+	d.Set("status", crVulnerabilityReportStatus(report))
+	d.Set("complete", report.Metadata.Complete)
+	d.Set("os_supported", report.Metadata.OsSupported)
+	if !report.Metadata.CrawledTime.IsZero() {
+		d.Set("crawled_time", report.Metadata.CrawledTime.UTC().Format(time.RFC3339))
+	}
+	d.Set("total_packages", report.Summary.Vulnerability.TotalPackages)
+	d.Set("vulnerable_packages", report.Summary.Vulnerability.VulnerablePackages)
+	d.Set("configuration_issues", report.Summary.Secureconfig.Misconfigured)
+	d.Set("vulnerabilities", flattenCrVulnerabilities(report))
+	return nil
+}
+
+// crVulnerabilityReportStatus summarizes the report, an incomplete scan or an unsupported
+// operating system is never OK
+func crVulnerabilityReportStatus(report *registryv1.ImageVulnerabilitiesResponse) string {
+	switch {
+	case !report.Metadata.Complete:
+		return crVulnerabilityStatusIncomplete
+	case !report.Metadata.OsSupported:
+		return crVulnerabilityStatusUnsupported
+	case report.Summary.Vulnerability.VulnerablePackages > 0 || report.Summary.Secureconfig.Misconfigured > 0:
+		return crVulnerabilityStatusFail
+	}
+	return crVulnerabilityStatusOK
+}
+
+func flattenCrVulnerabilities(report *registryv1.ImageVulnerabilitiesResponse) []map[string]interface{} {
+	vulnerabilities := []map[string]interface{}{}
+	for _, pkg := range report.Detail.Vulnerability {
+		for _, v := range pkg.Vulnerabilities {
+			vulnerability := map[string]interface{}{}
+			vulnerability["package_name"] = pkg.PackageName
+			vulnerability["cve_ids"] = v.Cveid
+			vulnerability["summary"] = v.Summary
+			vulnerability["url"] = v.URL
+			vulnerabilities = append(vulnerabilities, vulnerability)
+		}
+	}
+	return vulnerabilities
+}
@@ -0,0 +1,56 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gotest.tools/assert"
+
+	registryv1 "github.com/IBM-Cloud/bluemix-go/api/container/registryv1"
+)
+
+func TestAccIBMCrVulnerabilityReportDataSourceBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCrVulnerabilityReportDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_cr_vulnerability_report.report", "image", crImageDigest),
+					resource.TestCheckResourceAttrSet("data.ibm_cr_vulnerability_report.report", "status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCrVulnerabilityReportDataSourceConfig() string {
+	return fmt.Sprintf(`
+	data "ibm_cr_vulnerability_report" "report" {
+		image = "%s"
+	}
+`, crImageDigest)
+}
+
+func TestCrVulnerabilityReportStatus(t *testing.T) {
+	report := &registryv1.ImageVulnerabilitiesResponse{}
+	assert.Equal(t, crVulnerabilityStatusIncomplete, crVulnerabilityReportStatus(report))
+
+	report.Metadata.Complete = true
+	assert.Equal(t, crVulnerabilityStatusUnsupported, crVulnerabilityReportStatus(report))
+
+	report.Metadata.OsSupported = true
+	assert.Equal(t, crVulnerabilityStatusOK, crVulnerabilityReportStatus(report))
+
+	report.Summary.Secureconfig.Misconfigured = 1
+	assert.Equal(t, crVulnerabilityStatusFail, crVulnerabilityReportStatus(report))
+
+	report.Summary.Secureconfig.Misconfigured = 0
+	report.Summary.Vulnerability.VulnerablePackages = 2
+	assert.Equal(t, crVulnerabilityStatusFail, crVulnerabilityReportStatus(report))
+}
@@ -0,0 +1,75 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func dataSourceIBMDNSDomainZoneFile() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMDNSDomainZoneFileRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the DNS domain",
+			},
+			"zone_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The resource records of the domain in BIND zone file format",
+			},
+			"records": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of resource records in the zone file",
+			},
+		},
+	}
+}
+
+func dataSourceIBMDNSDomainZoneFileRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	domainID := d.Get("domain_id").(int)
+
+	domain, err := services.GetDnsDomainService(sess).Id(domainID).Mask("id,name,serial,resourceRecords").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Domain %d: %s", domainID, err)
+	}
+
+	records := domain.ResourceRecords
+	// The SOA record comes first and the name servers follow it, as in the zone files of the DNS service
+	order := map[string]int{"soa": 0, "ns": 1}
+	sort.SliceStable(records, func(i, j int) bool {
+		rank := func(t *string) int {
+			if r, ok := order[strings.ToLower(sl.Get(t, "").(string))]; ok {
+				return r
+			}
+			return len(order)
+		}
+		return rank(records[i].Type) < rank(records[j].Type)
+	})
+
+	serial := fmt.Sprintf("%d", sl.Get(domain.Serial, 0).(int))
+	var zone strings.Builder
+	fmt.Fprintf(&zone, "$ORIGIN %s.\n", sl.Get(domain.Name, "").(string))
+	for _, record := range records {
+		zone.WriteString(formatDNSZoneRecord(record, serial))
+		zone.WriteString("\n")
+	}
+
+	d.SetId(fmt.Sprintf("%d", domainID))
+	d.Set("zone_file", zone.String())
+	d.Set("records", len(records))
+
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMDNSDomainZoneFileDataSource_Basic(t *testing.T) {
+	domainName := fmt.Sprintf("tfuatdomainz%s.ibm.com", acctest.RandString(10))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDNSDomainZoneFileDataSourceConfig(domainName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.ibm_dns_domain_zone_file.zone", "zone_file",
+						regexp.MustCompile(fmt.Sprintf(`^\$ORIGIN %s\.\n@\t\d+\tIN\tSOA\t`, regexp.QuoteMeta(domainName)))),
+					resource.TestMatchResourceAttr("data.ibm_dns_domain_zone_file.zone", "zone_file",
+						regexp.MustCompile("\nwww\t900\tIN\tA\t127.0.0.1\n")),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDNSDomainZoneFileDataSourceConfig(domainName string) string {
+	return fmt.Sprintf(`
+resource "ibm_dns_domain" "zone_file" {
+	name = "%s"
+}
+
+resource "ibm_dns_record" "www" {
+	domain_id = ibm_dns_domain.zone_file.id
+	host      = "www"
+	data      = "127.0.0.1"
+	type      = "a"
+	ttl       = 900
+}
+
+data "ibm_dns_domain_zone_file" "zone" {
+	domain_id = ibm_dns_record.www.domain_id
+}
+`, domainName)
+}
@@ -0,0 +1,97 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIBMISInstanceGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMISInstanceGroupMembershipRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"instance_group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "instance group ID",
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the instance group membership.",
+			},
+
+			"membership_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the instance group membership.",
+			},
+
+			"delete_instance_on_membership_delete": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "If set to true, when deleting the membership the instance will also be deleted.",
+			},
+
+			"instance": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the instance of the membership.",
+			},
+
+			"instance_template": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the instance template the membership was created from.",
+			},
+
+			"load_balancer_pool_member": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the load balancer pool member of the membership.",
+			},
+
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the instance group membership - deleting, failed, healthy, pending, unhealthy",
+			},
+		},
+	}
+}
+
+func dataSourceIBMISInstanceGroupMembershipRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	instanceGroupID := d.Get("instance_group").(string)
+	membershipName := d.Get("name").(string)
+
+	allrecs, err := listInstanceGroupMemberships(sess, instanceGroupID)
+	if err != nil {
+		return err
+	}
+
+	for _, instanceGroupMembership := range allrecs {
+		if *instanceGroupMembership.Name == membershipName {
+			membership := flattenInstanceGroupMembership(instanceGroupMembership)
+			d.SetId(fmt.Sprintf("%s/%s", instanceGroupID, *instanceGroupMembership.ID))
+			d.Set("membership_id", membership["id"])
+			d.Set("delete_instance_on_membership_delete", membership["delete_instance_on_membership_delete"])
+			d.Set("instance", membership["instance"])
+			d.Set("instance_template", membership["instance_template"])
+			d.Set("load_balancer_pool_member", membership["load_balancer_pool_member"])
+			d.Set("status", membership["status"])
+			return nil
+		}
+	}
+	return fmt.Errorf("Instance group membership %s not found", membershipName)
+}
@@ -0,0 +1,123 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"time"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIBMISInstanceGroupMemberships() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMISInstanceGroupMembershipsRead,
+
+		Schema: map[string]*schema.Schema{
+
+			"instance_group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "instance group ID",
+			},
+
+			"memberships": {
+				Type:        schema.TypeList,
+				Description: "List of instance group memberships",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the instance group membership.",
+						},
+
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the instance group membership.",
+						},
+
+						"delete_instance_on_membership_delete": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "If set to true, when deleting the membership the instance will also be deleted.",
+						},
+
+						"instance": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the instance of the membership.",
+						},
+
+						"instance_template": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the instance template the membership was created from.",
+						},
+
+						"load_balancer_pool_member": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the load balancer pool member of the membership.",
+						},
+
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the instance group membership - deleting, failed, healthy, pending, unhealthy",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMISInstanceGroupMembershipsRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	instanceGroupID := d.Get("instance_group").(string)
+	allrecs, err := listInstanceGroupMemberships(sess, instanceGroupID)
+	if err != nil {
+		return err
+	}
+
+	memberships := make([]map[string]interface{}, 0)
+	for _, instanceGroupMembership := range allrecs {
+		memberships = append(memberships, flattenInstanceGroupMembership(instanceGroupMembership))
+	}
+	d.Set("memberships", memberships)
+	d.SetId(dataSourceIBMISInstanceGroupMembershipsID(d))
+	return nil
+}
+
+func flattenInstanceGroupMembership(instanceGroupMembership vpcv1.InstanceGroupMembership) map[string]interface{} {
+	membership := map[string]interface{}{
+		"id":                                   *instanceGroupMembership.ID,
+		"name":                                 *instanceGroupMembership.Name,
+		"delete_instance_on_membership_delete": *instanceGroupMembership.DeleteInstanceOnMembershipDelete,
+		"status":                               *instanceGroupMembership.Status,
+	}
+	if instanceGroupMembership.Instance != nil {
+		membership["instance"] = *instanceGroupMembership.Instance.ID
+	}
+	if instanceGroupMembership.InstanceTemplate != nil {
+		membership["instance_template"] = *instanceGroupMembership.InstanceTemplate.ID
+	}
+	if instanceGroupMembership.PoolMember != nil {
+		membership["load_balancer_pool_member"] = *instanceGroupMembership.PoolMember.ID
+	}
+	return membership
+}
+
+// dataSourceIBMISInstanceGroupMembershipsID returns a reasonable ID for a instance group membership list.
+func dataSourceIBMISInstanceGroupMembershipsID(d *schema.ResourceData) string {
+	return time.Now().UTC().String()
+}
@@ -6,6 +6,7 @@ package ibm
 import (
 	"fmt"
 
+	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,6 +24,8 @@ const (
 	isReservedIPName       = "name"
 	isReservedIPOwner      = "owner"
 	isReservedIPType       = "resource_type"
+	isReservedIPTarget     = "target"
+	isReservedIPTargetType = "target_type"
 )
 
 func dataSourceIBMISReservedIP() *schema.Resource {
@@ -87,6 +90,16 @@ func dataSourceIBMISReservedIP() *schema.Resource {
 				Computed:    true,
 				Description: "The resource type.",
 			},
+			isReservedIPTarget: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Reserved IP target id.",
+			},
+			isReservedIPTargetType: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The resource type of the reserved IP target.",
+			},
 		},
 	}
 }
@@ -110,11 +123,31 @@ func dataSdataSourceIBMISReservedIPRead(d *schema.ResourceData, meta interface{}
 	}
 
 	d.SetId(*reserveIP.ID)
+	d.Set(isReservedIPAddress, *reserveIP.Address)
 	d.Set(isReservedIPAutoDelete, *reserveIP.AutoDelete)
 	d.Set(isReservedIPCreatedAt, (*reserveIP.CreatedAt).String())
 	d.Set(isReservedIPhref, *reserveIP.Href)
 	d.Set(isReservedIPName, *reserveIP.Name)
 	d.Set(isReservedIPOwner, *reserveIP.Owner)
 	d.Set(isReservedIPType, *reserveIP.ResourceType)
+	d.Set(isReservedIPTarget, flattenReservedIPTarget(reserveIP.Target))
+	d.Set(isReservedIPTargetType, flattenReservedIPTargetType(reserveIP.Target))
 	return nil // By default there should be no error
 }
+
+// flattenReservedIPTarget returns the ID of the resource a reserved IP is bound to, or an empty string if it is unbound.
+func flattenReservedIPTarget(target vpcv1.ReservedIPTargetIntf) string {
+	if t, ok := target.(*vpcv1.ReservedIPTarget); ok && t != nil && t.ID != nil {
+		return *t.ID
+	}
+	return ""
+}
+
+// flattenReservedIPTargetType returns the resource type of the resource a reserved IP is bound to, such as
+// endpoint_gateway, load_balancer or network_interface, or an empty string if it is unbound.
+func flattenReservedIPTargetType(target vpcv1.ReservedIPTargetIntf) string {
+	if t, ok := target.(*vpcv1.ReservedIPTarget); ok && t != nil && t.ResourceType != nil {
+		return *t.ResourceType
+	}
+	return ""
+}
@@ -87,6 +87,16 @@ func dataSourceIBMISReservedIPs() *schema.Resource {
 							Computed:    true,
 							Description: "The resource type.",
 						},
+						isReservedIPTarget: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Reserved IP target id.",
+						},
+						isReservedIPTargetType: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The resource type of the reserved IP target.",
+						},
 					},
 				},
 			},
@@ -140,6 +150,8 @@ func dataSdataSourceIBMISReservedIPsRead(d *schema.ResourceData, meta interface{
 		ipsOutput[isReservedIPName] = *data.Name
 		ipsOutput[isReservedIPOwner] = *data.Owner
 		ipsOutput[isReservedIPType] = *data.ResourceType
+		ipsOutput[isReservedIPTarget] = flattenReservedIPTarget(data.Target)
+		ipsOutput[isReservedIPTargetType] = flattenReservedIPTargetType(data.Target)
 
 		reservedIPs = append(reservedIPs, ipsOutput)
 	}
@@ -0,0 +1,387 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	isCIDRPlanVPC             = "vpc"
+	isCIDRPlanRequests        = "request"
+	isCIDRPlanPeerCIDRs       = "peer_cidrs"
+	isCIDRPlanAddressPrefixes = "address_prefixes"
+	isCIDRPlanSubnets         = "subnets"
+	isCIDRPlanAllocations     = "allocations"
+	isCIDRPlanOverlaps        = "overlaps"
+	isCIDRPlanName            = "name"
+	isCIDRPlanZone            = "zone"
+	isCIDRPlanCIDR            = "cidr"
+	isCIDRPlanPrefixLength    = "prefix_length"
+	isCIDRPlanAddressPrefix   = "address_prefix"
+	isCIDRPlanPeerCIDR        = "peer_cidr"
+)
+
+func dataSourceIBMISVPCCIDRPlan() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMISVPCCIDRPlanRead,
+
+		Schema: map[string]*schema.Schema{
+			isCIDRPlanVPC: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "VPC identifier",
+			},
+
+			isCIDRPlanRequests: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "CIDR blocks to allocate, in order, from the free space of the address prefixes in a zone",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isCIDRPlanName: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name identifying the allocation",
+						},
+						isCIDRPlanZone: {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Zone of the address prefixes to allocate from",
+						},
+						isCIDRPlanPrefixLength: {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: InvokeDataSourceValidator("ibm_is_vpc_cidr_plan", isCIDRPlanPrefixLength),
+							Description:  "Prefix length of the CIDR block to allocate",
+						},
+					},
+				},
+			},
+
+			isCIDRPlanPeerCIDRs: {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "CIDR blocks used by peer networks, such as other VPCs attached to the same transit gateway, that must not overlap",
+			},
+
+			isCIDRPlanAddressPrefixes: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Address prefixes of the VPC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isCIDRPlanName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address prefix name",
+						},
+						isCIDRPlanZone: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address prefix zone",
+						},
+						isCIDRPlanCIDR: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address prefix CIDR",
+						},
+					},
+				},
+			},
+
+			isCIDRPlanSubnets: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IPv4 subnets of the VPC",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isCIDRPlanName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subnet name",
+						},
+						isCIDRPlanZone: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subnet zone",
+						},
+						isCIDRPlanCIDR: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subnet IPv4 CIDR",
+						},
+					},
+				},
+			},
+
+			isCIDRPlanAllocations: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "CIDR blocks allocated for the requests, in request order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isCIDRPlanName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the request",
+						},
+						isCIDRPlanZone: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Zone of the allocation",
+						},
+						isCIDRPlanCIDR: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Allocated CIDR block",
+						},
+						isCIDRPlanAddressPrefix: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "CIDR of the address prefix the block was allocated from",
+						},
+					},
+				},
+			},
+
+			isCIDRPlanOverlaps: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Address prefixes of the VPC that overlap a peer CIDR",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isCIDRPlanName: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address prefix name",
+						},
+						isCIDRPlanCIDR: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address prefix CIDR",
+						},
+						isCIDRPlanPeerCIDR: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Overlapping peer CIDR",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMISVPCCIDRPlanValidator() *ResourceValidator {
+	validateSchema := make([]ValidateSchema, 1)
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 isCIDRPlanPrefixLength,
+			ValidateFunctionIdentifier: IntBetween,
+			Type:                       TypeInt,
+			Required:                   true,
+			MinValue:                   "8",
+			MaxValue:                   "29"})
+
+	ibmISVPCCIDRPlanDataSourceValidator := ResourceValidator{ResourceName: "ibm_is_vpc_cidr_plan", Schema: validateSchema}
+	return &ibmISVPCCIDRPlanDataSourceValidator
+}
+
+func dataSourceIBMISVPCCIDRPlanRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	vpcID := d.Get(isCIDRPlanVPC).(string)
+
+	start := ""
+	addressPrefixes := []vpcv1.AddressPrefix{}
+	for {
+		listVpcAddressPrefixesOptions := &vpcv1.ListVPCAddressPrefixesOptions{
+			VPCID: &vpcID,
+		}
+		if start != "" {
+			listVpcAddressPrefixesOptions.Start = &start
+		}
+		addressPrefixCollection, response, err := sess.ListVPCAddressPrefixes(listVpcAddressPrefixesOptions)
+		if err != nil {
+			return fmt.Errorf("Error Fetching Address Prefixes %s\n%s", err, response)
+		}
+		start = GetNext(addressPrefixCollection.Next)
+		addressPrefixes = append(addressPrefixes, addressPrefixCollection.AddressPrefixes...)
+		if start == "" {
+			break
+		}
+	}
+
+	start = ""
+	subnets := []vpcv1.Subnet{}
+	for {
+		listSubnetsOptions := &vpcv1.ListSubnetsOptions{}
+		if start != "" {
+			listSubnetsOptions.Start = &start
+		}
+		subnetCollection, response, err := sess.ListSubnets(listSubnetsOptions)
+		if err != nil {
+			return fmt.Errorf("Error Fetching subnets %s\n%s", err, response)
+		}
+		start = GetNext(subnetCollection.Next)
+		for _, subnet := range subnetCollection.Subnets {
+			if subnet.VPC != nil && *subnet.VPC.ID == vpcID && subnet.Ipv4CIDRBlock != nil {
+				subnets = append(subnets, subnet)
+			}
+		}
+		if start == "" {
+			break
+		}
+	}
+
+	peers := make([]*net.IPNet, 0)
+	for _, p := range d.Get(isCIDRPlanPeerCIDRs).([]interface{}) {
+		_, peer, err := net.ParseCIDR(p.(string))
+		if err != nil {
+			return fmt.Errorf("Invalid peer CIDR %s: %s", p, err)
+		}
+		if peer.IP.To4() == nil {
+			return fmt.Errorf("Invalid peer CIDR %s: only IPv4 CIDRs are supported", p)
+		}
+		peers = append(peers, peer)
+	}
+
+	prefixesByZone := make(map[string][]*net.IPNet)
+	prefixList := make([]map[string]interface{}, 0)
+	overlaps := make([]map[string]interface{}, 0)
+	for _, addressPrefix := range addressPrefixes {
+		_, prefix, err := net.ParseCIDR(*addressPrefix.CIDR)
+		if err != nil {
+			return fmt.Errorf("Invalid address prefix CIDR %s: %s", *addressPrefix.CIDR, err)
+		}
+		zone := *addressPrefix.Zone.Name
+		prefixesByZone[zone] = append(prefixesByZone[zone], prefix)
+		prefixList = append(prefixList, map[string]interface{}{
+			isCIDRPlanName: *addressPrefix.Name,
+			isCIDRPlanZone: zone,
+			isCIDRPlanCIDR: *addressPrefix.CIDR,
+		})
+		for _, peer := range peers {
+			if cidrsOverlap(prefix, peer) {
+				overlaps = append(overlaps, map[string]interface{}{
+					isCIDRPlanName:     *addressPrefix.Name,
+					isCIDRPlanCIDR:     *addressPrefix.CIDR,
+					isCIDRPlanPeerCIDR: peer.String(),
+				})
+			}
+		}
+	}
+
+	used := make([]*net.IPNet, 0, len(subnets)+len(peers))
+	used = append(used, peers...)
+	subnetList := make([]map[string]interface{}, 0)
+	for _, subnet := range subnets {
+		_, block, err := net.ParseCIDR(*subnet.Ipv4CIDRBlock)
+		if err != nil {
+			return fmt.Errorf("Invalid subnet CIDR %s: %s", *subnet.Ipv4CIDRBlock, err)
+		}
+		used = append(used, block)
+		subnetList = append(subnetList, map[string]interface{}{
+			isCIDRPlanName: *subnet.Name,
+			isCIDRPlanZone: *subnet.Zone.Name,
+			isCIDRPlanCIDR: *subnet.Ipv4CIDRBlock,
+		})
+	}
+
+	allocations := make([]map[string]interface{}, 0)
+	for _, r := range d.Get(isCIDRPlanRequests).([]interface{}) {
+		request := r.(map[string]interface{})
+		name := request[isCIDRPlanName].(string)
+		zone := request[isCIDRPlanZone].(string)
+		prefixLength := request[isCIDRPlanPrefixLength].(int)
+
+		block, prefix, err := allocateCIDR(prefixesByZone[zone], used, prefixLength)
+		if err != nil {
+			return fmt.Errorf("Error allocating a /%d for %s in zone %s of VPC %s: %s", prefixLength, name, zone, vpcID, err)
+		}
+		used = append(used, block)
+		allocations = append(allocations, map[string]interface{}{
+			isCIDRPlanName:          name,
+			isCIDRPlanZone:          zone,
+			isCIDRPlanCIDR:          block.String(),
+			isCIDRPlanAddressPrefix: prefix.String(),
+		})
+	}
+
+	d.SetId(vpcID)
+	d.Set(isCIDRPlanAddressPrefixes, prefixList)
+	d.Set(isCIDRPlanSubnets, subnetList)
+	d.Set(isCIDRPlanAllocations, allocations)
+	d.Set(isCIDRPlanOverlaps, overlaps)
+	return nil
+}
+
+// allocateCIDR returns the lowest IPv4 block of the given prefix length that fits in one of the
+// prefixes without overlapping any of the used blocks, along with the prefix it was taken from.
+func allocateCIDR(prefixes []*net.IPNet, used []*net.IPNet, prefixLength int) (*net.IPNet, *net.IPNet, error) {
+	if len(prefixes) == 0 {
+		return nil, nil, fmt.Errorf("no address prefixes")
+	}
+	sorted := make([]*net.IPNet, len(prefixes))
+	copy(sorted, prefixes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].IP.To4(), sorted[j].IP.To4()) < 0
+	})
+
+	mask := net.CIDRMask(prefixLength, 32)
+	size := uint64(1) << uint(32-prefixLength)
+	for _, prefix := range sorted {
+		ones, bits := prefix.Mask.Size()
+		if bits != 32 || ones > prefixLength {
+			continue
+		}
+		first, last := cidrRange(prefix)
+		for candidate := first; candidate+size-1 <= last; {
+			block := &net.IPNet{IP: uint32ToIP(uint32(candidate)), Mask: mask}
+			next := uint64(0)
+			for _, u := range used {
+				if cidrsOverlap(block, u) {
+					_, uLast := cidrRange(u)
+					if uLast+1 > next {
+						next = uLast + 1
+					}
+				}
+			}
+			if next == 0 {
+				return block, prefix, nil
+			}
+			// Skip past the used block, keeping the candidate aligned to the block size.
+			candidate = (next + size - 1) / size * size
+		}
+	}
+	return nil, nil, fmt.Errorf("no free address space")
+}
+
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func cidrRange(n *net.IPNet) (uint64, uint64) {
+	ones, bits := n.Mask.Size()
+	first := uint64(binary.BigEndian.Uint32(n.IP.To4()))
+	return first, first + (uint64(1) << uint(bits-ones)) - 1
+}
+
+func uint32ToIP(v uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, v)
+	return ip
+}
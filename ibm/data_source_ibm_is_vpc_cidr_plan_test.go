@@ -0,0 +1,111 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gotest.tools/assert"
+)
+
+func TestAccIBMISVPCCIDRPlanDataSource_basic(t *testing.T) {
+	node := "data.ibm_is_vpc_cidr_plan.plan"
+	vpcName := fmt.Sprintf("tfcidrplan-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetName := fmt.Sprintf("tfcidrplan-subnet-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCCIDRPlanDataSourceConfig(vpcName, subnetName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(node, "allocations.#", "2"),
+					resource.TestCheckResourceAttr(node, "allocations.0.name", "app"),
+					resource.TestCheckResourceAttr(node, "allocations.0.cidr", "10.10.0.64/26"),
+					resource.TestCheckResourceAttr(node, "allocations.1.cidr", "10.10.0.128/25"),
+					resource.TestCheckResourceAttr(node, "overlaps.#", "1"),
+					resource.TestCheckResourceAttr(node, "overlaps.0.peer_cidr", "10.10.0.0/27"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISVPCCIDRPlanDataSourceConfig(vpcName, subnetName string) string {
+	return fmt.Sprintf(`
+resource "ibm_is_vpc" "testacc_vpc" {
+	name                      = "%s"
+	address_prefix_management = "manual"
+}
+
+resource "ibm_is_vpc_address_prefix" "testacc_prefix" {
+	name = "tfcidrplan-prefix"
+	zone = "%s"
+	vpc  = ibm_is_vpc.testacc_vpc.id
+	cidr = "10.10.0.0/24"
+}
+
+resource "ibm_is_subnet" "testacc_subnet" {
+	name            = "%s"
+	vpc             = ibm_is_vpc.testacc_vpc.id
+	zone            = "%s"
+	ipv4_cidr_block = "10.10.0.0/26"
+	depends_on      = [ibm_is_vpc_address_prefix.testacc_prefix]
+}
+
+data "ibm_is_vpc_cidr_plan" "plan" {
+	vpc        = ibm_is_subnet.testacc_subnet.vpc
+	peer_cidrs = ["10.10.0.0/27"]
+
+	request {
+		name          = "app"
+		zone          = "%s"
+		prefix_length = 26
+	}
+
+	request {
+		name          = "data"
+		zone          = "%s"
+		prefix_length = 25
+	}
+}
+`, vpcName, ISZoneName, subnetName, ISZoneName, ISZoneName, ISZoneName)
+}
+
+func TestAllocateCIDR(t *testing.T) {
+	parse := func(cidrs ...string) []*net.IPNet {
+		nets := make([]*net.IPNet, 0, len(cidrs))
+		for _, c := range cidrs {
+			_, n, _ := net.ParseCIDR(c)
+			nets = append(nets, n)
+		}
+		return nets
+	}
+
+	prefixes := parse("10.20.0.0/24", "10.10.0.0/24")
+
+	block, prefix, err := allocateCIDR(prefixes, parse("10.10.0.0/26"), 26)
+	assert.NilError(t, err)
+	assert.Equal(t, "10.10.0.64/26", block.String())
+	assert.Equal(t, "10.10.0.0/24", prefix.String())
+
+	block, _, err = allocateCIDR(prefixes, parse("10.10.0.0/26"), 25)
+	assert.NilError(t, err)
+	assert.Equal(t, "10.10.0.128/25", block.String())
+
+	block, prefix, err = allocateCIDR(prefixes, parse("10.10.0.0/16"), 24)
+	assert.NilError(t, err)
+	assert.Equal(t, "10.20.0.0/24", block.String())
+	assert.Equal(t, "10.20.0.0/24", prefix.String())
+
+	_, _, err = allocateCIDR(prefixes, parse("10.0.0.0/8"), 28)
+	assert.Assert(t, err != nil)
+
+	_, _, err = allocateCIDR(nil, nil, 28)
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,133 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIBMISVPCRoutingTableRoute() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMISVPCRoutingTableRouteRead,
+		Schema: map[string]*schema.Schema{
+			isRoutingTableRouteVpcID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "VPC identifier",
+			},
+			isRouteTableID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Routing table identifier",
+			},
+			isRoutingTableRouteID: {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Routing Table Route ID",
+			},
+			isRoutingTableRouteHref: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Href",
+			},
+			isRoutingTableRouteName: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Name",
+			},
+			isRoutingTableRouteCreatedAt: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Created At",
+			},
+			isRoutingTableRouteLifecycleState: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Lifecycle State",
+			},
+			isRoutingTableRouteAction: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Action",
+			},
+			isRoutingTableRoutePriority: {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Routing Table Route Priority, routes with the same destination and priority in a zone share the traffic",
+			},
+			isRoutingTableRouteDestination: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Destination",
+			},
+			isRoutingTableRouteNexthop: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Nexthop Address or VPN Gateway Connection ID",
+			},
+			isRoutingTableRouteZoneName: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Routing Table Route Zone Name",
+			},
+		},
+	}
+}
+
+func dataSourceIBMISVPCRoutingTableRouteRead(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	vpcID := d.Get(isRoutingTableRouteVpcID).(string)
+	routingTableID := d.Get(isRouteTableID).(string)
+	routeID := d.Get(isRoutingTableRouteID).(string)
+
+	getVpcRoutingTableRouteOptions := sess.NewGetVPCRoutingTableRouteOptions(vpcID, routingTableID, routeID)
+	route, response, err := sess.GetVPCRoutingTableRoute(getVpcRoutingTableRouteOptions)
+	if err != nil {
+		return fmt.Errorf("Error Getting VPC Routing table route: %s\n%s", err, response)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", vpcID, routingTableID, *route.ID))
+	if route.Href != nil {
+		d.Set(isRoutingTableRouteHref, *route.Href)
+	}
+	if route.Name != nil {
+		d.Set(isRoutingTableRouteName, *route.Name)
+	}
+	if route.CreatedAt != nil {
+		d.Set(isRoutingTableRouteCreatedAt, (*route.CreatedAt).String())
+	}
+	if route.LifecycleState != nil {
+		d.Set(isRoutingTableRouteLifecycleState, *route.LifecycleState)
+	}
+	details := vpcRoutingTableRoute{}
+	response, err = vpcRoutingTableRouteRequest(sess, core.GET, vpcID, routingTableID, routeID, "", nil, &details)
+	if err != nil {
+		return fmt.Errorf("Error reading the action and priority of VPC Routing table route: %s\n%s", err, response)
+	}
+	d.Set(isRoutingTableRouteAction, details.Action)
+	d.Set(isRoutingTableRoutePriority, details.Priority)
+	if route.Destination != nil {
+		d.Set(isRoutingTableRouteDestination, *route.Destination)
+	}
+	if route.Zone != nil && route.Zone.Name != nil {
+		d.Set(isRoutingTableRouteZoneName, *route.Zone.Name)
+	}
+	if route.NextHop != nil {
+		nexthop := *route.NextHop.(*vpcv1.RouteNextHop)
+		if nexthop.Address != nil {
+			d.Set(isRoutingTableRouteNexthop, *nexthop.Address)
+		} else if nexthop.ID != nil {
+			d.Set(isRoutingTableRouteNexthop, *nexthop.ID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMISVPCRoutingTableRouteDataSource_basic(t *testing.T) {
+	node := "data.ibm_is_vpc_routing_table_route.route_test"
+	name1 := fmt.Sprintf("tfvpcuat-create-data-%d", acctest.RandIntRange(10, 100))
+	subnetName := fmt.Sprintf("tfsubnet-create-data-%d", acctest.RandIntRange(10, 100))
+	routeName := fmt.Sprintf("tfvpcuat-create-data-%d", acctest.RandIntRange(10, 100))
+	routeTableName := fmt.Sprintf("tfvpcrt-create-data-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCRoutingTableRouteDataSourceConfig(routeTableName, name1, subnetName, routeName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(node, "name", routeName),
+					resource.TestCheckResourceAttr(node, "nexthop", ISRouteNextHop),
+					resource.TestCheckResourceAttr(node, "zone", ISZoneName),
+					resource.TestCheckResourceAttrSet(node, "lifecycle_state"),
+					resource.TestCheckResourceAttr(node, "action", "deliver"),
+					resource.TestCheckResourceAttr(node, "priority", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISVPCRoutingTableRouteDataSourceConfig(rtName, name, subnetName, routeName string) string {
+	return testAccCheckIBMISVPCRoutingTableRoutesDataSourceConfig(rtName, name, subnetName, routeName) + fmt.Sprintf(`
+
+data "ibm_is_vpc_routing_table_route" "route_test" {
+	vpc = ibm_is_vpc.testacc_vpc.id
+	routing_table = ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table.routing_table
+	route_id = ibm_is_vpc_routing_table_route.test_custom_route1.route_id
+}
+`)
+}
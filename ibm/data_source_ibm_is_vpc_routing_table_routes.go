@@ -4,9 +4,11 @@
 package ibm
 
 import (
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -18,6 +20,7 @@ const (
 	isRoutingTableRouteCreatedAt      = "created_at"
 	isRoutingTableRouteLifecycleState = "lifecycle_state"
 	isRoutingTableRouteAction         = "action"
+	isRoutingTableRoutePriority       = "priority"
 	isRoutingTableRouteDestination    = "destination"
 	isRoutingTableRouteNexthop        = "nexthop"
 	isRoutingTableRouteZoneName       = "zone"
@@ -76,6 +79,11 @@ func dataSourceIBMISVPCRoutingTableRoutes() *schema.Resource {
 							Computed:    true,
 							Description: "Routing Table Route Action",
 						},
+						isRoutingTableRoutePriority: {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Routing Table Route Priority, routes with the same destination and priority in a zone share the traffic",
+						},
 						isRoutingTableRouteDestination: {
 							Type:        schema.TypeString,
 							Computed:    true,
@@ -125,6 +133,11 @@ func dataSourceIBMISVPCRoutingTableRoutesList(d *schema.ResourceData, meta inter
 		}
 	}
 
+	details, err := getVPCRoutingTableRouteDetails(sess, vpcID, routingTableID)
+	if err != nil {
+		return err
+	}
+
 	vpcRoutingTableRoutes := make([]map[string]interface{}, 0)
 
 	for _, instance := range allrecs {
@@ -144,6 +157,10 @@ func dataSourceIBMISVPCRoutingTableRoutesList(d *schema.ResourceData, meta inter
 		if instance.LifecycleState != nil {
 			route[isRoutingTableRouteLifecycleState] = *instance.LifecycleState
 		}
+		if instance.ID != nil {
+			route[isRoutingTableRouteAction] = details[*instance.ID].Action
+			route[isRoutingTableRoutePriority] = details[*instance.ID].Priority
+		}
 		if instance.Destination != nil {
 			route[isRoutingTableRouteDestination] = *instance.Destination
 		}
@@ -172,3 +189,51 @@ func dataSourceIBMISVPCRoutingTableRoutesList(d *schema.ResourceData, meta inter
 func dataSourceIBMISVPCRoutingTableRoutesID(d *schema.ResourceData) string {
 	return time.Now().UTC().String()
 }
+
+// vpcRoutingTableRoute has the fields of a route that the Route model of the vpc sdk does not have
+type vpcRoutingTableRoute struct {
+	ID       string `json:"id"`
+	Action   string `json:"action"`
+	Priority int64  `json:"priority"`
+}
+
+// vpcRoutingTableRouteRequest sends a plain request to the routes of a routing table, or to a route
+// when routeID is set, for the route fields that the vpc sdk does not support
+func vpcRoutingTableRouteRequest(sess *vpcv1.VpcV1, method, vpcID, routingTableID, routeID, start string, body, result interface{}) (*core.DetailedResponse, error) {
+	path := `/vpcs/{vpc_id}/routing_tables/{routing_table_id}/routes`
+	pathParams := map[string]string{"vpc_id": vpcID, "routing_table_id": routingTableID}
+	if routeID != "" {
+		path += `/{id}`
+		pathParams["id"] = routeID
+	}
+	return vpcPlainRequest(sess, method, path, pathParams, start, body, result)
+}
+
+// getVPCRoutingTableRouteDetails returns the action and the priority of every route of a routing table
+// by route ID
+func getVPCRoutingTableRouteDetails(sess *vpcv1.VpcV1, vpcID, routingTableID string) (map[string]vpcRoutingTableRoute, error) {
+	type routeCollection struct {
+		Routes []vpcRoutingTableRoute `json:"routes"`
+		Next   *struct {
+			Href *string `json:"href"`
+		} `json:"next"`
+	}
+
+	routes := map[string]vpcRoutingTableRoute{}
+	start := ""
+	for {
+		result := routeCollection{}
+		response, err := vpcRoutingTableRouteRequest(sess, core.GET, vpcID, routingTableID, "", start, nil, &result)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading the actions and priorities of VPC Routing Table Routes: %s\n%s", err, response)
+		}
+		for _, route := range result.Routes {
+			routes[route.ID] = route
+		}
+		start = GetNext(result.Next)
+		if start == "" {
+			break
+		}
+	}
+	return routes, nil
+}
@@ -0,0 +1,202 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	vlanIPUsageSubnetMask    = "id,networkIdentifier,cidr,subnetType,gateway,version"
+	vlanIPUsageIPAddressMask = "id,ipAddress,isNetwork,isGateway,isBroadcast,isReserved,note," +
+		"virtualGuest[id],hardware[id],applicationDeliveryController[id],networkComponent[id],guestNetworkComponent[id]"
+)
+
+func dataSourceIBMNetworkVlanIPUsage() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMNetworkVlanIPUsageRead,
+
+		Schema: map[string]*schema.Schema{
+			"vlan_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the VLAN",
+			},
+			"used_ip_addresses": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of IP addresses in use or reserved in the IPv4 subnets of the VLAN",
+			},
+			"free_ip_addresses": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of unassigned IP addresses in the IPv4 subnets of the VLAN",
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IP address usage of the IPv4 subnets of the VLAN",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the subnet",
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The subnet in CIDR notation",
+						},
+						"subnet_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the subnet",
+						},
+						"gateway": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The gateway of the subnet",
+						},
+						"total_ip_addresses": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of IP addresses in the subnet",
+						},
+						"used_ip_addresses": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of IP addresses in use or reserved",
+						},
+						"free_ip_addresses": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of unassigned IP addresses",
+						},
+						"unassigned_ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The unassigned IP addresses",
+						},
+						"reserved_ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The IP addresses reserved for the network, gateway, broadcast or by the user",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"ip_address": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The IP address",
+									},
+									"reason": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Why the IP address is reserved: network, gateway, broadcast or reserved",
+									},
+									"note": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "The note of the IP address",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMNetworkVlanIPUsageRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	vlanID := d.Get("vlan_id").(int)
+
+	subnets, err := services.GetNetworkVlanService(sess).Id(vlanID).Mask(vlanIPUsageSubnetMask).GetSubnets()
+	if err != nil {
+		return fmt.Errorf("Error retrieving subnets of VLAN %d: %s", vlanID, err)
+	}
+
+	usedTotal, freeTotal := 0, 0
+	subnetUsage := make([]map[string]interface{}, 0, len(subnets))
+	for _, subnet := range subnets {
+		// IPv6 subnets are too large to list their addresses
+		if sl.Get(subnet.Version, 4).(int) != 4 {
+			continue
+		}
+		ipAddresses, err := services.GetNetworkSubnetService(sess).Id(*subnet.Id).Mask(vlanIPUsageIPAddressMask).GetIpAddresses()
+		if err != nil {
+			return fmt.Errorf("Error retrieving IP addresses of subnet %d: %s", *subnet.Id, err)
+		}
+		usage := flattenSubnetIPUsage(subnet, ipAddresses)
+		usedTotal += usage["used_ip_addresses"].(int)
+		freeTotal += usage["free_ip_addresses"].(int)
+		subnetUsage = append(subnetUsage, usage)
+	}
+
+	d.SetId(fmt.Sprintf("%d", vlanID))
+	d.Set("used_ip_addresses", usedTotal)
+	d.Set("free_ip_addresses", freeTotal)
+	d.Set("subnets", subnetUsage)
+
+	return nil
+}
+
+func flattenSubnetIPUsage(subnet datatypes.Network_Subnet, ipAddresses []datatypes.Network_Subnet_IpAddress) map[string]interface{} {
+	unassigned := []string{}
+	reserved := []map[string]interface{}{}
+	for _, ip := range ipAddresses {
+		if reason := subnetIPReservation(ip); reason != "" {
+			reserved = append(reserved, map[string]interface{}{
+				"ip_address": sl.Get(ip.IpAddress, ""),
+				"reason":     reason,
+				"note":       sl.Get(ip.Note, ""),
+			})
+			continue
+		}
+		if !subnetIPAssigned(ip) {
+			unassigned = append(unassigned, sl.Get(ip.IpAddress, "").(string))
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                      *subnet.Id,
+		"subnet":                  fmt.Sprintf("%s/%d", sl.Get(subnet.NetworkIdentifier, ""), sl.Get(subnet.Cidr, 0)),
+		"subnet_type":             sl.Get(subnet.SubnetType, ""),
+		"gateway":                 sl.Get(subnet.Gateway, ""),
+		"total_ip_addresses":      len(ipAddresses),
+		"used_ip_addresses":       len(ipAddresses) - len(unassigned),
+		"free_ip_addresses":       len(unassigned),
+		"unassigned_ip_addresses": unassigned,
+		"reserved_ip_addresses":   reserved,
+	}
+}
+
+// subnetIPReservation returns why the address cannot be assigned, or an empty string
+func subnetIPReservation(ip datatypes.Network_Subnet_IpAddress) string {
+	switch {
+	case sl.Get(ip.IsNetwork, false).(bool):
+		return "network"
+	case sl.Get(ip.IsGateway, false).(bool):
+		return "gateway"
+	case sl.Get(ip.IsBroadcast, false).(bool):
+		return "broadcast"
+	case sl.Get(ip.IsReserved, false).(bool):
+		return "reserved"
+	}
+	return ""
+}
+
+// subnetIPAssigned reports whether the address is bound to a device
+func subnetIPAssigned(ip datatypes.Network_Subnet_IpAddress) bool {
+	return ip.VirtualGuest != nil || ip.Hardware != nil || ip.ApplicationDeliveryController != nil ||
+		ip.NetworkComponent != nil || ip.GuestNetworkComponent != nil
+}
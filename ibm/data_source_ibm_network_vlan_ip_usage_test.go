@@ -0,0 +1,76 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+	"gotest.tools/assert"
+)
+
+func TestAccIBMNetworkVlanIPUsageDataSource_Basic(t *testing.T) {
+	name := fmt.Sprintf("terraformuat_vlan_%s", acctest.RandString(2))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMNetworkVlanIPUsageDataSourceConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ibm_network_vlan_ip_usage.usage", "subnets.#", "1"),
+					resource.TestCheckResourceAttrSet("data.ibm_network_vlan_ip_usage.usage", "free_ip_addresses"),
+					resource.TestCheckResourceAttrSet("data.ibm_network_vlan_ip_usage.usage", "subnets.0.reserved_ip_addresses.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMNetworkVlanIPUsageDataSourceConfig(name string) string {
+	return fmt.Sprintf(`
+resource "ibm_network_vlan" "test_vlan_private" {
+	name       = "%s"
+	datacenter = "dal06"
+	type       = "PRIVATE"
+}
+
+data "ibm_network_vlan_ip_usage" "usage" {
+	vlan_id = ibm_network_vlan.test_vlan_private.id
+}`, name)
+}
+
+func TestFlattenSubnetIPUsage(t *testing.T) {
+	subnet := datatypes.Network_Subnet{
+		Id:                sl.Int(1),
+		NetworkIdentifier: sl.String("10.0.0.0"),
+		Cidr:              sl.Int(30),
+		SubnetType:        sl.String("PRIMARY"),
+		Gateway:           sl.String("10.0.0.1"),
+	}
+	ipAddresses := []datatypes.Network_Subnet_IpAddress{
+		{IpAddress: sl.String("10.0.0.0"), IsNetwork: sl.Bool(true)},
+		{IpAddress: sl.String("10.0.0.1"), IsGateway: sl.Bool(true)},
+		{IpAddress: sl.String("10.0.0.2"), VirtualGuest: &datatypes.Virtual_Guest{Id: sl.Int(5)}},
+		{IpAddress: sl.String("10.0.0.3"), IsReserved: sl.Bool(true), Note: sl.String("vip")},
+		{IpAddress: sl.String("10.0.0.4")},
+	}
+
+	usage := flattenSubnetIPUsage(subnet, ipAddresses)
+	assert.Equal(t, "10.0.0.0/30", usage["subnet"])
+	assert.Equal(t, 5, usage["total_ip_addresses"])
+	assert.Equal(t, 4, usage["used_ip_addresses"])
+	assert.Equal(t, 1, usage["free_ip_addresses"])
+	assert.DeepEqual(t, []string{"10.0.0.4"}, usage["unassigned_ip_addresses"])
+	assert.DeepEqual(t, []map[string]interface{}{
+		{"ip_address": "10.0.0.0", "reason": "network", "note": ""},
+		{"ip_address": "10.0.0.1", "reason": "gateway", "note": ""},
+		{"ip_address": "10.0.0.3", "reason": "reserved", "note": "vip"},
+	}, usage["reserved_ip_addresses"])
+}
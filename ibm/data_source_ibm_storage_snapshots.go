@@ -0,0 +1,83 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func dataSourceIBMStorageSnapshots() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIBMStorageSnapshotsRead,
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "ID of the block or file storage volume",
+			},
+			"snapshots": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Snapshots of the storage volume",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "ID of the snapshot",
+						},
+						"notes": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Notes of the snapshot",
+						},
+						"snapshot_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Creation time of the snapshot",
+						},
+						"snapshot_size_bytes": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Size of the snapshot in bytes",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIBMStorageSnapshotsRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	volumeID := d.Get("volume_id").(int)
+
+	snapshots, err := services.GetNetworkStorageService(sess).
+		Id(volumeID).
+		Mask(storageSnapshotMask).
+		GetSnapshots()
+	if err != nil {
+		return fmt.Errorf("Error retrieving snapshots of storage %d: %s", volumeID, err)
+	}
+
+	snapshotList := make([]map[string]interface{}, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		snapshotList = append(snapshotList, map[string]interface{}{
+			"id":                  *snapshot.Id,
+			"notes":               sl.Get(snapshot.Notes, ""),
+			"snapshot_date":       sl.Get(snapshot.SnapshotCreationTimestamp, ""),
+			"snapshot_size_bytes": sl.Get(snapshot.SnapshotSizeBytes, ""),
+		})
+	}
+
+	d.SetId(fmt.Sprintf("%d", volumeID))
+	d.Set("snapshots", snapshotList)
+
+	return nil
+}
@@ -0,0 +1,36 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMStorageSnapshotsDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMStorageSnapshotsDataSourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.ibm_storage_snapshots.snapshots", "snapshots.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.ibm_storage_snapshots.snapshots", "snapshots.0.id", "ibm_storage_snapshot.snapshot", "id"),
+					resource.TestCheckResourceAttr(
+						"data.ibm_storage_snapshots.snapshots", "snapshots.0.notes", "manual snapshot"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckIBMStorageSnapshotsDataSourceConfig = testAccCheckIBMStorageSnapshotConfig_basic + `
+data "ibm_storage_snapshots" "snapshots" {
+        volume_id = ibm_storage_snapshot.snapshot.volume_id
+}
+`
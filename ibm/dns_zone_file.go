@@ -0,0 +1,347 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+// dnsZoneRecord is a record parsed from a zone file. It holds the same keys as the
+// ibm_dns_record arguments, so expandDNSRecord can build it.
+type dnsZoneRecord map[string]interface{}
+
+func (r dnsZoneRecord) Get(k string) interface{} {
+	return r[k]
+}
+
+func (r dnsZoneRecord) GetOk(k string) (interface{}, bool) {
+	v, ok := r[k]
+	if !ok {
+		return nil, false
+	}
+	switch value := v.(type) {
+	case string:
+		return v, value != ""
+	case int:
+		return v, value != 0
+	}
+	return v, true
+}
+
+func (r dnsZoneRecord) Id() string {
+	return ""
+}
+
+// parseDNSZoneFile parses the records of a BIND zone file for the domain. Only the
+// record types of allowedDomainRecordTypes are accepted.
+func parseDNSZoneFile(zone string, domain string) ([]dnsZoneRecord, error) {
+	domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+	origin := domain + "."
+	defaultTTL := 86400
+	owner := origin
+
+	records := []dnsZoneRecord{}
+	lines := strings.Split(strings.Replace(zone, "\r\n", "\n", -1), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := stripDNSZoneComment(lines[i])
+		// Records in parentheses span several lines
+		for strings.Count(line, "(") > strings.Count(line, ")") && i+1 < len(lines) {
+			i++
+			line += " " + stripDNSZoneComment(lines[i])
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		continued := line[0] == ' ' || line[0] == '\t'
+		fields := tokenizeDNSZoneLine(strings.NewReplacer("(", " ", ")", " ").Replace(line))
+
+		switch strings.ToUpper(fields[0]) {
+		case "$ORIGIN":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("Line %d: $ORIGIN requires a domain name", lineNumber)
+			}
+			origin = absoluteDNSName(fields[1], origin)
+			continue
+		case "$TTL":
+			if len(fields) < 2 {
+				return nil, fmt.Errorf("Line %d: $TTL requires a value", lineNumber)
+			}
+			ttl, err := parseDNSZoneTTL(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %s", lineNumber, err)
+			}
+			defaultTTL = ttl
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("Line %d: %s is not supported", lineNumber, fields[0])
+		}
+
+		if !continued {
+			owner = absoluteDNSName(fields[0], origin)
+			fields = fields[1:]
+		}
+
+		ttl := defaultTTL
+		for len(fields) > 0 {
+			if strings.ToUpper(fields[0]) == "IN" {
+				fields = fields[1:]
+			} else if t, err := parseDNSZoneTTL(fields[0]); err == nil {
+				ttl = t
+				fields = fields[1:]
+			} else {
+				break
+			}
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("Line %d: incomplete record", lineNumber)
+		}
+
+		recordType := strings.ToLower(fields[0])
+		rdata := fields[1:]
+		if !isAllowedDomainRecordType(recordType) {
+			return nil, fmt.Errorf("Line %d: %s is not one of the valid domain record types: %s",
+				lineNumber, fields[0], strings.Join(allowedDomainRecordTypes, ", "))
+		}
+
+		host, err := relativeDNSName(owner, domain)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %s", lineNumber, err)
+		}
+		record := dnsZoneRecord{
+			"host":        host,
+			"ttl":         ttl,
+			"type":        recordType,
+			"mx_priority": 0,
+			"priority":    0,
+			"weight":      0,
+		}
+
+		expected := map[string]int{"mx": 2, "srv": 4, "soa": 7}[recordType]
+		if expected == 0 {
+			expected = 1
+		}
+		if len(rdata) < expected {
+			return nil, fmt.Errorf("Line %d: %s record requires %d values", lineNumber, fields[0], expected)
+		}
+
+		switch recordType {
+		case "a", "aaaa":
+			record["data"] = strings.ToLower(rdata[0])
+		case "cname", "ns", "ptr":
+			record["data"] = absoluteDNSName(rdata[0], origin)
+		case "txt", "spf":
+			record["data"] = unquoteDNSZoneText(rdata)
+		case "mx":
+			priority, err := strconv.Atoi(rdata[0])
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: invalid MX priority %s", lineNumber, rdata[0])
+			}
+			record["mx_priority"] = priority
+			record["data"] = absoluteDNSName(rdata[1], origin)
+		case "srv":
+			labels := strings.SplitN(host, ".", 3)
+			if len(labels) < 2 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") {
+				return nil, fmt.Errorf("Line %d: SRV record %s must be named _service._protocol", lineNumber, host)
+			}
+			record["service"] = labels[0]
+			record["protocol"] = labels[1]
+			record["host"] = "@"
+			if len(labels) == 3 {
+				record["host"] = labels[2]
+			}
+			values := make([]int, 3)
+			for j := range values {
+				values[j], err = strconv.Atoi(rdata[j])
+				if err != nil {
+					return nil, fmt.Errorf("Line %d: invalid SRV value %s", lineNumber, rdata[j])
+				}
+			}
+			record["priority"] = values[0]
+			record["weight"] = values[1]
+			record["port"] = values[2]
+			record["data"] = absoluteDNSName(rdata[3], origin)
+		case "soa":
+			record["data"] = absoluteDNSName(rdata[0], origin)
+			record["responsible_person"] = absoluteDNSName(rdata[1], origin)
+			values := make([]int, 4)
+			for j := range values {
+				values[j], err = parseDNSZoneTTL(rdata[j+3])
+				if err != nil {
+					return nil, fmt.Errorf("Line %d: %s", lineNumber, err)
+				}
+			}
+			record["refresh"] = values[0]
+			record["retry"] = values[1]
+			record["expire"] = values[2]
+			record["minimum_ttl"] = values[3]
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// formatDNSZoneRecord renders the record as a zone file line
+func formatDNSZoneRecord(record datatypes.Dns_Domain_ResourceRecord, serial string) string {
+	recordType := strings.ToLower(sl.Get(record.Type, "").(string))
+	owner := sl.Get(record.Host, "@").(string)
+	data := sl.Get(record.Data, "").(string)
+	ttl := sl.Get(record.Ttl, 86400).(int)
+
+	switch recordType {
+	case "mx":
+		data = fmt.Sprintf("%d %s", sl.Get(record.MxPriority, 0).(int), data)
+	case "srv":
+		srvOwner := fmt.Sprintf("%s.%s", sl.Get(record.Service, "").(string), sl.Get(record.Protocol, "").(string))
+		if owner != "@" && owner != "" {
+			srvOwner += "." + owner
+		}
+		owner = srvOwner
+		data = fmt.Sprintf("%d %d %d %s", sl.Get(record.Priority, 0).(int), sl.Get(record.Weight, 0).(int),
+			sl.Get(record.Port, 0).(int), data)
+	case "txt", "spf":
+		data = quoteDNSZoneText(data)
+	case "soa":
+		data = fmt.Sprintf("%s %s ( %s %d %d %d %d )", data, sl.Get(record.ResponsiblePerson, "").(string), serial,
+			sl.Get(record.Refresh, 0).(int), sl.Get(record.Retry, 0).(int), sl.Get(record.Expire, 0).(int),
+			sl.Get(record.Minimum, 0).(int))
+	}
+	return fmt.Sprintf("%s\t%d\tIN\t%s\t%s", owner, ttl, strings.ToUpper(recordType), data)
+}
+
+func isAllowedDomainRecordType(recordType string) bool {
+	for _, rtype := range allowedDomainRecordTypes {
+		if recordType == rtype {
+			return true
+		}
+	}
+	return false
+}
+
+func stripDNSZoneComment(line string) string {
+	quoted := false
+	for i, c := range line {
+		switch c {
+		case '"':
+			quoted = !quoted
+		case ';':
+			if !quoted {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
+
+// tokenizeDNSZoneLine splits the line on whitespace and keeps quoted strings together
+func tokenizeDNSZoneLine(line string) []string {
+	tokens := []string{}
+	var current strings.Builder
+	quoted := false
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			current.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			current.WriteRune(c)
+			escaped = true
+		case c == '"':
+			current.WriteRune(c)
+			quoted = !quoted
+		case !quoted && (c == ' ' || c == '\t'):
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(c)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+func parseDNSZoneTTL(value string) (int, error) {
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	total, number := 0, ""
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c >= '0' && c <= '9' {
+			number += string(c)
+			continue
+		}
+		unit, ok := units[c|0x20]
+		if !ok || number == "" {
+			return 0, fmt.Errorf("Invalid TTL %s", value)
+		}
+		n, _ := strconv.Atoi(number)
+		total += n * unit
+		number = ""
+	}
+	if number != "" {
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid TTL %s", value)
+		}
+		total += n
+	} else if total == 0 {
+		return 0, fmt.Errorf("Invalid TTL %s", value)
+	}
+	return total, nil
+}
+
+// absoluteDNSName returns the name with a trailing dot, relative names are completed with origin
+func absoluteDNSName(name, origin string) string {
+	if name == "@" {
+		return origin
+	}
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "." + origin
+}
+
+// relativeDNSName returns the host of an absolute name within the domain, @ for the domain itself
+func relativeDNSName(name, domain string) (string, error) {
+	name = strings.ToLower(name)
+	if name == domain+"." {
+		return "@", nil
+	}
+	if strings.HasSuffix(name, "."+domain+".") {
+		return strings.TrimSuffix(name, "."+domain+"."), nil
+	}
+	return "", fmt.Errorf("%s is not within the domain %s", name, domain)
+}
+
+func unquoteDNSZoneText(values []string) string {
+	var text strings.Builder
+	for _, v := range values {
+		if unquoted, err := strconv.Unquote(v); err == nil {
+			text.WriteString(unquoted)
+		} else {
+			text.WriteString(strings.Trim(v, `"`))
+		}
+	}
+	return text.String()
+}
+
+// quoteDNSZoneText quotes the text in strings of at most 255 characters
+func quoteDNSZoneText(text string) string {
+	chunks := []string{}
+	for len(text) > 255 {
+		chunks = append(chunks, strconv.Quote(text[:255]))
+		text = text[255:]
+	}
+	chunks = append(chunks, strconv.Quote(text))
+	return strings.Join(chunks, " ")
+}
@@ -199,10 +199,13 @@ func Provider() *schema.Provider {
 			"ibm_cis_waf_rules":                      dataSourceIBMCISWAFRules(),
 			"ibm_database":                           dataSourceIBMDatabaseInstance(),
 			"ibm_compute_bare_metal":                 dataSourceIBMComputeBareMetal(),
+			"ibm_compute_bare_metals":                dataSourceIBMComputeBareMetals(),
 			"ibm_compute_image_template":             dataSourceIBMComputeImageTemplate(),
+			"ibm_classic_order_estimate":             dataSourceIBMClassicOrderEstimate(),
 			"ibm_compute_placement_group":            dataSourceIBMComputePlacementGroup(),
 			"ibm_compute_ssh_key":                    dataSourceIBMComputeSSHKey(),
 			"ibm_compute_vm_instance":                dataSourceIBMComputeVmInstance(),
+			"ibm_compute_vm_instances":               dataSourceIBMComputeVmInstances(),
 			"ibm_container_addons":                   datasourceIBMContainerAddOns(),
 			"ibm_container_alb":                      dataSourceIBMContainerALB(),
 			"ibm_container_alb_cert":                 dataSourceIBMContainerALBCert(),
@@ -211,6 +214,10 @@ func Provider() *schema.Provider {
 			"ibm_container_cluster_config":           dataSourceIBMContainerClusterConfig(),
 			"ibm_container_cluster_versions":         dataSourceIBMContainerClusterVersions(),
 			"ibm_container_cluster_worker":           dataSourceIBMContainerClusterWorker(),
+			"ibm_container_dedicated_host_pool":      dataSourceIBMContainerDedicatedHostPool(),
+			"ibm_container_dedicated_host":           dataSourceIBMContainerDedicatedHost(),
+			"ibm_container_nlb_dns":                  dataSourceIBMContainerNlbDNS(),
+			"ibm_container_storage_attachment":       dataSourceIBMContainerStorageAttachment(),
 			"ibm_container_vpc_cluster_alb":          dataSourceIBMContainerVPCClusterALB(),
 			"ibm_container_vpc_alb":                  dataSourceIBMContainerVPCClusterALB(),
 			"ibm_container_vpc_cluster":              dataSourceIBMContainerVPCCluster(),
@@ -219,9 +226,12 @@ func Provider() *schema.Provider {
 			"ibm_container_vpc_worker_pool":          dataSourceIBMContainerVpcClusterWorkerPool(),
 			"ibm_container_worker_pool":              dataSourceIBMContainerWorkerPool(),
 			"ibm_cr_namespaces":                      dataIBMContainerRegistryNamespaces(),
+			"ibm_cr_images":                          dataIBMContainerRegistryImages(),
+			"ibm_cr_vulnerability_report":            dataIBMContainerRegistryVulnerabilityReport(),
 			"ibm_cos_bucket":                         dataSourceIBMCosBucket(),
 			"ibm_dns_domain_registration":            dataSourceIBMDNSDomainRegistration(),
 			"ibm_dns_domain":                         dataSourceIBMDNSDomain(),
+			"ibm_dns_domain_zone_file":               dataSourceIBMDNSDomainZoneFile(),
 			"ibm_dns_secondary":                      dataSourceIBMDNSSecondary(),
 			"ibm_event_streams_topic":                dataSourceIBMEventStreamsTopic(),
 			"ibm_iam_access_group":                   dataSourceIBMIAMAccessGroup(),
@@ -249,6 +259,8 @@ func Provider() *schema.Provider {
 			"ibm_is_instance_group_managers":         dataSourceIBMISInstanceGroupManagers(),
 			"ibm_is_instance_group_manager_policies": dataSourceIBMISInstanceGroupManagerPolicies(),
 			"ibm_is_instance_group_manager_policy":   dataSourceIBMISInstanceGroupManagerPolicy(),
+			"ibm_is_instance_group_membership":       dataSourceIBMISInstanceGroupMembership(),
+			"ibm_is_instance_group_memberships":      dataSourceIBMISInstanceGroupMemberships(),
 			"ibm_is_virtual_endpoint_gateways":       dataSourceIBMISEndpointGateways(),
 			"ibm_is_virtual_endpoint_gateway_ips":    dataSourceIBMISEndpointGatewayIPs(),
 			"ibm_is_virtual_endpoint_gateway":        dataSourceIBMISEndpointGateway(),
@@ -277,10 +289,13 @@ func Provider() *schema.Provider {
 			"ibm_is_vpc_default_routing_table":       dataSourceIBMISVPCDefaultRoutingTable(),
 			"ibm_is_vpc_routing_tables":              dataSourceIBMISVPCRoutingTables(),
 			"ibm_is_vpc_routing_table_routes":        dataSourceIBMISVPCRoutingTableRoutes(),
+			"ibm_is_vpc_routing_table_route":         dataSourceIBMISVPCRoutingTableRoute(),
+			"ibm_is_vpc_cidr_plan":                   dataSourceIBMISVPCCIDRPlan(),
 			"ibm_is_zone":                            dataSourceIBMISZone(),
 			"ibm_is_zones":                           dataSourceIBMISZones(),
 			"ibm_lbaas":                              dataSourceIBMLbaas(),
 			"ibm_network_vlan":                       dataSourceIBMNetworkVlan(),
+			"ibm_network_vlan_ip_usage":              dataSourceIBMNetworkVlanIPUsage(),
 			"ibm_org":                                dataSourceIBMOrg(),
 			"ibm_org_quota":                          dataSourceIBMOrgQuota(),
 			"ibm_kp_key":                             dataSourceIBMkey(),
@@ -297,6 +312,7 @@ func Provider() *schema.Provider {
 			"ibm_service_key":                        dataSourceIBMServiceKey(),
 			"ibm_service_plan":                       dataSourceIBMServicePlan(),
 			"ibm_space":                              dataSourceIBMSpace(),
+			"ibm_storage_snapshots":                  dataSourceIBMStorageSnapshots(),
 
 			// Added for Schematics
 			"ibm_schematics_workspace": dataSourceIBMSchematicsWorkspace(),
@@ -423,19 +439,30 @@ func Provider() *schema.Provider {
 			"ibm_container_alb":                                  resourceIBMContainerALB(),
 			"ibm_container_api_key_reset":                        resourceIBMContainerAPIKeyReset(),
 			"ibm_container_vpc_alb":                              resourceIBMContainerVpcALB(),
+			"ibm_container_vpc_alb_create":                       resourceIBMContainerVpcALBCreateNew(),
 			"ibm_container_vpc_worker_pool":                      resourceIBMContainerVpcWorkerPool(),
 			"ibm_container_vpc_cluster":                          resourceIBMContainerVpcCluster(),
 			"ibm_container_alb_cert":                             resourceIBMContainerALBCert(),
 			"ibm_container_cluster":                              resourceIBMContainerCluster(),
 			"ibm_container_cluster_feature":                      resourceIBMContainerClusterFeature(),
+			"ibm_container_dedicated_host_pool":                  resourceIBMContainerDedicatedHostPool(),
+			"ibm_container_dedicated_host":                       resourceIBMContainerDedicatedHost(),
+			"ibm_container_ingress_instance":                     resourceIBMContainerIngressInstance(),
+			"ibm_container_ingress_secret_opaque":                resourceIBMContainerIngressSecretOpaque(),
+			"ibm_container_ingress_secret_tls":                   resourceIBMContainerIngressSecretTLS(),
+			"ibm_container_nlb_dns":                              resourceIBMContainerNlbDNS(),
+			"ibm_container_storage_attachment":                   resourceIBMContainerStorageAttachment(),
 			"ibm_container_bind_service":                         resourceIBMContainerBindService(),
 			"ibm_container_worker_pool":                          resourceIBMContainerWorkerPool(),
+			"ibm_container_worker_pool_autoscale":                resourceIBMContainerWorkerPoolAutoscale(),
 			"ibm_container_worker_pool_zone_attachment":          resourceIBMContainerWorkerPoolZoneAttachment(),
 			"ibm_ob_logging":                                     resourceIBMObLogging(),
 			"ibm_ob_monitoring":                                  resourceIBMObMonitoring(),
 			"ibm_cr_namespace":                                   resourceIBMContainerRegistryNamespace(),
+			"ibm_cr_retention_policy":                            resourceIBMCrRetentionPolicy(),
 			"ibm_cos_bucket":                                     resourceIBMCOS(),
 			"ibm_dns_domain":                                     resourceIBMDNSDomain(),
+			"ibm_dns_domain_zone_import":                         resourceIBMDNSDomainZoneImport(),
 			"ibm_dns_domain_registration_nameservers":            resourceIBMDNSDomainRegistrationNameservers(),
 			"ibm_dns_secondary":                                  resourceIBMDNSSecondary(),
 			"ibm_dns_record":                                     resourceIBMDNSRecord(),
@@ -501,11 +528,16 @@ func Provider() *schema.Provider {
 			"ibm_lb_service":                                     resourceIBMLbService(),
 			"ibm_lb_service_group":                               resourceIBMLbServiceGroup(),
 			"ibm_lb_vpx":                                         resourceIBMLbVpx(),
+			"ibm_lb_vpx_cs_policy":                               resourceIBMLbVpxCsPolicy(),
 			"ibm_lb_vpx_ha":                                      resourceIBMLbVpxHa(),
+			"ibm_lb_vpx_monitor":                                 resourceIBMLbVpxMonitor(),
+			"ibm_lb_vpx_rewrite_policy":                          resourceIBMLbVpxRewritePolicy(),
 			"ibm_lb_vpx_service":                                 resourceIBMLbVpxService(),
+			"ibm_lb_vpx_ssl_profile":                             resourceIBMLbVpxSslProfile(),
 			"ibm_lb_vpx_vip":                                     resourceIBMLbVpxVip(),
 			"ibm_multi_vlan_firewall":                            resourceIBMMultiVlanFirewall(),
 			"ibm_network_gateway":                                resourceIBMNetworkGateway(),
+			"ibm_network_gateway_config":                         resourceIBMNetworkGatewayConfig(),
 			"ibm_network_gateway_vlan_association":               resourceIBMNetworkGatewayVlanAttachment(),
 			"ibm_network_interface_sg_attachment":                resourceIBMNetworkInterfaceSGAttachment(),
 			"ibm_network_public_ip":                              resourceIBMNetworkPublicIp(),
@@ -523,12 +555,15 @@ func Provider() *schema.Provider {
 			"ibm_resource_key":                                   resourceIBMResourceKey(),
 			"ibm_security_group":                                 resourceIBMSecurityGroup(),
 			"ibm_security_group_rule":                            resourceIBMSecurityGroupRule(),
+			"ibm_security_group_rules":                           resourceIBMSecurityGroupRules(),
 			"ibm_service_instance":                               resourceIBMServiceInstance(),
 			"ibm_service_key":                                    resourceIBMServiceKey(),
 			"ibm_space":                                          resourceIBMSpace(),
 			"ibm_storage_evault":                                 resourceIBMStorageEvault(),
 			"ibm_storage_block":                                  resourceIBMStorageBlock(),
 			"ibm_storage_file":                                   resourceIBMStorageFile(),
+			"ibm_storage_replica":                                resourceIBMStorageReplica(),
+			"ibm_storage_snapshot":                               resourceIBMStorageSnapshot(),
 			"ibm_subnet":                                         resourceIBMSubnet(),
 			"ibm_dns_reverse_record":                             resourceIBMDNSReverseRecord(),
 			"ibm_ssl_certificate":                                resourceIBMSSLCertificate(),
@@ -617,6 +652,7 @@ func Validator() ValidatorDict {
 				"ibm_cis_waf_rule":                     resourceIBMCISWAFRuleValidator(),
 				"ibm_cis_certificate_order":            resourceIBMCISCertificateOrderValidator(),
 				"ibm_cr_namespace":                     resourceIBMCrNamespaceValidator(),
+				"ibm_cr_retention_policy":              resourceIBMCrRetentionPolicyValidator(),
 				"ibm_tg_gateway":                       resourceIBMTGValidator(),
 				"ibm_tg_connection":                    resourceIBMTransitGatewayConnectionValidator(),
 				"ibm_dl_virtual_connection":            resourceIBMdlGatewayVCValidator(),
@@ -669,6 +705,7 @@ func Validator() ValidatorDict {
 				"ibm_is_virtual_endpoint_gateway":      resourceIBMISEndpointGatewayValidator(),
 				"ibm_container_vpc_cluster":            resourceIBMContainerVpcClusterValidator(),
 				"ibm_container_cluster":                resourceIBMContainerClusterValidator(),
+				"ibm_container_vpc_alb_create":         resourceIBMContainerVpcALBCreateNewValidator(),
 			},
 			DataSourceValidatorDictionary: map[string]*ResourceValidator{
 				"ibm_is_subnet":               dataSourceIBMISSubnetValidator(),
@@ -676,6 +713,7 @@ func Validator() ValidatorDict {
 				"ibm_dl_routers":              datasourceIBMDLRoutersValidator(),
 				"ibm_is_vpc":                  dataSourceIBMISVpcValidator(),
 				"ibm_is_volume":               dataSourceIBMISVolumeValidator(),
+				"ibm_is_vpc_cidr_plan":        dataSourceIBMISVPCCIDRPlanValidator(),
 				"ibm_secrets_manager_secret":  datasourceIBMSecretsManagerSecretValidator(),
 				"ibm_secrets_manager_secrets": datasourceIBMSecretsManagerSecretsValidator(),
 			},
@@ -68,6 +68,15 @@ var hpcsInstanceID string
 var secretsManagerInstanceID string
 var secretsManagerSecretType string
 var secretsManagerSecretID string
+var secretsManagerInstanceCRN string
+var secretsManagerCertCRN string
+var secretsManagerSecretCRN string
+var clusterNameOrID string
+var clusterNlbIP string
+var crImageDigest string
+var networkGatewayID string
+var networkGatewayMemberHostname string
+var networkGatewayMemberHostKey string
 
 // For Power Colo
 
@@ -495,6 +504,51 @@ func init() {
 		fmt.Println("[WARN] Set the environment variable SECRETS_MANAGER_SECRET_ID for testing data_source_ibm_secrets_manager_secret_test else tests will fail if this is not set correctly")
 	}
 
+	secretsManagerInstanceCRN = os.Getenv("SECRETS_MANAGER_INSTANCE_CRN")
+	if secretsManagerInstanceCRN == "" {
+		fmt.Println("[INFO] Set the environment variable SECRETS_MANAGER_INSTANCE_CRN for testing ibm_container_ingress_instance resource else tests will fail if this is not set correctly")
+	}
+
+	secretsManagerCertCRN = os.Getenv("SECRETS_MANAGER_CERT_CRN")
+	if secretsManagerCertCRN == "" {
+		fmt.Println("[INFO] Set the environment variable SECRETS_MANAGER_CERT_CRN for testing ibm_container_ingress_secret_tls resource else tests will fail if this is not set correctly")
+	}
+
+	secretsManagerSecretCRN = os.Getenv("SECRETS_MANAGER_SECRET_CRN")
+	if secretsManagerSecretCRN == "" {
+		fmt.Println("[INFO] Set the environment variable SECRETS_MANAGER_SECRET_CRN for testing ibm_container_ingress_secret_opaque resource else tests will fail if this is not set correctly")
+	}
+
+	clusterNameOrID = os.Getenv("IBM_CONTAINER_CLUSTER")
+	if clusterNameOrID == "" {
+		fmt.Println("[INFO] Set the environment variable IBM_CONTAINER_CLUSTER for testing ibm_container_nlb_dns resource else tests will fail if this is not set correctly")
+	}
+
+	clusterNlbIP = os.Getenv("IBM_CONTAINER_NLB_IP")
+	if clusterNlbIP == "" {
+		fmt.Println("[INFO] Set the environment variable IBM_CONTAINER_NLB_IP for testing ibm_container_nlb_dns resource else tests will fail if this is not set correctly")
+	}
+
+	crImageDigest = os.Getenv("IBM_CR_IMAGE_DIGEST")
+	if crImageDigest == "" {
+		fmt.Println("[INFO] Set the environment variable IBM_CR_IMAGE_DIGEST for testing ibm_cr_vulnerability_report data source else tests will fail if this is not set correctly")
+	}
+
+	networkGatewayID = os.Getenv("IBM_NETWORK_GATEWAY_ID")
+	if networkGatewayID == "" {
+		fmt.Println("[INFO] Set the environment variable IBM_NETWORK_GATEWAY_ID for testing ibm_network_gateway_config resource else tests will fail if this is not set correctly")
+	}
+
+	networkGatewayMemberHostname = os.Getenv("IBM_NETWORK_GATEWAY_MEMBER_HOSTNAME")
+	if networkGatewayMemberHostname == "" {
+		fmt.Println("[INFO] Set the environment variable IBM_NETWORK_GATEWAY_MEMBER_HOSTNAME for testing ibm_network_gateway_config resource else tests will fail if this is not set correctly")
+	}
+
+	networkGatewayMemberHostKey = os.Getenv("IBM_NETWORK_GATEWAY_MEMBER_HOST_KEY")
+	if networkGatewayMemberHostKey == "" {
+		fmt.Println("[INFO] Set the environment variable IBM_NETWORK_GATEWAY_MEMBER_HOST_KEY for testing ibm_network_gateway_config resource else tests will fail if this is not set correctly")
+	}
+
 	tg_cross_network_account_id = os.Getenv("IBM_TG_CROSS_ACCOUNT_ID")
 	if tg_cross_network_account_id == "" {
 		fmt.Println("[INFO] Set the environment variable IBM_TG_CROSS_ACCOUNT_ID for testing ibm_tg_connection resource else  tests will fail if this is not set correctly")
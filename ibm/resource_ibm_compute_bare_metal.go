@@ -4,12 +4,14 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -30,6 +32,15 @@ func resourceIBMComputeBareMetal() *schema.Resource {
 		Exists:   resourceIBMComputeBareMetalExists,
 		Importer: &schema.ResourceImporter{},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return forceNewUnlessOSReload(diff, []string{"image_template_id", "os_reference_code"}, []string{"ssh_key_ids", "post_install_script_uri"})
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceIBMComputeBareMetalEstimateCost(diff, v)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 
 			"hostname": {
@@ -63,7 +74,6 @@ func resourceIBMComputeBareMetal() *schema.Resource {
 				Type:        schema.TypeList,
 				Optional:    true,
 				Elem:        &schema.Schema{Type: schema.TypeInt},
-				ForceNew:    true,
 				Description: "SSH KEY IDS list",
 			},
 
@@ -104,8 +114,7 @@ func resourceIBMComputeBareMetal() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Default:          nil,
-				ForceNew:         true,
-				DiffSuppressFunc: applyOnce,
+				DiffSuppressFunc: applyOnceUnlessOSReload,
 			},
 
 			"tags": {
@@ -129,20 +138,25 @@ func resourceIBMComputeBareMetal() *schema.Resource {
 				Type:             schema.TypeString,
 				Optional:         true,
 				Computed:         true,
-				ForceNew:         true,
 				ConflictsWith:    []string{"image_template_id"},
-				DiffSuppressFunc: applyOnce,
+				DiffSuppressFunc: applyOnceUnlessOSReload,
 				Description:      "OS refernece code value",
 			},
 
 			"image_template_id": {
 				Type:          schema.TypeInt,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"os_reference_code"},
 				Description:   "OS image template ID",
 			},
 
+			"reload_os_on_image_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reload the operating system in place instead of re-creating the server when the image changes",
+			},
+
 			"datacenter": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -440,11 +454,21 @@ func resourceIBMComputeBareMetal() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			estimatedHourlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated hourly cost of the order, quoted by verifyOrder at plan time",
+			},
+			estimatedMonthlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated monthly cost of the order, quoted by verifyOrder at plan time",
+			},
 		},
 	}
 }
 
-func getBareMetalOrderFromResourceData(d *schema.ResourceData, meta interface{}) (datatypes.Hardware, error) {
+func getBareMetalOrderFromResourceData(d dataRetriever, meta interface{}) (datatypes.Hardware, error) {
 	dc := datatypes.Location{
 		Name: sl.String(d.Get("datacenter").(string)),
 	}
@@ -550,52 +574,10 @@ func resourceIBMComputeBareMetalCreate(d *schema.ResourceData, meta interface{})
 			order.Hardware,
 			hardware,
 		)
-	} else if _, ok := d.GetOk("fixed_config_preset"); ok {
-		// Build an hourly bare metal server template using fixed_config_preset.
-		hardware, err = getBareMetalOrderFromResourceData(d, meta)
-		if err != nil {
-			return err
-		}
-		order, err = services.GetHardwareService(sess).GenerateOrderTemplate(&hardware)
-		if err != nil {
-			return fmt.Errorf(
-				"Encountered problem trying to get the bare metal order template: %s", err)
-		}
-		items, err := product.GetPackageProducts(sess, *order.PackageId, productItemMaskWithPriceLocationGroupID)
-		if err != nil {
-			return err
-		}
-		redundantNetwork := d.Get("redundant_network").(bool)
-		unbondedNetwork := d.Get("unbonded_network").(bool)
-
-		if redundantNetwork || unbondedNetwork {
-			// Remove network price
-			prices := make([]datatypes.Product_Item_Price, len(order.Prices))
-			i := 0
-			for _, p := range order.Prices {
-				if !strings.Contains(*p.Item.Description, "Network Uplink") {
-					prices[i] = p
-					i++
-				}
-			}
-			portSpeed, err := findNetworkItemPriceId(items, d)
-			if err != nil {
-				return err
-			}
-			prices[i] = portSpeed
-			order.Prices = prices
-		}
-		err = setMonthlyHourlyCommonOrder(d, items, &order)
-		if err != nil {
-			return err
-		}
-
 	} else {
-		// Build a monthly bare metal server template
-		order, err = getMonthlyBareMetalOrder(d, meta)
+		order, err = buildBareMetalOrder(d, meta)
 		if err != nil {
-			return fmt.Errorf(
-				"Encountered problem trying to get the custom bare metal order template: %s", err)
+			return err
 		}
 	}
 
@@ -660,6 +642,63 @@ func resourceIBMComputeBareMetalCreate(d *schema.ResourceData, meta interface{})
 	return resourceIBMComputeBareMetalRead(d, meta)
 }
 
+// buildBareMetalOrder builds the product order for a bare metal server from
+// either its fixed_config_preset or its monthly package configuration.
+func buildBareMetalOrder(d dataRetriever, meta interface{}) (datatypes.Container_Product_Order, error) {
+	sess := meta.(ClientSession).SoftLayerSession()
+	var order datatypes.Container_Product_Order
+	var err error
+	if _, ok := d.GetOk("fixed_config_preset"); ok {
+		// Build an hourly bare metal server template using fixed_config_preset.
+		hardware, err := getBareMetalOrderFromResourceData(d, meta)
+		if err != nil {
+			return order, err
+		}
+		order, err = services.GetHardwareService(sess).GenerateOrderTemplate(&hardware)
+		if err != nil {
+			return order, fmt.Errorf(
+				"Encountered problem trying to get the bare metal order template: %s", err)
+		}
+		items, err := product.GetPackageProducts(sess, *order.PackageId, productItemMaskWithPriceLocationGroupID)
+		if err != nil {
+			return order, err
+		}
+		redundantNetwork := d.Get("redundant_network").(bool)
+		unbondedNetwork := d.Get("unbonded_network").(bool)
+
+		if redundantNetwork || unbondedNetwork {
+			// Remove network price
+			prices := make([]datatypes.Product_Item_Price, len(order.Prices))
+			i := 0
+			for _, p := range order.Prices {
+				if !strings.Contains(*p.Item.Description, "Network Uplink") {
+					prices[i] = p
+					i++
+				}
+			}
+			portSpeed, err := findNetworkItemPriceId(items, d)
+			if err != nil {
+				return order, err
+			}
+			prices[i] = portSpeed
+			order.Prices = prices
+		}
+		err = setMonthlyHourlyCommonOrder(d, items, &order)
+		if err != nil {
+			return order, err
+		}
+	} else {
+		// Build a monthly bare metal server template
+		order, err = getMonthlyBareMetalOrder(d, meta)
+		if err != nil {
+			return order, fmt.Errorf(
+				"Encountered problem trying to get the custom bare metal order template: %s", err)
+		}
+	}
+
+	return order, nil
+}
+
 func resourceIBMComputeBareMetalRead(d *schema.ResourceData, meta interface{}) error {
 	service := services.GetHardwareService(meta.(ClientSession).SoftLayerSession())
 
@@ -820,6 +859,54 @@ func resourceIBMComputeBareMetalUpdate(d *schema.ResourceData, meta interface{})
 		return err
 	}
 
+	// Only reachable with reload_os_on_image_change, otherwise the image forces a new resource
+	if d.HasChange("image_template_id") || d.HasChange("os_reference_code") {
+		err = reloadBareMetalOS(d, meta, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func reloadBareMetalOS(d *schema.ResourceData, meta interface{}, id int) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetHardwareServerService(sess)
+
+	billingItem, err := service.Id(id).Mask("id,package[id]").GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error retrieving billing item of bare metal server %d: %s", id, err)
+	}
+
+	sshKeyIDs := make([]int, 0)
+	for _, sshKeyID := range d.Get("ssh_key_ids").([]interface{}) {
+		sshKeyIDs = append(sshKeyIDs, sshKeyID.(int))
+	}
+
+	config, err := buildOSReloadConfig(sess, billingItem.Package, d.Get("image_template_id").(int),
+		d.Get("os_reference_code").(string), sshKeyIDs, d.Get("post_install_script_uri").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reloading the operating system of bare metal server %d", id)
+	_, err = service.Id(id).ReloadOperatingSystem(sl.String("FORCE"), &config)
+	if err != nil {
+		return fmt.Errorf("Error reloading the operating system of bare metal server %d: %s", id, err)
+	}
+
+	_, err = waitForOSReloadTransactionsToAppear(id, func() ([]datatypes.Provisioning_Version1_Transaction, error) {
+		return services.GetHardwareServerService(meta.(ClientSession).SoftLayerSession()).Id(id).GetActiveTransactions()
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = waitForNoBareMetalActiveTransactions(id, meta)
+	if err != nil {
+		return fmt.Errorf("Error waiting for the operating system reload of bare metal server %d: %s", id, err)
+	}
 	return nil
 }
 
@@ -1035,7 +1122,7 @@ func getItemPriceId(items []datatypes.Product_Item, categoryCode string, keyName
 		fmt.Errorf("Could not find the matching item with categorycode %s and keyName %s. Available item(s) is(are) %s", categoryCode, keyName, availableItems)
 }
 
-func getMonthlyBareMetalOrder(d *schema.ResourceData, meta interface{}) (datatypes.Container_Product_Order, error) {
+func getMonthlyBareMetalOrder(d dataRetriever, meta interface{}) (datatypes.Container_Product_Order, error) {
 	sess := meta.(ClientSession).SoftLayerSession()
 	// Validate attributes for monthly bare metal server ordering.
 	if d.Get("hourly_billing").(bool) {
@@ -1187,7 +1274,7 @@ func getMonthlyBareMetalOrder(d *schema.ResourceData, meta interface{}) (datatyp
 	return order, nil
 }
 
-func setMonthlyHourlyCommonOrder(d *schema.ResourceData, items []datatypes.Product_Item, order *datatypes.Container_Product_Order) error {
+func setMonthlyHourlyCommonOrder(d dataRetriever, items []datatypes.Product_Item, order *datatypes.Container_Product_Order) error {
 	if d.Get("redundant_power_supply").(bool) {
 		powerSupply, err := getItemPriceId(items, "power_supply", "REDUNDANT_POWER_SUPPLY")
 		if err != nil {
@@ -1262,7 +1349,7 @@ func setMonthlyHourlyCommonOrder(d *schema.ResourceData, items []datatypes.Produ
 }
 
 // Set common parameters for server ordering.
-func setCommonBareMetalOrderOptions(d *schema.ResourceData, meta interface{}, order datatypes.Container_Product_Order) (datatypes.Container_Product_Order, error) {
+func setCommonBareMetalOrderOptions(d dataRetriever, meta interface{}, order datatypes.Container_Product_Order) (datatypes.Container_Product_Order, error) {
 
 	extendedHardwareTesting := d.Get("extended_hardware_testing").(bool)
 	order.ExtendedHardwareTesting = sl.Bool(extendedHardwareTesting)
@@ -1482,7 +1569,18 @@ func applyOnce(k, o, n string, d *schema.ResourceData) bool {
 	return true
 }
 
-func addCommomDefaultPrices(d *schema.ResourceData, meta interface{}, order datatypes.Container_Product_Order, items []datatypes.Product_Item) datatypes.Container_Product_Order {
+// applyOnceUnlessOSReload lets the value change after creation when the operating system is reloaded on change.
+func applyOnceUnlessOSReload(k, o, n string, d *schema.ResourceData) bool {
+	if !d.Get("reload_os_on_image_change").(bool) {
+		return applyOnce(k, o, n, d)
+	}
+	if strings.HasSuffix(n, "_LATEST") && strings.Contains(o, strings.TrimSuffix(n, "_LATEST")) {
+		return true
+	}
+	return o == n
+}
+
+func addCommomDefaultPrices(d dataRetriever, meta interface{}, order datatypes.Container_Product_Order, items []datatypes.Product_Item) datatypes.Container_Product_Order {
 
 	if !d.Get("tcp_monitoring").(bool) {
 		monExists, moniotring := getCommonItemPriceID(items, "monitoring", "MONITORING_HOST_PING")
@@ -1600,3 +1698,27 @@ func getCapacityRestrictionType(items []datatypes.Product_Item, categoryCode str
 	}
 	return ""
 }
+
+// resourceIBMComputeBareMetalEstimateCost quotes the cost of a new bare metal
+// server, and of a server whose configuration or operating system changes, at
+// plan time. Orders from a quote are not estimated.
+func resourceIBMComputeBareMetalEstimateCost(diff *schema.ResourceDiff, meta interface{}) error {
+	dependsOn := []string{"datacenter", "public_vlan_id", "private_vlan_id", "public_subnet", "private_subnet", "image_template_id",
+		"os_reference_code", "package_key_name", "fixed_config_preset", "process_key_name", "memory", "disk_key_names",
+		"storage_groups", "network_speed", "redundant_network", "unbonded_network", "redundant_power_supply",
+		"gpu_key_name", "gpu_secondary_key_name", "hourly_billing"}
+	return resourceClassicOrderEstimateCustomizeDiff(diff, meta, dependsOn, func(sess *session.Session) (interface{}, error) {
+		if diff.Get("quote_id").(int) > 0 {
+			return nil, nil
+		}
+		order, err := buildBareMetalOrder(diff, meta)
+		if err != nil {
+			return nil, err
+		}
+		order, err = setCommonBareMetalOrderOptions(diff, meta, order)
+		if err != nil {
+			return nil, err
+		}
+		return &order, nil
+	})
+}
@@ -74,6 +74,44 @@ func TestAccIBMComputeBareMetal_Basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMComputeBareMetal_ReloadOS(t *testing.T) {
+	var bareMetal, reloadedBareMetal datatypes.Hardware
+	configName := "ibm_compute_bare_metal.terraform-acceptance-test-reload"
+	hostname := acctest.RandString(16)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMComputeBareMetalDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMComputeBareMetalConfigReloadOS(hostname, "UBUNTU_16_64", "https://www.google.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMComputeBareMetalExists(configName, &bareMetal),
+					resource.TestCheckResourceAttr(
+						configName, "post_install_script_uri", "https://www.google.com"),
+				),
+			},
+			{
+				Config: testAccCheckIBMComputeBareMetalConfigReloadOS(hostname, "UBUNTU_18_64", "https://www.ibm.com"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMComputeBareMetalExists(configName, &reloadedBareMetal),
+					resource.TestCheckResourceAttr(
+						configName, "os_reference_code", "UBUNTU_18_64"),
+					resource.TestCheckResourceAttr(
+						configName, "post_install_script_uri", "https://www.ibm.com"),
+					func(s *terraform.State) error {
+						if *bareMetal.Id != *reloadedBareMetal.Id {
+							return fmt.Errorf("Bare metal server was re-created: %d != %d", *bareMetal.Id, *reloadedBareMetal.Id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccIBMComputeBareMetal_With_IPV6(t *testing.T) {
 	var bareMetal datatypes.Hardware
 	configName := "ibm_compute_bare_metal.terraform-acceptance-test-1"
@@ -411,6 +449,23 @@ resource "ibm_compute_bare_metal" "terraform-acceptance-test-1" {
 `, hostname, extendedHardwareTesting)
 }
 
+func testAccCheckIBMComputeBareMetalConfigReloadOS(hostname, osReferenceCode, postInstallScriptURI string) string {
+	return fmt.Sprintf(`
+resource "ibm_compute_bare_metal" "terraform-acceptance-test-reload" {
+  hostname                  = "%s"
+  domain                    = "terraformuat.ibm.com"
+  os_reference_code         = "%s"
+  datacenter                = "dal10"
+  network_speed             = 100
+  hourly_billing            = true
+  private_network_only      = false
+  fixed_config_preset       = "S1270_32GB_1X1TBSATA_NORAID"
+  post_install_script_uri   = "%s"
+  reload_os_on_image_change = true
+}
+`, hostname, osReferenceCode, postInstallScriptURI)
+}
+
 func testAccCheckIBMComputeBareMetalConfig_update(hostname string) string {
 	return fmt.Sprintf(`
 resource "ibm_compute_bare_metal" "terraform-acceptance-test-1" {
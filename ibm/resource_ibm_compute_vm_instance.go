@@ -5,6 +5,7 @@ package ibm
 
 import (
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
@@ -16,6 +17,7 @@ import (
 	"time"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/internal/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -50,6 +52,9 @@ const (
 	pendingUpgrade     = "pending_upgrade"
 	inProgressUpgrade  = "upgrade_started"
 
+	pendingOSReload    = "pending_reload"
+	inProgressOSReload = "reload_started"
+
 	activeTransaction = "active"
 	idleTransaction   = "idle"
 
@@ -75,6 +80,15 @@ func resourceIBMComputeVmInstance() *schema.Resource {
 			Update: schema.DefaultTimeout(90 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return forceNewUnlessOSReload(diff, []string{"image_id", "os_reference_code"}, []string{"post_install_script_uri"})
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceIBMComputeVmInstanceEstimateCost(diff, v)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 			"hostname": {
 				Type:          schema.TypeString,
@@ -137,7 +151,6 @@ func resourceIBMComputeVmInstance() *schema.Resource {
 			"os_reference_code": {
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
 				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
 					if strings.HasSuffix(n, "_LATEST") {
 						t := strings.Trim(n, "_LATEST")
@@ -486,16 +499,21 @@ func resourceIBMComputeVmInstance() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  nil,
-				ForceNew: true,
 			},
 
 			"image_id": {
 				Type:          schema.TypeInt,
 				Optional:      true,
-				ForceNew:      true,
 				ConflictsWith: []string{"os_reference_code"},
 			},
 
+			"reload_os_on_image_change": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reload the operating system in place instead of re-creating the instance when the image changes",
+			},
+
 			"tags": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -561,6 +579,16 @@ func resourceIBMComputeVmInstance() *schema.Resource {
 				Computed:    true,
 				Description: "The status of the resource",
 			},
+			estimatedHourlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated hourly cost of the order, quoted by verifyOrder at plan time",
+			},
+			estimatedMonthlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated monthly cost of the order, quoted by verifyOrder at plan time",
+			},
 		},
 	}
 }
@@ -628,7 +656,7 @@ func getNameForBlockDeviceWithFlavor(i int) string {
 	return strconv.Itoa(i + 2)
 }
 
-func getBlockDevices(d *schema.ResourceData) []datatypes.Virtual_Guest_Block_Device {
+func getBlockDevices(d dataRetriever) []datatypes.Virtual_Guest_Block_Device {
 	numBlocks := d.Get("disks.#").(int)
 	if numBlocks == 0 {
 		return nil
@@ -670,7 +698,7 @@ func expandSecurityGroupBindings(securityGroupsList []interface{}) ([]datatypes.
 	return sgBindings, nil
 }
 
-func getVirtualGuestTemplateFromResourceData(d *schema.ResourceData, meta interface{}, datacenter string, publicVlanID, privateVlanID, quote_id int) ([]datatypes.Virtual_Guest, error) {
+func getVirtualGuestTemplateFromResourceData(d dataRetriever, meta interface{}, datacenter string, publicVlanID, privateVlanID, quote_id int) ([]datatypes.Virtual_Guest, error) {
 
 	dc := datatypes.Location{
 		Name: sl.String(datacenter),
@@ -1473,9 +1501,165 @@ func resourceIBMComputeVmInstanceUpdate(d *schema.ResourceData, meta interface{}
 
 	}
 
+	// Only reachable with reload_os_on_image_change, otherwise the image forces a new resource
+	if d.HasChange("image_id") || d.HasChange("os_reference_code") {
+		for _, part := range parts {
+			guestID, err := strconv.Atoi(part)
+			if err != nil {
+				return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+			}
+			err = reloadVirtualGuestOS(d, meta, guestID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	return resourceIBMComputeVmInstanceRead(d, meta)
 }
 
+func reloadVirtualGuestOS(d *schema.ResourceData, meta interface{}, id int) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetVirtualGuestService(sess)
+
+	billingItem, err := service.Id(id).Mask("id,package[id]").GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error retrieving billing item of virtual guest %d: %s", id, err)
+	}
+
+	sshKeyIDs := make([]int, 0)
+	for _, sshKeyID := range d.Get("ssh_key_ids").(*schema.Set).List() {
+		sshKeyIDs = append(sshKeyIDs, sshKeyID.(int))
+	}
+
+	config, err := buildOSReloadConfig(sess, billingItem.Package, d.Get("image_id").(int),
+		d.Get("os_reference_code").(string), sshKeyIDs, d.Get("post_install_script_uri").(string))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[INFO] Reloading the operating system of virtual guest %d", id)
+	_, err = service.Id(id).ReloadOperatingSystem(sl.String("FORCE"), &config)
+	if err != nil {
+		return fmt.Errorf("Error reloading the operating system of virtual guest %d: %s", id, err)
+	}
+
+	_, err = waitForOSReloadTransactionsToAppear(id, func() ([]datatypes.Provisioning_Version1_Transaction, error) {
+		return services.GetVirtualGuestService(meta.(ClientSession).SoftLayerSession()).Id(id).GetActiveTransactions()
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = WaitForNoActiveTransactions(id, d, d.Timeout(schema.TimeoutUpdate), meta)
+	return err
+}
+
+// forceNewUnlessOSReload replaces the server when the image changes, unless
+// reload_os_on_image_change is set. The reloadKeys are only applied by a reload,
+// so they replace the server when they change on their own.
+func forceNewUnlessOSReload(diff *schema.ResourceDiff, imageKeys, reloadKeys []string) error {
+	if diff.Id() == "" {
+		return nil
+	}
+
+	if diff.Get("reload_os_on_image_change").(bool) {
+		for _, key := range imageKeys {
+			if diff.HasChange(key) {
+				return nil
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(imageKeys)+len(reloadKeys))
+	keys = append(keys, imageKeys...)
+	keys = append(keys, reloadKeys...)
+	for _, key := range keys {
+		if diff.HasChange(key) {
+			if err := diff.ForceNew(key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// buildOSReloadConfig builds the reload configuration of a virtual guest or bare metal server.
+// An operating system reference code is resolved against the package the server was ordered from.
+func buildOSReloadConfig(sess *session.Session, pkg *datatypes.Product_Package, imageTemplateID int, osReferenceCode string, sshKeyIDs []int, postInstallURI string) (datatypes.Container_Hardware_Server_Configuration, error) {
+	config := datatypes.Container_Hardware_Server_Configuration{
+		SshKeyIds: sshKeyIDs,
+	}
+	if postInstallURI != "" {
+		config.CustomProvisionScriptUri = sl.String(postInstallURI)
+	}
+
+	if imageTemplateID > 0 {
+		config.ImageTemplateId = sl.Int(imageTemplateID)
+		return config, nil
+	}
+
+	if osReferenceCode == "" {
+		return config, fmt.Errorf("One of image ID or os_reference_code is required to reload the operating system")
+	}
+	if pkg == nil || pkg.Id == nil {
+		return config, fmt.Errorf("Unable to find the package of the server to reload %s", osReferenceCode)
+	}
+
+	items, err := product.GetPackageProducts(sess, *pkg.Id, "id,keyName,softwareDescription[referenceCode],prices[id,locationGroupId]")
+	if err != nil {
+		return config, fmt.Errorf("Error retrieving items of package %d: %s", *pkg.Id, err)
+	}
+	for _, item := range items {
+		if item.SoftwareDescription == nil || item.SoftwareDescription.ReferenceCode == nil ||
+			*item.SoftwareDescription.ReferenceCode != osReferenceCode {
+			continue
+		}
+		for _, price := range item.Prices {
+			// Standard prices have no location group
+			if price.LocationGroupId == nil {
+				config.ItemPrices = []datatypes.Product_Item_Price{
+					{
+						Id: price.Id,
+					},
+				}
+				return config, nil
+			}
+		}
+	}
+
+	return config, fmt.Errorf("No operating system price found for %s in package %d", osReferenceCode, *pkg.Id)
+}
+
+// waitForOSReloadTransactionsToAppear waits for the reload to start, so that waiting for zero
+// active transactions afterwards does not return before the reload has begun.
+func waitForOSReloadTransactionsToAppear(id int, activeTransactions func() ([]datatypes.Provisioning_Version1_Transaction, error)) (interface{}, error) {
+	log.Printf("Waiting for server (%d) to start the operating system reload", id)
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"retry", pendingOSReload},
+		Target:  []string{inProgressOSReload},
+		Refresh: func() (interface{}, string, error) {
+			transactions, err := activeTransactions()
+			if err != nil {
+				if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+					return nil, "", fmt.Errorf("Couldn't fetch active transactions: %s", err)
+				}
+				return false, "retry", nil
+			}
+			if len(transactions) > 0 {
+				return transactions, inProgressOSReload, nil
+			}
+			return transactions, pendingOSReload, nil
+		},
+		Timeout:    10 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
 func modifyStorageAccess(sam storageAccessModifier, deviceID int, meta interface{}, d *schema.ResourceData) error {
 	var remove, add []int
 	if d.HasChange("file_storage_ids") {
@@ -1861,17 +2045,15 @@ func setNotes(id int, d *schema.ResourceData, meta interface{}) error {
 
 func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVlanID, privateVlanID, quote_id int) (datatypes.Container_Product_Order_Receipt, error) {
 	sess := meta.(ClientSession).SoftLayerSession()
-	service := services.GetVirtualGuestService(sess)
 
-	options, err := getVirtualGuestTemplateFromResourceData(d, meta, name, publicVlanID, privateVlanID, quote_id)
-	if err != nil {
-		return datatypes.Container_Product_Order_Receipt{}, err
-	}
-	guestOrders := make([]datatypes.Container_Product_Order, 0)
-	var template datatypes.Container_Product_Order
 	if quote_id > 0 {
+		options, err := getVirtualGuestTemplateFromResourceData(d, meta, name, publicVlanID, privateVlanID, quote_id)
+		if err != nil {
+			return datatypes.Container_Product_Order_Receipt{}, err
+		}
+		guestOrders := make([]datatypes.Container_Product_Order, 0)
 		// Build a virtual instance template from the quote.
-		template, err = services.GetBillingOrderQuoteService(sess).
+		template, err := services.GetBillingOrderQuoteService(sess).
 			Id(quote_id).GetRecalculatedOrderContainer(nil, sl.Bool(false))
 		if err != nil {
 			return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf(
@@ -1918,11 +2100,33 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 			Id(quote_id).PlaceOrder(order)
 		return receipt, err1
 	}
+	order, err := buildVirtualGuestOrder(d, meta, name, publicVlanID, privateVlanID)
+	if err != nil {
+		return datatypes.Container_Product_Order_Receipt{}, err
+	}
+
+	log.Println("[INFO] Creating virtual machine")
+	orderService := services.GetProductOrderService(sess.SetRetries(0))
+	receipt, err1 := orderService.PlaceOrder(order, sl.Bool(false))
+	return receipt, err1
+
+}
+
+// buildVirtualGuestOrder builds the product order for the virtual guests
+// described by d, one order container per guest.
+func buildVirtualGuestOrder(d dataRetriever, meta interface{}, name string, publicVlanID, privateVlanID int) (*datatypes.Container_Product_Order, error) {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetVirtualGuestService(sess)
+
+	options, err := getVirtualGuestTemplateFromResourceData(d, meta, name, publicVlanID, privateVlanID, 0)
+	if err != nil {
+		return nil, err
+	}
+	guestOrders := make([]datatypes.Container_Product_Order, 0)
+	var template datatypes.Container_Product_Order
 	for i := 0; i < len(options); i++ {
 		opts := options[i]
 
-		log.Println("[INFO] Creating virtual machine")
-
 		// Build an order template with a custom image.
 		if opts.BlockDevices != nil && opts.BlockDeviceTemplateGroup != nil {
 			bd := *opts.BlockDeviceTemplateGroup
@@ -1930,7 +2134,7 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 			opts.OperatingSystemReferenceCode = sl.String("UBUNTU_LATEST")
 			template, err = service.GenerateOrderTemplate(&opts)
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+				return nil, fmt.Errorf("Error generating order template: %s", err)
 			}
 
 			// Remove temporary OS from actual order
@@ -1951,13 +2155,13 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 			// Build an order template with os_reference_code
 			template, err = service.GenerateOrderTemplate(&opts)
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+				return nil, fmt.Errorf("Error generating order template: %s", err)
 			}
 		}
 
 		items, err := product.GetPackageProducts(sess, *template.PackageId, productItemMaskWithPriceLocationGroupID)
 		if err != nil {
-			return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+			return nil, fmt.Errorf("Error generating order template: %s", err)
 		}
 
 		privateNetworkOnly := d.Get("private_network_only").(bool)
@@ -1965,34 +2169,34 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 		secondaryIPCount := d.Get("secondary_ip_count").(int)
 		if secondaryIPCount > 0 {
 			if privateNetworkOnly {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Unable to configure public secondary addresses with a private_network_only option")
+				return nil, fmt.Errorf("Unable to configure public secondary addresses with a private_network_only option")
 			}
 			keyName := strconv.Itoa(secondaryIPCount) + "_PUBLIC_IP_ADDRESSES"
 			price, err := getItemPriceId(items, "sec_ip_addresses", keyName)
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, err
+				return nil, err
 			}
 			template.Prices = append(template.Prices, price)
 		}
 
 		if d.Get("ipv6_enabled").(bool) {
 			if privateNetworkOnly {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Unable to configure a public IPv6 address with a private_network_only option")
+				return nil, fmt.Errorf("Unable to configure a public IPv6 address with a private_network_only option")
 			}
 			price, err := getItemPriceId(items, "pri_ipv6_addresses", "1_IPV6_ADDRESS")
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+				return nil, fmt.Errorf("Error generating order template: %s", err)
 			}
 			template.Prices = append(template.Prices, price)
 		}
 
 		if d.Get("ipv6_static_enabled").(bool) {
 			if privateNetworkOnly {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Unable to configure a public static IPv6 address with a private_network_only option")
+				return nil, fmt.Errorf("Unable to configure a public static IPv6 address with a private_network_only option")
 			}
 			price, err := getItemPriceId(items, "static_ipv6_addresses", "64_BLOCK_STATIC_PUBLIC_IPV6_ADDRESSES")
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+				return nil, fmt.Errorf("Error generating order template: %s", err)
 			}
 			template.Prices = append(template.Prices, price)
 		}
@@ -2001,7 +2205,7 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 		// Add public bandwidth limited
 		if publicBandwidth, ok := d.GetOk("public_bandwidth_limited"); ok {
 			if *opts.HourlyBillingFlag {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Unable to configure a public bandwidth with a hourly_billing true")
+				return nil, fmt.Errorf("Unable to configure a public bandwidth with a hourly_billing true")
 			}
 			// Remove Default bandwidth price
 			prices := make([]datatypes.Product_Item_Price, len(template.Prices))
@@ -2020,7 +2224,7 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 			keyName := "BANDWIDTH_" + strconv.Itoa(publicBandwidth.(int)) + "_GB"
 			price, err := getItemPriceId(items, "bandwidth", keyName)
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+				return nil, fmt.Errorf("Error generating order template: %s", err)
 			}
 			template.Prices = append(template.Prices, price)
 		}
@@ -2029,11 +2233,11 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 		publicUnlimitedBandwidth := d.Get("public_bandwidth_unlimited").(bool)
 		if publicUnlimitedBandwidth {
 			if *opts.HourlyBillingFlag {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Unable to configure a public bandwidth with a hourly_billing true")
+				return nil, fmt.Errorf("Unable to configure a public bandwidth with a hourly_billing true")
 			}
 			networkSpeed := d.Get("network_speed").(int)
 			if networkSpeed != 100 {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Network speed must be 100 Mbps to configure public bandwidth unlimited")
+				return nil, fmt.Errorf("Network speed must be 100 Mbps to configure public bandwidth unlimited")
 			}
 			// Remove Default bandwidth price
 			prices := make([]datatypes.Product_Item_Price, len(template.Prices))
@@ -2051,20 +2255,20 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 			template.Prices = prices[:i]
 			price, err := getItemPriceId(items, "bandwidth", "BANDWIDTH_UNLIMITED_100_MBPS_UPLINK")
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+				return nil, fmt.Errorf("Error generating order template: %s", err)
 			}
 			template.Prices = append(template.Prices, price)
 		}
 
 		if evault, ok := d.GetOk("evault"); ok {
 			if *opts.HourlyBillingFlag {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Unable to configure a evault with hourly_billing true")
+				return nil, fmt.Errorf("Unable to configure a evault with hourly_billing true")
 			}
 
 			keyName := "EVAULT_" + strconv.Itoa(evault.(int)) + "_GB"
 			price, err := getItemPriceId(items, "evault", keyName)
 			if err != nil {
-				return datatypes.Container_Product_Order_Receipt{}, fmt.Errorf("Error generating order template: %s", err)
+				return nil, fmt.Errorf("Error generating order template: %s", err)
 			}
 			template.Prices = append(template.Prices, price)
 		}
@@ -2079,9 +2283,22 @@ func placeOrder(d *schema.ResourceData, meta interface{}, name string, publicVla
 	order := &datatypes.Container_Product_Order{
 		OrderContainers: guestOrders,
 	}
+	return order, nil
+}
 
-	orderService := services.GetProductOrderService(sess.SetRetries(0))
-	receipt, err1 := orderService.PlaceOrder(order, sl.Bool(false))
-	return receipt, err1
-
+// resourceIBMComputeVmInstanceEstimateCost quotes the cost of new virtual
+// guests, and of virtual guests whose flavor, cores, memory, disks or network
+// speed change, at plan time. Orders from a quote or a datacenter_choice are
+// not estimated.
+func resourceIBMComputeVmInstanceEstimateCost(diff *schema.ResourceDiff, meta interface{}) error {
+	dependsOn := []string{"datacenter", "public_vlan_id", "private_vlan_id", "public_subnet", "private_subnet",
+		"image_id", "os_reference_code", "dedicated_host_id", "placement_group_id", "bulk_vms", "disks",
+		"flavor_key_name", "cores", "memory", "network_speed", "local_disk", "hourly_billing", "dedicated_acct_host_only"}
+	return resourceClassicOrderEstimateCustomizeDiff(diff, meta, dependsOn, func(sess *session.Session) (interface{}, error) {
+		datacenter := diff.Get("datacenter").(string)
+		if datacenter == "" || diff.Get("quote_id").(int) > 0 {
+			return nil, nil
+		}
+		return buildVirtualGuestOrder(diff, meta, datacenter, diff.Get("public_vlan_id").(int), diff.Get("private_vlan_id").(int))
+	})
 }
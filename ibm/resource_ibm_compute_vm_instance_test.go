@@ -34,6 +34,8 @@ func TestAccIBMComputeVMInstance_basic(t *testing.T) {
 	userMetadata1 := "{\\\"value\\\":\\\"newvalue\\\"}"
 	userMetadata1Unquoted, _ := strconv.Unquote(`"` + userMetadata1 + `"`)
 
+	var estimatedCost string
+
 	configInstance := "ibm_compute_vm_instance.terraform-acceptance-test-1"
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -91,6 +93,7 @@ func TestAccIBMComputeVMInstance_basic(t *testing.T) {
 						configInstance, "secondary_ip_addresses.3"),
 					resource.TestCheckResourceAttr(
 						configInstance, "notes", "VM notes"),
+					testAccIBMComputeVMInstanceEstimatedCost(configInstance, &estimatedCost),
 				),
 			},
 
@@ -118,6 +121,7 @@ func TestAccIBMComputeVMInstance_basic(t *testing.T) {
 						configInstance, "memory", memory2),
 					resource.TestCheckResourceAttr(
 						configInstance, "network_speed", networkSpeed2),
+					testAccIBMComputeVMInstanceEstimatedCost(configInstance, &estimatedCost),
 				),
 			},
 
@@ -507,6 +511,44 @@ func TestAccIBMComputeVMInstance_PostInstallScriptUri(t *testing.T) {
 	})
 }
 
+func TestAccIBMComputeVMInstance_ReloadOS(t *testing.T) {
+	var guest, reloadedGuest datatypes.Virtual_Guest
+
+	hostname := acctest.RandString(16)
+	domain := "reload.terraformvmuat.ibm.com"
+	configInstance := "ibm_compute_vm_instance.terraform-acceptance-test-reload"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccIBMComputeVMInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIBMComputeVMInstanceConfigReloadOS(hostname, domain, "DEBIAN_9_64"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccIBMComputeVMInstanceExists(configInstance, &guest),
+					resource.TestCheckResourceAttr(
+						configInstance, "reload_os_on_image_change", "true"),
+				),
+			},
+			{
+				Config: testAccIBMComputeVMInstanceConfigReloadOS(hostname, domain, "UBUNTU_18_64"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccIBMComputeVMInstanceExists(configInstance, &reloadedGuest),
+					resource.TestCheckResourceAttr(
+						configInstance, "os_reference_code", "UBUNTU_18_64"),
+					func(s *terraform.State) error {
+						if *guest.Id != *reloadedGuest.Id {
+							return fmt.Errorf("Virtual guest was re-created: %d != %d", *guest.Id, *reloadedGuest.Id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
 func TestAccIBMComputeVMInstance_WINDOWS_PostInstallScriptUri(t *testing.T) {
 	var guest datatypes.Virtual_Guest
 
@@ -933,6 +975,26 @@ func testAccIBMComputeVMInstanceDestroy(s *terraform.State) error {
 	return nil
 }
 
+// testAccIBMComputeVMInstanceEstimatedCost checks that the estimated hourly
+// cost is set, and that it changed since the previous check.
+func testAccIBMComputeVMInstanceEstimatedCost(n string, cost *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		estimated := rs.Primary.Attributes["estimated_hourly_cost"]
+		if estimated == "" {
+			return fmt.Errorf("No estimated_hourly_cost is set on %s", n)
+		}
+		if estimated == *cost {
+			return fmt.Errorf("The estimated_hourly_cost of %s was not estimated again after the upgrade: %s", n, estimated)
+		}
+		*cost = estimated
+		return nil
+	}
+}
+
 func testAccIBMComputeVMInstanceExists(n string, guest *datatypes.Virtual_Guest) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -1133,6 +1195,24 @@ resource "ibm_compute_vm_instance" "terraform-acceptance-test-pISU" {
 }`, hostname, domain)
 }
 
+func testAccIBMComputeVMInstanceConfigReloadOS(hostname, domain, osReferenceCode string) string {
+	return fmt.Sprintf(`
+resource "ibm_compute_vm_instance" "terraform-acceptance-test-reload" {
+    hostname = "%s"
+    domain = "%s"
+    os_reference_code = "%s"
+    datacenter = "wdc04"
+    network_speed = 10
+    hourly_billing = true
+    private_network_only = false
+    cores = 1
+    memory = 1024
+    disks = [25]
+    local_disk = false
+    reload_os_on_image_change = true
+}`, hostname, domain, osReferenceCode)
+}
+
 func testAccIBMComputeVMInstanceConfigWindowsPostInstallScriptURI(hostname, domain string) string {
 	return fmt.Sprintf(`
 resource "ibm_compute_vm_instance" "terraform-acceptance-test-pISU" {
@@ -8,9 +8,13 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -49,12 +53,12 @@ const PUBLIC_SUBNET_TYPE = "public"
 
 func resourceIBMContainerCluster() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMContainerClusterCreate,
-		Read:     resourceIBMContainerClusterRead,
-		Update:   resourceIBMContainerClusterUpdate,
-		Delete:   resourceIBMContainerClusterDelete,
-		Exists:   resourceIBMContainerClusterExists,
-		Importer: &schema.ResourceImporter{},
+		Create:      resourceIBMContainerClusterCreate,
+		ReadContext: resourceIBMContainerClusterReadContext,
+		Update:      resourceIBMContainerClusterUpdate,
+		Delete:      resourceIBMContainerClusterDelete,
+		Exists:      resourceIBMContainerClusterExists,
+		Importer:    &schema.ResourceImporter{},
 
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
@@ -66,6 +70,12 @@ func resourceIBMContainerCluster() *schema.Resource {
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 				return resourceTagsCustomizeDiff(diff)
 			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceWorkerUpdateCustomizeDiff(diff, nil)
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceKubeVersionCustomizeDiff(diff, v)
+			},
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -179,7 +189,7 @@ func resourceIBMContainerCluster() *schema.Resource {
 				Computed: true,
 				Optional: true,
 				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
-					if o == "" {
+					if o == "" || isKubeVersionChannel(n) {
 						return false
 					}
 					new := strings.Split(n, ".")
@@ -190,7 +200,22 @@ func resourceIBMContainerCluster() *schema.Resource {
 					}
 					return false
 				},
-				Description: "Kubernetes version info",
+				Description: "Kubernetes version, or a channel that resolves to the default version, the latest version or the latest patch of a minor version: default, latest or major.minor.x",
+			},
+
+			"auto_upgrade_minor_version": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Upgrade the master to the next minor version when the default or latest kube_version channel moves to a newer minor version",
+			},
+
+			"end_of_support_warning_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Warn when the Kubernetes version of the master reaches its end of support within the number of days, 0 disables the warning",
 			},
 
 			"patch_version": {
@@ -279,6 +304,8 @@ func resourceIBMContainerCluster() *schema.Resource {
 				Default:     true,
 				Description: "Wait for worker node to update during kube version update.",
 			},
+			"update_strategy":      workerUpdateStrategySchema(true),
+			"worker_update_status": workerUpdateStatusSchema(),
 			"wait_till": {
 				Type:             schema.TypeString,
 				Optional:         true,
@@ -690,6 +717,13 @@ func resourceIBMContainerClusterCreate(d *schema.ResourceData, meta interface{})
 	return resourceIBMContainerClusterUpdate(d, meta)
 }
 
+func resourceIBMContainerClusterReadContext(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := resourceIBMContainerClusterRead(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	return kubeVersionSupportDiagnostics(d, meta)
+}
+
 func resourceIBMContainerClusterRead(d *schema.ResourceData, meta interface{}) error {
 	csClient, err := meta.(ClientSession).ContainerAPI()
 	if err != nil {
@@ -865,7 +899,7 @@ func resourceIBMContainerClusterUpdate(d *schema.ResourceData, meta interface{})
 
 	clusterID := d.Id()
 
-	if (d.HasChange("kube_version") || d.HasChange("update_all_workers") || d.HasChange("patch_version") || d.HasChange("retry_patch_version")) && !d.IsNewResource() {
+	if (d.HasChange("kube_version") || d.HasChange("update_all_workers") || d.HasChange("patch_version") || d.HasChange("retry_patch_version") || d.HasChange("worker_update_status")) && !d.IsNewResource() {
 		if d.HasChange("kube_version") {
 			var masterVersion string
 			if v, ok := d.GetOk("kube_version"); ok {
@@ -889,7 +923,7 @@ func resourceIBMContainerClusterUpdate(d *schema.ResourceData, meta interface{})
 		// "update_all_workers" deafult is false, enable to true when all worker nodes to be updated
 		// with major and minor updates.
 		updateAllWorkers := d.Get("update_all_workers").(bool)
-		if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") {
+		if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") || d.HasChange("worker_update_status") {
 			patchVersion := d.Get("patch_version").(string)
 			workerFields, err := wrkAPI.List(clusterID, targetEnv)
 			if err != nil {
@@ -902,29 +936,47 @@ func resourceIBMContainerClusterUpdate(d *schema.ResourceData, meta interface{})
 
 			waitForWorkerUpdate := d.Get("wait_for_worker_update").(bool)
 
+			/*kubeversion update done if
+			1. There is a change in Major.Minor version
+			2. Therese is a change in patch_version & Traget kube patch version and patch_version are same
+			*/
+			workers := make([]workerUpdateCandidate, 0, len(workerFields))
 			for _, w := range workerFields {
-				/*kubeversion update done if
-				1. There is a change in Major.Minor version
-				2. Therese is a change in patch_version & Traget kube patch version and patch_version are same
-				*/
-				if strings.Split(w.KubeVersion, "_")[0] != strings.Split(cluster.MasterKubeVersion, "_")[0] || (strings.Split(w.KubeVersion, ".")[2] != patchVersion && strings.Split(w.TargetVersion, ".")[2] == patchVersion) {
+				workers = append(workers, workerUpdateCandidate{
+					id:          w.ID,
+					pool:        w.PoolName,
+					poolID:      w.PoolID,
+					zone:        w.Location,
+					normal:      w.State == workerNormal,
+					needsUpdate: workerNeedsUpdate(w.KubeVersion, w.TargetVersion, cluster.MasterKubeVersion, patchVersion),
+				})
+			}
+			strategy := expandWorkerUpdateStrategy(d)
+			batches, skipped := planWorkerUpdate(workers, strategy)
+			status := workerUpdateStatus{targetVersion: cluster.MasterKubeVersion, skipped: skipped}
+			err = rollWorkerUpdate(batches, strategy, &status, func(ids []string) ([]string, []string, error) {
+				for i, id := range ids {
 					params := v1.WorkerUpdateParam{
 						Action: "update",
 					}
-					err = wrkAPI.Update(clusterID, w.ID, params, targetEnv)
+					err := wrkAPI.Update(clusterID, id, params, targetEnv)
 					if err != nil {
-						d.Set("patch_version", nil)
-						return fmt.Errorf("Error updating worker %s: %s", w.ID, err)
+						return ids[:i], ids[i:], fmt.Errorf("Error updating worker %s: %s", id, err)
 					}
-					if waitForWorkerUpdate {
-						_, err = WaitForWorkerAvailable(d, meta, targetEnv)
-						if err != nil {
-							d.Set("patch_version", nil)
-							return fmt.Errorf(
-								"Error waiting for workers of cluster (%s) to become ready: %s", d.Id(), err)
-						}
+				}
+				if waitForWorkerUpdate {
+					_, err := WaitForWorkerAvailable(d, meta, targetEnv)
+					if err != nil {
+						return nil, ids, fmt.Errorf(
+							"Error waiting for workers of cluster (%s) to become ready: %s", d.Id(), err)
 					}
 				}
+				return ids, nil, nil
+			})
+			d.Set("worker_update_status", flattenWorkerUpdateStatus(status))
+			if err != nil {
+				keepAppliedPatchVersion(d)
+				return err
 			}
 		}
 	}
@@ -1630,3 +1682,482 @@ func workerPoolContains(workerPools []v1.WorkerPoolResponse, pool string) bool {
 	}
 	return false
 }
+
+const (
+	workerUpdateCompleted = "completed"
+	workerUpdatePaused    = "paused"
+	workerUpdateFailed    = "failed"
+)
+
+func workerUpdateStrategySchema(withPools bool) *schema.Schema {
+	strategy := map[string]*schema.Schema{
+		"max_unavailable": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Number of worker nodes that are updated at the same time",
+		},
+		"zone_by_zone": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Update the worker nodes of one zone before the worker nodes of the next zone",
+		},
+		"pause_on_failure": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Stop the update when a worker node fails to update, the next apply resumes the update",
+		},
+	}
+	if withPools {
+		strategy["pools"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Names or IDs of the worker pools to update, in order. All worker pools are updated by default",
+		}
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Strategy of the rolling update of the worker nodes",
+		Elem:        &schema.Resource{Schema: strategy},
+	}
+}
+
+func workerUpdateStatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Progress of the last rolling update of the worker nodes",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"target_version": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Kubernetes version the worker nodes are updated to",
+				},
+				"state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "State of the update: completed, paused or failed",
+				},
+				"pending_workers": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Worker nodes that are not updated yet",
+				},
+				"updated_workers": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Worker nodes that are updated",
+				},
+				"skipped_workers": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Worker nodes that were skipped because they were not in normal state",
+				},
+				"failed_workers": {
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Worker nodes that failed to update",
+				},
+			},
+		},
+	}
+}
+
+type workerUpdateStrategy struct {
+	maxUnavailable int
+	pools          []string
+	zoneByZone     bool
+	pauseOnFailure bool
+}
+
+func expandWorkerUpdateStrategy(d *schema.ResourceData) workerUpdateStrategy {
+	strategy := workerUpdateStrategy{maxUnavailable: 1, pauseOnFailure: true}
+	if v, ok := d.GetOk("update_strategy"); ok && len(v.([]interface{})) > 0 && v.([]interface{})[0] != nil {
+		s := v.([]interface{})[0].(map[string]interface{})
+		strategy.maxUnavailable = s["max_unavailable"].(int)
+		strategy.zoneByZone = s["zone_by_zone"].(bool)
+		strategy.pauseOnFailure = s["pause_on_failure"].(bool)
+		if pools, ok := s["pools"]; ok {
+			strategy.pools = expandStringList(pools.([]interface{}))
+		}
+	}
+	return strategy
+}
+
+// workerUpdateCandidate is a classic or VPC worker node considered for an update
+type workerUpdateCandidate struct {
+	id          string
+	pool        string
+	poolID      string
+	zone        string
+	normal      bool
+	needsUpdate bool
+}
+
+type workerUpdateStatus struct {
+	targetVersion string
+	state         string
+	pending       []string
+	updated       []string
+	skipped       []string
+	failed        []string
+}
+
+// workerNeedsUpdate reports whether the worker is behind the version of the master or, when a
+// patch version is requested, behind that patch version.
+func workerNeedsUpdate(actualVersion, targetVersion, masterVersion, patchVersion string) bool {
+	if strings.Split(actualVersion, "_")[0] != strings.Split(masterVersion, "_")[0] {
+		return true
+	}
+	actual := strings.Split(actualVersion, ".")
+	target := strings.Split(targetVersion, ".")
+	if patchVersion == "" || len(actual) < 3 || len(target) < 3 {
+		return false
+	}
+	return actual[2] != patchVersion && target[2] == patchVersion
+}
+
+// planWorkerUpdate orders the workers to update by pool and, with zone_by_zone, by zone, and splits
+// them into batches of max_unavailable workers. Workers that are not normal are skipped.
+func planWorkerUpdate(workers []workerUpdateCandidate, strategy workerUpdateStrategy) ([][]string, []string) {
+	pools := strategy.pools
+	if len(pools) == 0 {
+		for _, w := range workers {
+			if !stringInSlice(w.pool, pools) {
+				pools = append(pools, w.pool)
+			}
+		}
+	}
+
+	batches := [][]string{}
+	skipped := []string{}
+	for _, pool := range pools {
+		groups := map[string][]string{}
+		zones := []string{}
+		for _, w := range workers {
+			if (w.pool != pool && w.poolID != pool) || !w.needsUpdate {
+				continue
+			}
+			if !w.normal {
+				log.Printf("[WARN] Skipping the update of worker %s, it is not in normal state", w.id)
+				skipped = append(skipped, w.id)
+				continue
+			}
+			zone := ""
+			if strategy.zoneByZone {
+				zone = w.zone
+			}
+			if _, ok := groups[zone]; !ok {
+				zones = append(zones, zone)
+			}
+			groups[zone] = append(groups[zone], w.id)
+		}
+		sort.Strings(zones)
+		for _, zone := range zones {
+			ids := groups[zone]
+			for len(ids) > 0 {
+				n := strategy.maxUnavailable
+				if n < 1 || n > len(ids) {
+					n = len(ids)
+				}
+				batches = append(batches, ids[:n])
+				ids = ids[n:]
+			}
+		}
+	}
+	return batches, skipped
+}
+
+// rollWorkerUpdate updates the batches one after the other. update returns the IDs of the updated
+// workers, which differ from the requested IDs when workers are replaced, and the requested IDs
+// that failed to update.
+func rollWorkerUpdate(batches [][]string, strategy workerUpdateStrategy, status *workerUpdateStatus, update func(ids []string) ([]string, []string, error)) error {
+	status.pending = []string{}
+	for _, batch := range batches {
+		status.pending = append(status.pending, batch...)
+	}
+	status.state = workerUpdateCompleted
+
+	var errs []string
+	for _, batch := range batches {
+		updated, failed, err := update(batch)
+		status.pending = status.pending[len(batch):]
+		status.updated = append(status.updated, updated...)
+		if err == nil {
+			continue
+		}
+		status.failed = append(status.failed, failed...)
+		errs = append(errs, err.Error())
+		if strategy.pauseOnFailure {
+			status.state = workerUpdatePaused
+			status.pending = append(failed, status.pending...)
+			return fmt.Errorf("The update of the worker nodes is paused after a failure, apply again to resume: %s", err)
+		}
+		status.state = workerUpdateFailed
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("Failed to update the worker nodes %s: %s", strings.Join(status.failed, ", "), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// keepAppliedPatchVersion keeps the patch_version of the last successful worker update in the state
+// when an update fails, so that the next apply plans the update again and resumes it
+func keepAppliedPatchVersion(d *schema.ResourceData) {
+	applied, _ := d.GetChange("patch_version")
+	d.Set("patch_version", applied)
+}
+
+func flattenWorkerUpdateStatus(status workerUpdateStatus) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"target_version":  status.targetVersion,
+		"state":           status.state,
+		"pending_workers": status.pending,
+		"updated_workers": status.updated,
+		"skipped_workers": status.skipped,
+		"failed_workers":  status.failed,
+	}}
+}
+
+// resourceWorkerUpdateCustomizeDiff plans an update when the last rolling update was paused, so
+// that the next apply resumes it, or when workersBehind reports workers to update.
+func resourceWorkerUpdateCustomizeDiff(diff *schema.ResourceDiff, workersBehind func() (bool, error)) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	if status, ok := diff.Get("worker_update_status").([]interface{}); ok && len(status) > 0 && status[0] != nil {
+		if status[0].(map[string]interface{})["state"] == workerUpdatePaused {
+			return diff.SetNewComputed("worker_update_status")
+		}
+	}
+	if workersBehind == nil {
+		return nil
+	}
+	behind, err := workersBehind()
+	if err != nil {
+		log.Printf("[WARN] Unable to check the version of the workers of %s: %s", diff.Id(), err)
+		return nil
+	}
+	if behind {
+		return diff.SetNewComputed("worker_update_status")
+	}
+	return nil
+}
+
+// clusterKubeVersion is a version of the versions API, the SDK does not expose its end of support
+type clusterKubeVersion struct {
+	Major        int    `json:"major"`
+	Minor        int    `json:"minor"`
+	Patch        int    `json:"patch"`
+	Default      bool   `json:"default"`
+	EndOfService string `json:"end_of_service"`
+}
+
+func (v clusterKubeVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+func (v clusterKubeVersion) less(other clusterKubeVersion) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
+
+const (
+	kubeVersionChannelDefault = "default"
+	kubeVersionChannelLatest  = "latest"
+	openshiftVersionSuffix    = "_openshift"
+)
+
+var kubeVersionMinorChannel = regexp.MustCompile(`^(\d+)\.(\d+)\.x$`)
+
+// isKubeVersionChannel reports whether the kube_version is a channel rather than a version: default,
+// latest or major.minor.x, with the _openshift suffix for OpenShift clusters
+func isKubeVersionChannel(version string) bool {
+	channel := strings.TrimSuffix(version, openshiftVersionSuffix)
+	return channel == kubeVersionChannelDefault || channel == kubeVersionChannelLatest || kubeVersionMinorChannel.MatchString(channel)
+}
+
+// parseKubeVersion returns the major and minor version of versions like 1.20, 1.20.7 or 4.6_openshift
+func parseKubeVersion(version string) (int, int, bool) {
+	parts := strings.Split(strings.Split(version, "_")[0], ".")
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+func getClusterKubeVersions(d dataRetriever, meta interface{}) (map[string][]clusterKubeVersion, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string][]clusterKubeVersion{}
+	_, err = restClient.Get("/v1/versions", &versions, targetEnv.ToMap())
+	return versions, err
+}
+
+// platformKubeVersions returns the Kubernetes or the OpenShift versions, depending on the version
+func platformKubeVersions(versions map[string][]clusterKubeVersion, version string) []clusterKubeVersion {
+	if strings.HasSuffix(version, openshiftVersionSuffix) {
+		return versions["openshift"]
+	}
+	return versions["kubernetes"]
+}
+
+// resolveKubeVersionChannel returns the default version, the latest version or the latest patch of the
+// minor version of the channel
+func resolveKubeVersionChannel(versions []clusterKubeVersion, channel string) (clusterKubeVersion, error) {
+	name := strings.TrimSuffix(channel, openshiftVersionSuffix)
+	var resolved *clusterKubeVersion
+	for i, v := range versions {
+		switch {
+		case name == kubeVersionChannelDefault:
+			if v.Default {
+				return v, nil
+			}
+			continue
+		case name != kubeVersionChannelLatest && name != fmt.Sprintf("%d.%d.x", v.Major, v.Minor):
+			continue
+		}
+		if resolved == nil || resolved.less(v) {
+			resolved = &versions[i]
+		}
+	}
+	if resolved == nil {
+		return clusterKubeVersion{}, fmt.Errorf("No supported version matches the kube_version channel %s", channel)
+	}
+	return *resolved, nil
+}
+
+// planKubeVersion returns the version to plan for the channel of a cluster at the current version, or of
+// a new cluster when current is empty. A major.minor.x channel always follows the latest patch. The default
+// and latest channels keep the current patch and never move the master to another minor version unless
+// autoUpgrade is set, and then by one minor version at a time like the API requires.
+func planKubeVersion(versions []clusterKubeVersion, channel, current string, autoUpgrade bool) (string, error) {
+	resolved, err := resolveKubeVersionChannel(versions, channel)
+	if err != nil {
+		return "", err
+	}
+	suffix := ""
+	if strings.HasSuffix(channel, openshiftVersionSuffix) {
+		suffix = openshiftVersionSuffix
+	}
+	major, minor, ok := parseKubeVersion(current)
+	if !ok || kubeVersionMinorChannel.MatchString(strings.TrimSuffix(channel, openshiftVersionSuffix)) {
+		return resolved.String() + suffix, nil
+	}
+	if resolved.Major == major && resolved.Minor == minor {
+		return current, nil
+	}
+	if !autoUpgrade || resolved.less(clusterKubeVersion{Major: major, Minor: minor}) {
+		return current, nil
+	}
+	next, err := resolveKubeVersionChannel(versions, fmt.Sprintf("%d.%d.x", major, minor+1))
+	if err != nil {
+		// Moving to the next major version
+		return resolved.String() + suffix, nil
+	}
+	return next.String() + suffix, nil
+}
+
+// resourceKubeVersionCustomizeDiff resolves a kube_version channel with the versions API, so that the plan
+// shows the version that the cluster is created with or updated to
+func resourceKubeVersionCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	channel := diff.Get("kube_version").(string)
+	if !isKubeVersionChannel(channel) {
+		return nil
+	}
+	versions, err := getClusterKubeVersions(diff, meta)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the supported versions to resolve kube_version %s: %s", channel, err)
+	}
+	current := ""
+	if diff.Id() != "" {
+		old, _ := diff.GetChange("kube_version")
+		current = old.(string)
+	}
+	version, err := planKubeVersion(platformKubeVersions(versions, channel), channel, current, diff.Get("auto_upgrade_minor_version").(bool))
+	if err != nil {
+		return err
+	}
+	return diff.SetNew("kube_version", version)
+}
+
+// kubeVersionEndOfSupport returns the end of support of the minor version, which is zero until it is
+// announced. A version that the API no longer lists is not supported.
+func kubeVersionEndOfSupport(versions []clusterKubeVersion, version string) (time.Time, bool) {
+	major, minor, ok := parseKubeVersion(version)
+	if !ok {
+		return time.Time{}, true
+	}
+	for _, v := range versions {
+		if v.Major != major || v.Minor != minor {
+			continue
+		}
+		for _, layout := range []string{"2006-01-02", time.RFC3339} {
+			if eos, err := time.Parse(layout, v.EndOfService); err == nil {
+				return eos, true
+			}
+		}
+		return time.Time{}, true
+	}
+	return time.Time{}, false
+}
+
+// kubeVersionSupportDiagnostics warns when the master version of the cluster reaches its end of support
+// within end_of_support_warning_days
+func kubeVersionSupportDiagnostics(d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	version := d.Get("kube_version").(string)
+	days := d.Get("end_of_support_warning_days").(int)
+	if d.Id() == "" || version == "" || days <= 0 {
+		return nil
+	}
+	versions, err := getClusterKubeVersions(d, meta)
+	if err != nil {
+		log.Printf("[WARN] Unable to check the end of support of version %s of cluster %s: %s", version, d.Id(), err)
+		return nil
+	}
+	eos, supported := kubeVersionEndOfSupport(platformKubeVersions(versions, version), version)
+	summary := ""
+	switch {
+	case !supported:
+		summary = fmt.Sprintf("Version %s of cluster %s is no longer supported", version, d.Id())
+	case !eos.IsZero() && eos.Before(time.Now().AddDate(0, 0, days)):
+		summary = fmt.Sprintf("Version %s of cluster %s reaches its end of support on %s", version, d.Id(), eos.Format("2006-01-02"))
+	default:
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  summary,
+		Detail:   "Update kube_version to a supported version, or set auto_upgrade_minor_version with the default or latest kube_version channel.",
+	}}
+}
@@ -4,14 +4,18 @@
 package ibm
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
 
 	"github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
 )
@@ -355,3 +359,140 @@ resource "ibm_container_cluster" "testacc_cluster" {
   subnet_id       = ["%s"]
 }	`, clusterName, datacenter, machineType, publicVlanID, privateVlanID, privateSubnetID)
 }
+
+func TestWorkerNeedsUpdate(t *testing.T) {
+	// Behind the version of the master
+	assert.Assert(t, workerNeedsUpdate("1.19.9_1535", "1.20.5_1533", "1.20.5_1533", ""))
+	assert.Assert(t, workerNeedsUpdate("1.20.4_1530", "1.20.5_1533", "1.20.5_1533", ""))
+	assert.Assert(t, !workerNeedsUpdate("1.20.5_1530", "1.20.5_1533", "1.20.5_1533", ""))
+	// Behind the requested patch version
+	assert.Assert(t, workerNeedsUpdate("1.20.5_1530", "1.20.5_1533", "1.20.5_1533", "5_1533"))
+	assert.Assert(t, !workerNeedsUpdate("1.20.5_1533", "1.20.5_1533", "1.20.5_1533", "5_1533"))
+	assert.Assert(t, !workerNeedsUpdate("1.20.5_1530", "1.20.5_1530", "1.20.5_1533", "5_1533"))
+}
+
+func TestPlanWorkerUpdate(t *testing.T) {
+	workers := []workerUpdateCandidate{
+		{id: "w1", pool: "default", poolID: "p1", zone: "us-south-1", normal: true, needsUpdate: true},
+		{id: "w2", pool: "default", poolID: "p1", zone: "us-south-2", normal: true, needsUpdate: true},
+		{id: "w3", pool: "default", poolID: "p1", zone: "us-south-1", normal: true, needsUpdate: true},
+		{id: "w4", pool: "edge", poolID: "p2", zone: "us-south-1", normal: true, needsUpdate: true},
+		{id: "w5", pool: "edge", poolID: "p2", zone: "us-south-2", normal: false, needsUpdate: true},
+		{id: "w6", pool: "edge", poolID: "p2", zone: "us-south-2", normal: true, needsUpdate: false},
+	}
+
+	batches, skipped := planWorkerUpdate(workers, workerUpdateStrategy{maxUnavailable: 1})
+	assert.DeepEqual(t, [][]string{{"w1"}, {"w2"}, {"w3"}, {"w4"}}, batches)
+	assert.DeepEqual(t, []string{"w5"}, skipped)
+
+	batches, _ = planWorkerUpdate(workers, workerUpdateStrategy{maxUnavailable: 2})
+	assert.DeepEqual(t, [][]string{{"w1", "w2"}, {"w3"}, {"w4"}}, batches)
+
+	batches, _ = planWorkerUpdate(workers, workerUpdateStrategy{maxUnavailable: 2, zoneByZone: true})
+	assert.DeepEqual(t, [][]string{{"w1", "w3"}, {"w2"}, {"w4"}}, batches)
+
+	// Pools are updated in the given order, by name or ID
+	batches, _ = planWorkerUpdate(workers, workerUpdateStrategy{maxUnavailable: 3, pools: []string{"p2", "default"}})
+	assert.DeepEqual(t, [][]string{{"w4"}, {"w1", "w2", "w3"}}, batches)
+}
+
+func TestRollWorkerUpdate(t *testing.T) {
+	batches := [][]string{{"w1", "w2"}, {"w3"}, {"w4"}}
+	replace := func(ids []string) ([]string, []string, error) {
+		if ids[0] == "w3" {
+			return nil, ids, errors.New("worker w3 did not come back")
+		}
+		updated := []string{}
+		for _, id := range ids {
+			updated = append(updated, id+"-new")
+		}
+		return updated, nil, nil
+	}
+
+	status := workerUpdateStatus{}
+	err := rollWorkerUpdate(batches, workerUpdateStrategy{pauseOnFailure: true}, &status, replace)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, workerUpdatePaused, status.state)
+	assert.DeepEqual(t, []string{"w1-new", "w2-new"}, status.updated)
+	assert.DeepEqual(t, []string{"w3"}, status.failed)
+	assert.DeepEqual(t, []string{"w3", "w4"}, status.pending)
+
+	status = workerUpdateStatus{}
+	err = rollWorkerUpdate(batches, workerUpdateStrategy{pauseOnFailure: false}, &status, replace)
+	assert.Assert(t, err != nil)
+	assert.Equal(t, workerUpdateFailed, status.state)
+	assert.DeepEqual(t, []string{"w1-new", "w2-new", "w4-new"}, status.updated)
+	assert.DeepEqual(t, []string{"w3"}, status.failed)
+	assert.Assert(t, is.Len(status.pending, 0))
+
+	status = workerUpdateStatus{}
+	err = rollWorkerUpdate(batches[:1], workerUpdateStrategy{pauseOnFailure: true}, &status, replace)
+	assert.NilError(t, err)
+	assert.Equal(t, workerUpdateCompleted, status.state)
+}
+
+func TestPlanKubeVersion(t *testing.T) {
+	versions := []clusterKubeVersion{
+		{Major: 1, Minor: 19, Patch: 11},
+		{Major: 1, Minor: 20, Patch: 7, Default: true},
+		{Major: 1, Minor: 20, Patch: 8},
+		{Major: 1, Minor: 21, Patch: 1},
+		{Major: 1, Minor: 22, Patch: 0},
+	}
+	assert.Assert(t, isKubeVersionChannel("latest"))
+	assert.Assert(t, isKubeVersionChannel("4.6.x_openshift"))
+	assert.Assert(t, !isKubeVersionChannel("1.20"))
+	assert.Assert(t, !isKubeVersionChannel("1.20.7"))
+
+	// New clusters
+	version, err := planKubeVersion(versions, "default", "", false)
+	assert.NilError(t, err)
+	assert.Equal(t, "1.20.7", version)
+	version, _ = planKubeVersion(versions, "latest", "", false)
+	assert.Equal(t, "1.22.0", version)
+	version, _ = planKubeVersion(versions, "1.20.x", "", false)
+	assert.Equal(t, "1.20.8", version)
+	_, err = planKubeVersion(versions, "1.18.x", "", false)
+	assert.Assert(t, err != nil)
+
+	// A minor version channel moves the master to the latest patch
+	version, _ = planKubeVersion(versions, "1.20.x", "1.20.7", false)
+	assert.Equal(t, "1.20.8", version)
+	version, _ = planKubeVersion(versions, "1.20.x", "1.20.8", false)
+	assert.Equal(t, "1.20.8", version)
+	// A new patch never updates the master with the floating channels
+	version, _ = planKubeVersion(versions, "default", "1.20.7", false)
+	assert.Equal(t, "1.20.7", version)
+	// A minor version channel moves the master
+	version, _ = planKubeVersion(versions, "1.21.x", "1.20.7", false)
+	assert.Equal(t, "1.21.1", version)
+	// Floating channels move the master by one minor version with auto upgrade only
+	version, _ = planKubeVersion(versions, "latest", "1.20.7", false)
+	assert.Equal(t, "1.20.7", version)
+	version, _ = planKubeVersion(versions, "latest", "1.20.7", true)
+	assert.Equal(t, "1.21.1", version)
+	version, _ = planKubeVersion(versions, "default", "1.21.1", true)
+	assert.Equal(t, "1.21.1", version)
+
+	version, _ = planKubeVersion([]clusterKubeVersion{{Major: 4, Minor: 6, Patch: 23}}, "4.6.x_openshift", "", false)
+	assert.Equal(t, "4.6.23_openshift", version)
+}
+
+func TestKubeVersionEndOfSupport(t *testing.T) {
+	versions := []clusterKubeVersion{
+		{Major: 1, Minor: 19, Patch: 11, EndOfService: "2021-09-30"},
+		{Major: 1, Minor: 20, Patch: 7, EndOfService: "2022-02-28T00:00:00Z"},
+		{Major: 1, Minor: 21, Patch: 1},
+	}
+	eos, supported := kubeVersionEndOfSupport(versions, "1.19.11")
+	assert.Assert(t, supported)
+	assert.Equal(t, time.Date(2021, 9, 30, 0, 0, 0, 0, time.UTC), eos)
+	eos, supported = kubeVersionEndOfSupport(versions, "1.20")
+	assert.Assert(t, supported)
+	assert.Equal(t, time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC), eos)
+	eos, supported = kubeVersionEndOfSupport(versions, "1.21.1")
+	assert.Assert(t, supported)
+	assert.Assert(t, eos.IsZero())
+	_, supported = kubeVersionEndOfSupport(versions, "1.18.20")
+	assert.Assert(t, !supported)
+}
@@ -0,0 +1,453 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+const (
+	dedicatedHostProvisioned = "provisioned"
+	dedicatedHostDeleted     = "deleted"
+)
+
+// dedicatedHost is a dedicated host of a dedicated host pool
+type dedicatedHost struct {
+	ID               string                 `json:"id"`
+	Flavor           string                 `json:"flavor"`
+	Zone             string                 `json:"zone"`
+	PlacementEnabled bool                   `json:"placementEnabled"`
+	Lifecycle        dedicatedHostLifecycle `json:"lifecycle"`
+	Resources        dedicatedHostResources `json:"resources"`
+	Workers          []dedicatedHostWorker  `json:"workers"`
+}
+
+type dedicatedHostLifecycle struct {
+	ActualState  string `json:"actualState"`
+	DesiredState string `json:"desiredState"`
+	Message      string `json:"message"`
+}
+
+type dedicatedHostResources struct {
+	Capacity dedicatedHostCapacity `json:"capacity"`
+	Consumed dedicatedHostCapacity `json:"consumed"`
+}
+
+type dedicatedHostWorker struct {
+	ClusterID    string `json:"clusterID"`
+	Flavor       string `json:"flavor"`
+	WorkerID     string `json:"workerID"`
+	WorkerPoolID string `json:"workerPoolID"`
+}
+
+type dedicatedHostCreateRequest struct {
+	HostPoolID string `json:"hostPoolID"`
+	Flavor     string `json:"flavor"`
+	Zone       string `json:"zone"`
+}
+
+type dedicatedHostCreateResponse struct {
+	ID string `json:"id"`
+}
+
+type dedicatedHostRemoveRequest struct {
+	Host     string `json:"host"`
+	HostPool string `json:"hostPool"`
+}
+
+type dedicatedHostPlacementRequest struct {
+	HostPoolID string `json:"hostPoolID"`
+	HostID     string `json:"hostID"`
+}
+
+func resourceIBMContainerDedicatedHost() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerDedicatedHostCreate,
+		Read:     resourceIBMContainerDedicatedHostRead,
+		Update:   resourceIBMContainerDedicatedHostUpdate,
+		Delete:   resourceIBMContainerDedicatedHostDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(40 * time.Minute),
+			Delete: schema.DefaultTimeout(40 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"host_pool_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the dedicated host pool",
+			},
+			"flavor": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The flavor of the dedicated host, it must belong to the flavor class of the pool",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The zone of the dedicated host, it must be in the metro of the pool",
+			},
+			"placement_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Allow new workers to be placed on the dedicated host",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of the resource group.",
+			},
+			"host_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the dedicated host",
+			},
+			"life_cycle": dedicatedHostLifecycleSchema(),
+			"resources":  dedicatedHostResourcesSchema(),
+			"workers":    dedicatedHostWorkersSchema(),
+		},
+	}
+}
+
+func dedicatedHostLifecycleSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The lifecycle of the dedicated host",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"actual_state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The actual state of the dedicated host",
+				},
+				"desired_state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The desired state of the dedicated host",
+				},
+				"message": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The message of the last state change",
+				},
+			},
+		},
+	}
+}
+
+func dedicatedHostResourcesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The resources of the dedicated host",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"capacity": dedicatedHostCapacitySchema("The capacity of the dedicated host"),
+				"consumed": dedicatedHostCapacitySchema("The resources of the dedicated host that are used by workers"),
+			},
+		},
+	}
+}
+
+func dedicatedHostWorkersSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The workers that are placed on the dedicated host",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cluster_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the cluster",
+				},
+				"flavor": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The flavor of the worker",
+				},
+				"worker_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the worker",
+				},
+				"worker_pool_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the worker pool",
+				},
+			},
+		},
+	}
+}
+
+func resourceIBMContainerDedicatedHostCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	hostPoolID := d.Get("host_pool_id").(string)
+	params := dedicatedHostCreateRequest{
+		HostPoolID: hostPoolID,
+		Flavor:     d.Get("flavor").(string),
+		Zone:       d.Get("zone").(string),
+	}
+	res := dedicatedHostCreateResponse{}
+	if _, err := restClient.Post("/v2/createDedicatedHost", params, &res, targetEnv.ToMap()); err != nil {
+		return fmt.Errorf("Error creating dedicated host in dedicated host pool (%s): %s", hostPoolID, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", hostPoolID, res.ID))
+
+	_, err = WaitForDedicatedHostAvailable(d, meta, hostPoolID, res.ID, d.Timeout(schema.TimeoutCreate), targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for dedicated host (%s) to become ready: %s", d.Id(), err)
+	}
+
+	return resourceIBMContainerDedicatedHostUpdate(d, meta)
+}
+
+func resourceIBMContainerDedicatedHostRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	hostPoolID := parts[0]
+	hostID := parts[1]
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	host, err := getDedicatedHost(restClient, hostPoolID, hostID, targetEnv.ToMap())
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving dedicated host (%s) of dedicated host pool (%s): %s", hostID, hostPoolID, err)
+	}
+	if host.Lifecycle.ActualState == dedicatedHostDeleted {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("host_pool_id", hostPoolID)
+	d.Set("host_id", host.ID)
+	d.Set("flavor", host.Flavor)
+	d.Set("zone", host.Zone)
+	d.Set("placement_enabled", host.PlacementEnabled)
+	d.Set("life_cycle", flattenDedicatedHostLifecycle(host.Lifecycle))
+	d.Set("resources", flattenDedicatedHostResources(host.Resources))
+	d.Set("workers", flattenDedicatedHostWorkers(host.Workers))
+	return nil
+}
+
+func resourceIBMContainerDedicatedHostUpdate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	hostPoolID := parts[0]
+	hostID := parts[1]
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// A new host has placement enabled, so only a disabled placement is set on create
+	if d.HasChange("placement_enabled") || (d.IsNewResource() && !d.Get("placement_enabled").(bool)) {
+		path := "/v2/disableDedicatedHostPlacement"
+		if d.Get("placement_enabled").(bool) {
+			path = "/v2/enableDedicatedHostPlacement"
+		}
+		params := dedicatedHostPlacementRequest{
+			HostPoolID: hostPoolID,
+			HostID:     hostID,
+		}
+		if _, err := restClient.Post(path, params, nil, targetEnv.ToMap()); err != nil {
+			return fmt.Errorf("Error updating the placement of dedicated host (%s) of dedicated host pool (%s): %s", hostID, hostPoolID, err)
+		}
+	}
+
+	return resourceIBMContainerDedicatedHostRead(d, meta)
+}
+
+func resourceIBMContainerDedicatedHostDelete(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	hostPoolID := parts[0]
+	hostID := parts[1]
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	params := dedicatedHostRemoveRequest{
+		Host:     hostID,
+		HostPool: hostPoolID,
+	}
+	if _, err := restClient.Post("/v2/removeDedicatedHost", params, nil, targetEnv.ToMap()); err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error removing dedicated host (%s) from dedicated host pool (%s): %s", hostID, hostPoolID, err)
+	}
+
+	_, err = WaitForDedicatedHostDelete(d, meta, hostPoolID, hostID, d.Timeout(schema.TimeoutDelete), targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for dedicated host (%s) to be removed: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getDedicatedHost(restClient containerRESTClient, hostPoolID, hostID string, target map[string]string) (dedicatedHost, error) {
+	host := dedicatedHost{}
+	_, err := restClient.Get(fmt.Sprintf("/v2/getDedicatedHost?hostpool=%s&host=%s", url.QueryEscape(hostPoolID), url.QueryEscape(hostID)), &host, target)
+	return host, err
+}
+
+func WaitForDedicatedHostAvailable(d *schema.ResourceData, meta interface{}, hostPoolID, hostID string, timeout time.Duration, target map[string]string) (interface{}, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Waiting for dedicated host (%s) to be available.", hostID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"provision_pending"},
+		Target:     []string{dedicatedHostProvisioned},
+		Refresh:    dedicatedHostStateRefreshFunc(restClient, hostPoolID, hostID, target),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func dedicatedHostStateRefreshFunc(client containerRESTClient, hostPoolID, hostID string, target map[string]string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		host, err := getDedicatedHost(client, hostPoolID, hostID, target)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error retrieving dedicated host: %s", err)
+		}
+		state := host.Lifecycle.ActualState
+		if strings.Contains(state, "failed") {
+			return host, state, fmt.Errorf("The dedicated host %s failed: %s", hostID, host.Lifecycle.Message)
+		}
+		if state != dedicatedHostProvisioned {
+			log.Printf("dedicated host: %s state: %s", hostID, state)
+			return host, "provision_pending", nil
+		}
+		return host, dedicatedHostProvisioned, nil
+	}
+}
+
+func WaitForDedicatedHostDelete(d *schema.ResourceData, meta interface{}, hostPoolID, hostID string, timeout time.Duration, target map[string]string) (interface{}, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting"},
+		Target:     []string{dedicatedHostDeleted},
+		Refresh:    dedicatedHostDeleteStateRefreshFunc(restClient, hostPoolID, hostID, target),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func dedicatedHostDeleteStateRefreshFunc(client containerRESTClient, hostPoolID, hostID string, target map[string]string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		host, err := getDedicatedHost(client, hostPoolID, hostID, target)
+		if err != nil {
+			if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+				return host, dedicatedHostDeleted, nil
+			}
+			return nil, "", fmt.Errorf("Error retrieving dedicated host: %s", err)
+		}
+		if host.Lifecycle.ActualState != dedicatedHostDeleted {
+			log.Printf("Deleting dedicated host %s", hostID)
+			return host, "deleting", nil
+		}
+		return host, dedicatedHostDeleted, nil
+	}
+}
+
+func flattenDedicatedHostLifecycle(lifecycle dedicatedHostLifecycle) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"actual_state":  lifecycle.ActualState,
+			"desired_state": lifecycle.DesiredState,
+			"message":       lifecycle.Message,
+		},
+	}
+}
+
+func flattenDedicatedHostResources(resources dedicatedHostResources) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"capacity": flattenDedicatedHostCapacity(resources.Capacity),
+			"consumed": flattenDedicatedHostCapacity(resources.Consumed),
+		},
+	}
+}
+
+func flattenDedicatedHostWorkers(workers []dedicatedHostWorker) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(workers))
+	for _, worker := range workers {
+		result = append(result, map[string]interface{}{
+			"cluster_id":     worker.ClusterID,
+			"flavor":         worker.Flavor,
+			"worker_id":      worker.WorkerID,
+			"worker_pool_id": worker.WorkerPoolID,
+		})
+	}
+	return result
+}
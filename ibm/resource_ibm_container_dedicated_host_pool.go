@@ -0,0 +1,387 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+const (
+	dedicatedHostPoolCreated = "created"
+	dedicatedHostPoolDeleted = "deleted"
+)
+
+// dedicatedHostPool is a pool of dedicated hosts that single tenant worker pools are placed on
+type dedicatedHostPool struct {
+	ID          string                        `json:"id"`
+	Name        string                        `json:"name"`
+	FlavorClass string                        `json:"flavorClass"`
+	Metro       string                        `json:"metro"`
+	State       string                        `json:"state"`
+	HostCount   int                           `json:"hostCount"`
+	Zones       []dedicatedHostPoolZone       `json:"zones"`
+	WorkerPools []dedicatedHostPoolWorkerPool `json:"workerPools"`
+}
+
+type dedicatedHostPoolZone struct {
+	Zone      string                `json:"zone"`
+	HostCount int                   `json:"hostCount"`
+	Capacity  dedicatedHostCapacity `json:"capacity"`
+}
+
+type dedicatedHostPoolWorkerPool struct {
+	ClusterID    string `json:"clusterID"`
+	WorkerPoolID string `json:"workerPoolID"`
+}
+
+type dedicatedHostCapacity struct {
+	MemoryBytes int64 `json:"memoryBytes"`
+	VCPU        int   `json:"vcpu"`
+}
+
+type dedicatedHostPoolCreateRequest struct {
+	Name        string `json:"name"`
+	FlavorClass string `json:"flavorClass"`
+	Metro       string `json:"metro"`
+}
+
+type dedicatedHostPoolCreateResponse struct {
+	ID string `json:"id"`
+}
+
+type dedicatedHostPoolRemoveRequest struct {
+	HostPool string `json:"hostPool"`
+}
+
+func resourceIBMContainerDedicatedHostPool() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerDedicatedHostPoolCreate,
+		Read:     resourceIBMContainerDedicatedHostPoolRead,
+		Delete:   resourceIBMContainerDedicatedHostPoolDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the dedicated host pool",
+			},
+			"flavor_class": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The flavor class of the dedicated hosts in the pool, for example bx2d",
+			},
+			"metro": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The metro to create the dedicated host pool in",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of the resource group.",
+			},
+			"host_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of dedicated hosts in the pool",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the dedicated host pool",
+			},
+			"zones":        dedicatedHostPoolZonesSchema(),
+			"worker_pools": dedicatedHostPoolWorkerPoolsSchema(),
+		},
+	}
+}
+
+func dedicatedHostPoolZonesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The zones of the dedicated host pool",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"zone": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the zone",
+				},
+				"host_count": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of dedicated hosts in the zone",
+				},
+				"capacity": dedicatedHostCapacitySchema("The capacity of the dedicated hosts in the zone"),
+			},
+		},
+	}
+}
+
+func dedicatedHostPoolWorkerPoolsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The worker pools that are placed on the dedicated host pool",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"cluster_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the cluster",
+				},
+				"worker_pool_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the worker pool",
+				},
+			},
+		},
+	}
+}
+
+func dedicatedHostCapacitySchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"memory_bytes": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The memory in bytes",
+				},
+				"vcpu": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of virtual CPUs",
+				},
+			},
+		},
+	}
+}
+
+func resourceIBMContainerDedicatedHostPoolCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	params := dedicatedHostPoolCreateRequest{
+		Name:        d.Get("name").(string),
+		FlavorClass: d.Get("flavor_class").(string),
+		Metro:       d.Get("metro").(string),
+	}
+	res := dedicatedHostPoolCreateResponse{}
+	if _, err := restClient.Post("/v2/createDedicatedHostPool", params, &res, targetEnv.ToMap()); err != nil {
+		return fmt.Errorf("Error creating dedicated host pool (%s): %s", params.Name, err)
+	}
+
+	d.SetId(res.ID)
+
+	_, err = WaitForDedicatedHostPoolAvailable(d, meta, res.ID, d.Timeout(schema.TimeoutCreate), targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for dedicated host pool (%s) to become ready: %s", d.Id(), err)
+	}
+
+	return resourceIBMContainerDedicatedHostPoolRead(d, meta)
+}
+
+func resourceIBMContainerDedicatedHostPoolRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	hostPool, err := getDedicatedHostPool(restClient, d.Id(), targetEnv.ToMap())
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving dedicated host pool (%s): %s", d.Id(), err)
+	}
+	if hostPool.State == dedicatedHostPoolDeleted {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("name", hostPool.Name)
+	d.Set("flavor_class", hostPool.FlavorClass)
+	d.Set("metro", hostPool.Metro)
+	d.Set("host_count", hostPool.HostCount)
+	d.Set("state", hostPool.State)
+	d.Set("zones", flattenDedicatedHostPoolZones(hostPool.Zones))
+	d.Set("worker_pools", flattenDedicatedHostPoolWorkerPools(hostPool.WorkerPools))
+	return nil
+}
+
+func resourceIBMContainerDedicatedHostPoolDelete(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	params := dedicatedHostPoolRemoveRequest{
+		HostPool: d.Id(),
+	}
+	if _, err := restClient.Post("/v2/removeDedicatedHostPool", params, nil, targetEnv.ToMap()); err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error removing dedicated host pool (%s): %s", d.Id(), err)
+	}
+
+	_, err = WaitForDedicatedHostPoolDelete(d, meta, d.Id(), d.Timeout(schema.TimeoutDelete), targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for dedicated host pool (%s) to be removed: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getDedicatedHostPool(restClient containerRESTClient, hostPoolID string, target map[string]string) (dedicatedHostPool, error) {
+	hostPool := dedicatedHostPool{}
+	_, err := restClient.Get(fmt.Sprintf("/v2/getDedicatedHostPool?dedicatedhostpool=%s", url.QueryEscape(hostPoolID)), &hostPool, target)
+	return hostPool, err
+}
+
+func WaitForDedicatedHostPoolAvailable(d *schema.ResourceData, meta interface{}, hostPoolID string, timeout time.Duration, target map[string]string) (interface{}, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Waiting for dedicated host pool (%s) to be available.", hostPoolID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{dedicatedHostPoolCreated},
+		Refresh:    dedicatedHostPoolStateRefreshFunc(restClient, hostPoolID, target),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func dedicatedHostPoolStateRefreshFunc(client containerRESTClient, hostPoolID string, target map[string]string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		hostPool, err := getDedicatedHostPool(client, hostPoolID, target)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error retrieving dedicated host pool: %s", err)
+		}
+		if strings.Contains(hostPool.State, "failed") {
+			return hostPool, hostPool.State, fmt.Errorf("The dedicated host pool %s failed: %s", hostPoolID, hostPool.State)
+		}
+		if hostPool.State != dedicatedHostPoolCreated {
+			log.Printf("dedicated host pool: %s state: %s", hostPoolID, hostPool.State)
+			return hostPool, "creating", nil
+		}
+		return hostPool, dedicatedHostPoolCreated, nil
+	}
+}
+
+func WaitForDedicatedHostPoolDelete(d *schema.ResourceData, meta interface{}, hostPoolID string, timeout time.Duration, target map[string]string) (interface{}, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"deleting"},
+		Target:     []string{dedicatedHostPoolDeleted},
+		Refresh:    dedicatedHostPoolDeleteStateRefreshFunc(restClient, hostPoolID, target),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func dedicatedHostPoolDeleteStateRefreshFunc(client containerRESTClient, hostPoolID string, target map[string]string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		hostPool, err := getDedicatedHostPool(client, hostPoolID, target)
+		if err != nil {
+			if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+				return hostPool, dedicatedHostPoolDeleted, nil
+			}
+			return nil, "", fmt.Errorf("Error retrieving dedicated host pool: %s", err)
+		}
+		if hostPool.State != dedicatedHostPoolDeleted {
+			log.Printf("Deleting dedicated host pool %s", hostPoolID)
+			return hostPool, "deleting", nil
+		}
+		return hostPool, dedicatedHostPoolDeleted, nil
+	}
+}
+
+func flattenDedicatedHostPoolZones(zones []dedicatedHostPoolZone) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(zones))
+	for _, zone := range zones {
+		result = append(result, map[string]interface{}{
+			"zone":       zone.Zone,
+			"host_count": zone.HostCount,
+			"capacity":   flattenDedicatedHostCapacity(zone.Capacity),
+		})
+	}
+	return result
+}
+
+func flattenDedicatedHostPoolWorkerPools(workerPools []dedicatedHostPoolWorkerPool) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(workerPools))
+	for _, workerPool := range workerPools {
+		result = append(result, map[string]interface{}{
+			"cluster_id":     workerPool.ClusterID,
+			"worker_pool_id": workerPool.WorkerPoolID,
+		})
+	}
+	return result
+}
+
+func flattenDedicatedHostCapacity(capacity dedicatedHostCapacity) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"memory_bytes": int(capacity.MemoryBytes),
+			"vcpu":         capacity.VCPU,
+		},
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+func TestAccIBMContainerDedicatedHostPool_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf-hostpool-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMContainerDedicatedHostPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerDedicatedHostPoolBasic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_dedicated_host_pool.pool", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_container_dedicated_host_pool.pool", "flavor_class", "bx2d"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_dedicated_host_pool.pool", "state", "created"),
+				),
+			},
+			{
+				ResourceName:      "ibm_container_dedicated_host_pool.pool",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerDedicatedHostPoolDestroy(s *terraform.State) error {
+	restClient, err := getContainerRESTClient(testAccProvider.Meta())
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_container_dedicated_host_pool" {
+			continue
+		}
+
+		hostPool, err := getDedicatedHostPool(restClient, rs.Primary.ID, nil)
+		if err == nil {
+			if hostPool.State != dedicatedHostPoolDeleted {
+				return fmt.Errorf("Dedicated host pool still exists: %s", rs.Primary.ID)
+			}
+		} else if apiErr, ok := err.(bmxerror.RequestFailure); !ok || apiErr.StatusCode() != 404 {
+			return fmt.Errorf("Error waiting for dedicated host pool (%s) to be destroyed: %s", rs.Primary.ID, err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMContainerDedicatedHostPoolBasic(name string) string {
+	return fmt.Sprintf(`
+provider "ibm" {
+  region = "eu-de"
+}
+
+resource "ibm_container_dedicated_host_pool" "pool" {
+  name         = "%s"
+  flavor_class = "bx2d"
+  metro        = "fra"
+}`, name)
+}
@@ -0,0 +1,65 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMContainerDedicatedHost_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf-host-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerDedicatedHostBasic(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_dedicated_host.host", "zone", "eu-de-1"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_dedicated_host.host", "placement_enabled", "true"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_dedicated_host.host", "life_cycle.0.actual_state", "provisioned"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerDedicatedHostBasic(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_dedicated_host.host", "placement_enabled", "false"),
+				),
+			},
+			{
+				ResourceName:      "ibm_container_dedicated_host.host",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerDedicatedHostBasic(name string, placement bool) string {
+	return fmt.Sprintf(`
+provider "ibm" {
+  region = "eu-de"
+}
+
+resource "ibm_container_dedicated_host_pool" "pool" {
+  name         = "%s"
+  flavor_class = "bx2d"
+  metro        = "fra"
+}
+
+resource "ibm_container_dedicated_host" "host" {
+  host_pool_id      = ibm_container_dedicated_host_pool.pool.id
+  flavor            = "bx2d.host.152x608"
+  zone              = "eu-de-1"
+  placement_enabled = %t
+}`, name, placement)
+}
@@ -0,0 +1,262 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+// ingressInstance is a Secrets Manager instance registered with the ingress secret sync of a cluster
+type ingressInstance struct {
+	Cluster         string `json:"cluster"`
+	Name            string `json:"name"`
+	CRN             string `json:"crn"`
+	SecretGroupID   string `json:"secretGroupID"`
+	SecretGroupName string `json:"secretGroupName"`
+	CallbackChannel string `json:"callbackChannel"`
+	UserManaged     bool   `json:"userManaged"`
+	IsDefault       bool   `json:"isDefault"`
+	Type            string `json:"type"`
+	Status          string `json:"status"`
+}
+
+type ingressInstanceRegisterRequest struct {
+	Cluster       string `json:"cluster"`
+	CRN           string `json:"crn"`
+	IsDefault     bool   `json:"isDefault"`
+	SecretGroupID string `json:"secretGroupID,omitempty"`
+}
+
+type ingressInstanceUpdateRequest struct {
+	Cluster       string `json:"cluster"`
+	Name          string `json:"name"`
+	IsDefault     bool   `json:"isDefault"`
+	SecretGroupID string `json:"secretGroupID"`
+}
+
+type ingressInstanceDeleteRequest struct {
+	Cluster string `json:"cluster"`
+	Name    string `json:"name"`
+}
+
+func resourceIBMContainerIngressInstance() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerIngressInstanceCreate,
+		Read:     resourceIBMContainerIngressInstanceRead,
+		Update:   resourceIBMContainerIngressInstanceUpdate,
+		Delete:   resourceIBMContainerIngressInstanceDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cluster ID or name",
+			},
+			"instance_crn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "CRN of the Secrets Manager instance",
+			},
+			"is_default": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Make the instance the default instance of the cluster, the default instance stores the default ingress certificate",
+			},
+			"secret_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the secret group of the instance that is used by the cluster",
+			},
+			"instance_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the instance in the cluster",
+			},
+			"secret_group_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the secret group",
+			},
+			"instance_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the instance",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the instance registration",
+			},
+			"user_managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The instance was registered by a user and not by IBM",
+			},
+		},
+	}
+}
+
+func resourceIBMContainerIngressInstanceCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+
+	cluster := d.Get("cluster").(string)
+	params := ingressInstanceRegisterRequest{
+		Cluster:       cluster,
+		CRN:           d.Get("instance_crn").(string),
+		IsDefault:     d.Get("is_default").(bool),
+		SecretGroupID: d.Get("secret_group_id").(string),
+	}
+	instance := ingressInstance{}
+	if _, err := restClient.Post("/ingress/v2/secret/registerInstance", params, &instance); err != nil {
+		return fmt.Errorf("Error registering instance (%s) with cluster (%s): %s", params.CRN, cluster, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", cluster, instance.Name))
+	_, err = waitForContainerIngressInstance(d, restClient, cluster, instance.Name, schema.TimeoutCreate)
+	if err != nil {
+		return fmt.Errorf("Error waiting for the registration of instance (%s) : %s", d.Id(), err)
+	}
+
+	return resourceIBMContainerIngressInstanceRead(d, meta)
+}
+
+func resourceIBMContainerIngressInstanceRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	instanceName := parts[1]
+
+	instance, err := getIngressInstance(restClient, cluster, instanceName)
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving instance (%s) of cluster (%s): %s", instanceName, cluster, err)
+	}
+
+	d.Set("cluster", cluster)
+	d.Set("instance_crn", instance.CRN)
+	d.Set("is_default", instance.IsDefault)
+	d.Set("secret_group_id", instance.SecretGroupID)
+	d.Set("instance_name", instance.Name)
+	d.Set("secret_group_name", instance.SecretGroupName)
+	d.Set("instance_type", instance.Type)
+	d.Set("status", instance.Status)
+	d.Set("user_managed", instance.UserManaged)
+	return nil
+}
+
+func resourceIBMContainerIngressInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	instanceName := parts[1]
+
+	if d.HasChange("is_default") || d.HasChange("secret_group_id") {
+		params := ingressInstanceUpdateRequest{
+			Cluster:       cluster,
+			Name:          instanceName,
+			IsDefault:     d.Get("is_default").(bool),
+			SecretGroupID: d.Get("secret_group_id").(string),
+		}
+		if _, err := restClient.Post("/ingress/v2/secret/updateInstance", params, nil); err != nil {
+			return fmt.Errorf("Error updating instance (%s) of cluster (%s): %s", instanceName, cluster, err)
+		}
+		_, err = waitForContainerIngressInstance(d, restClient, cluster, instanceName, schema.TimeoutUpdate)
+		if err != nil {
+			return fmt.Errorf("Error waiting for the update of instance (%s) : %s", d.Id(), err)
+		}
+	}
+	return resourceIBMContainerIngressInstanceRead(d, meta)
+}
+
+func resourceIBMContainerIngressInstanceDelete(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	params := ingressInstanceDeleteRequest{
+		Cluster: parts[0],
+		Name:    parts[1],
+	}
+	if _, err := restClient.Post("/ingress/v2/secret/unregisterInstance", params, nil); err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error unregistering instance (%s) from cluster (%s): %s", parts[1], parts[0], err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func getIngressInstance(restClient containerRESTClient, cluster, instanceName string) (ingressInstance, error) {
+	instance := ingressInstance{}
+	_, err := restClient.Get(fmt.Sprintf("/ingress/v2/secret/getInstance?cluster=%s&name=%s", url.QueryEscape(cluster), url.QueryEscape(instanceName)), &instance)
+	return instance, err
+}
+
+func waitForContainerIngressInstance(d *schema.ResourceData, restClient containerRESTClient, cluster, instanceName, timeout string) (interface{}, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"registering"},
+		Target:  []string{"done"},
+		Refresh: func() (interface{}, string, error) {
+			instance, err := getIngressInstance(restClient, cluster, instanceName)
+			if err != nil {
+				if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+					return instance, "registering", nil
+				}
+				return nil, "", err
+			}
+			if strings.Contains(instance.Status, "failed") {
+				return instance, "failed", fmt.Errorf("The registration of instance %s failed: %s", instanceName, instance.Status)
+			}
+			if instance.Status != "created" && instance.Status != "updated" {
+				return instance, "registering", nil
+			}
+			return instance, "done", nil
+		},
+		Timeout:    d.Timeout(timeout),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
@@ -0,0 +1,90 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMContainerIngressInstance_Basic(t *testing.T) {
+	clusterName := fmt.Sprintf("tf-container-ingress-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMContainerIngressInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerIngressInstanceBasic(clusterName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_instance.instance", "instance_crn", secretsManagerInstanceCRN),
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_instance.instance", "is_default", "false"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_ingress_instance.instance", "instance_name"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerIngressInstanceBasic(clusterName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_instance.instance", "is_default", "true"),
+				),
+			},
+			{
+				ResourceName:      "ibm_container_ingress_instance.instance",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerIngressInstanceDestroy(s *terraform.State) error {
+	restClient, err := getContainerRESTClient(testAccProvider.Meta())
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_container_ingress_instance" {
+			continue
+		}
+
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = getIngressInstance(restClient, parts[0], parts[1])
+		if err == nil {
+			return fmt.Errorf("Ingress instance still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMContainerIngressInstanceBasic(clusterName string, isDefault bool) string {
+	return fmt.Sprintf(`
+resource "ibm_container_cluster" "testacc_cluster" {
+  name              = "%s"
+  datacenter        = "%s"
+  default_pool_size = 1
+  machine_type      = "%s"
+  hardware          = "shared"
+  public_vlan_id    = "%s"
+  private_vlan_id   = "%s"
+  wait_till         = "MasterNodeReady"
+}
+
+resource "ibm_container_ingress_instance" "instance" {
+  cluster      = ibm_container_cluster.testacc_cluster.id
+  instance_crn = "%s"
+  is_default   = %t
+}`, clusterName, datacenter, machineType, publicVlanID, privateVlanID, secretsManagerInstanceCRN, isDefault)
+}
@@ -0,0 +1,357 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+type ingressSecretFieldAdd struct {
+	Name         string `json:"name,omitempty"`
+	CRN          string `json:"crn"`
+	AppendPrefix bool   `json:"appendPrefix"`
+}
+
+type ingressSecretFieldRemove struct {
+	Name string `json:"name"`
+}
+
+type ingressSecretFieldsRequest struct {
+	Cluster   string                     `json:"cluster"`
+	Name      string                     `json:"name"`
+	Namespace string                     `json:"namespace"`
+	Add       []ingressSecretFieldAdd    `json:"add,omitempty"`
+	Remove    []ingressSecretFieldRemove `json:"remove,omitempty"`
+}
+
+func resourceIBMContainerIngressSecretOpaque() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerIngressSecretOpaqueCreate,
+		Read:     resourceIBMContainerIngressSecretOpaqueRead,
+		Update:   resourceIBMContainerIngressSecretOpaqueUpdate,
+		Delete:   resourceIBMContainerIngressSecretOpaqueDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cluster ID or name",
+			},
+			"secret_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Secret name",
+			},
+			"secret_namespace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Secret namespace",
+			},
+			"persistence": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Persist the secret data in the cluster, the secret is recreated when it is deleted from the cluster",
+			},
+			"fields": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Secrets Manager secrets that are added as fields of the secret",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"crn": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "CRN of the secret in Secrets Manager",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Name of the field, defaults to the name of the secret in Secrets Manager",
+						},
+						"prefix": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Prefix the name of the field with the name of the secret in Secrets Manager",
+						},
+						"expires_on": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expiration date of the secret in Secrets Manager",
+						},
+						"last_updated_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the last synchronization of the field",
+						},
+						"auto_rotate": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "The field is updated automatically when the secret is rotated in Secrets Manager",
+						},
+					},
+				},
+			},
+			"update_secret": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Change the value to synchronize the fields with the latest version of the secrets",
+			},
+			"last_updated_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last synchronization of the secret, a rotated secret is synchronized automatically",
+			},
+			"auto_rotate": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The secret is updated automatically when its secrets are rotated in Secrets Manager",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Secret status",
+			},
+			"user_managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The secret was created by a user and not by IBM",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the secret",
+			},
+		},
+	}
+}
+
+func resourceIBMContainerIngressSecretOpaqueCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+
+	cluster := d.Get("cluster").(string)
+	secretName := d.Get("secret_name").(string)
+	namespace := d.Get("secret_namespace").(string)
+	add, _ := diffIngressSecretFields(nil, d.Get("fields").([]interface{}))
+	params := ingressSecretCreateRequest{
+		Cluster:     cluster,
+		Name:        secretName,
+		Namespace:   namespace,
+		Persistence: d.Get("persistence").(bool),
+		Type:        ingressSecretTypeOpaque,
+		FieldsToAdd: add,
+	}
+	if _, err := restClient.Post("/ingress/v2/secret/createSecret", params, nil); err != nil {
+		return fmt.Errorf("Error creating ingress secret (%s) in cluster (%s): %s", secretName, cluster, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", cluster, secretName, namespace))
+	_, err = waitForContainerIngressSecret(d, restClient, cluster, secretName, namespace, schema.TimeoutCreate)
+	if err != nil {
+		return fmt.Errorf("Error waiting for create ingress secret (%s) : %s", d.Id(), err)
+	}
+
+	return resourceIBMContainerIngressSecretOpaqueRead(d, meta)
+}
+
+func resourceIBMContainerIngressSecretOpaqueRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	secretName := parts[1]
+	namespace := parts[2]
+
+	secret, err := getIngressSecret(restClient, cluster, secretName, namespace)
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving ingress secret (%s) of cluster (%s): %s", secretName, cluster, err)
+	}
+	if secret.Status == "deleted" {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cluster", cluster)
+	d.Set("secret_name", secret.Name)
+	d.Set("secret_namespace", secret.Namespace)
+	d.Set("persistence", secret.Persistence)
+	d.Set("fields", flattenIngressSecretFields(secret.Fields, d.Get("fields").([]interface{})))
+	d.Set("last_updated_timestamp", secret.LastUpdatedTimestamp)
+	d.Set("auto_rotate", secret.AutoRotate)
+	d.Set("status", secret.Status)
+	d.Set("user_managed", secret.UserManaged)
+	d.Set("type", secret.Type)
+	return nil
+}
+
+func resourceIBMContainerIngressSecretOpaqueUpdate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	secretName := parts[1]
+	namespace := parts[2]
+
+	if d.HasChange("fields") {
+		o, n := d.GetChange("fields")
+		add, remove := diffIngressSecretFields(o.([]interface{}), n.([]interface{}))
+		// Fields are added first so that a renamed field is never missing from the secret
+		if len(add) > 0 {
+			params := ingressSecretFieldsRequest{
+				Cluster:   cluster,
+				Name:      secretName,
+				Namespace: namespace,
+				Add:       add,
+			}
+			if _, err := restClient.Post("/ingress/v2/secret/addField", params, nil); err != nil {
+				return fmt.Errorf("Error adding fields to ingress secret (%s) of cluster (%s): %s", secretName, cluster, err)
+			}
+		}
+		if len(remove) > 0 {
+			params := ingressSecretFieldsRequest{
+				Cluster:   cluster,
+				Name:      secretName,
+				Namespace: namespace,
+				Remove:    remove,
+			}
+			if _, err := restClient.Post("/ingress/v2/secret/removeField", params, nil); err != nil {
+				return fmt.Errorf("Error removing fields from ingress secret (%s) of cluster (%s): %s", secretName, cluster, err)
+			}
+		}
+	}
+	if d.HasChange("update_secret") {
+		params := ingressSecretUpdateRequest{
+			Cluster:   cluster,
+			Name:      secretName,
+			Namespace: namespace,
+		}
+		if _, err := restClient.Post("/ingress/v2/secret/updateSecret", params, nil); err != nil {
+			return fmt.Errorf("Error updating ingress secret (%s) of cluster (%s): %s", secretName, cluster, err)
+		}
+	}
+	if d.HasChange("fields") || d.HasChange("update_secret") {
+		_, err = waitForContainerIngressSecret(d, restClient, cluster, secretName, namespace, schema.TimeoutUpdate)
+		if err != nil {
+			return fmt.Errorf("Error waiting for update ingress secret (%s) : %s", d.Id(), err)
+		}
+	}
+	return resourceIBMContainerIngressSecretOpaqueRead(d, meta)
+}
+
+func resourceIBMContainerIngressSecretOpaqueDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteContainerIngressSecret(d, meta)
+}
+
+// ingressSecretFieldMatches reports whether a configured field is the field in the state. A field
+// without a configured name matches any name.
+func ingressSecretFieldMatches(state, config map[string]interface{}) bool {
+	if state["crn"] != config["crn"] || state["prefix"] != config["prefix"] {
+		return false
+	}
+	name, _ := config["name"].(string)
+	return name == "" || name == state["name"]
+}
+
+// diffIngressSecretFields returns the fields to add to and remove from the secret
+func diffIngressSecretFields(o, n []interface{}) ([]ingressSecretFieldAdd, []ingressSecretFieldRemove) {
+	add := []ingressSecretFieldAdd{}
+	remove := []ingressSecretFieldRemove{}
+	matched := make([]bool, len(o))
+
+	for _, nf := range n {
+		config := nf.(map[string]interface{})
+		found := false
+		for i, of := range o {
+			if !matched[i] && ingressSecretFieldMatches(of.(map[string]interface{}), config) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			name, _ := config["name"].(string)
+			prefix, _ := config["prefix"].(bool)
+			add = append(add, ingressSecretFieldAdd{
+				Name:         name,
+				CRN:          config["crn"].(string),
+				AppendPrefix: prefix,
+			})
+		}
+	}
+	for i, of := range o {
+		if !matched[i] {
+			remove = append(remove, ingressSecretFieldRemove{Name: of.(map[string]interface{})["name"].(string)})
+		}
+	}
+	return add, remove
+}
+
+// flattenIngressSecretFields keeps the order and the prefix setting of the current fields, the
+// API returns neither
+func flattenIngressSecretFields(fields []ingressSecretField, current []interface{}) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(fields))
+	used := make([]bool, len(fields))
+
+	flatten := func(i int, prefix bool) {
+		used[i] = true
+		result = append(result, map[string]interface{}{
+			"crn":                    fields[i].CRN,
+			"name":                   fields[i].Name,
+			"prefix":                 prefix,
+			"expires_on":             fields[i].ExpiresOn,
+			"last_updated_timestamp": fields[i].LastUpdatedTimestamp,
+			"auto_rotate":            fields[i].AutoRotate,
+		})
+	}
+	for _, c := range current {
+		field := c.(map[string]interface{})
+		name, _ := field["name"].(string)
+		for i := range fields {
+			if !used[i] && fields[i].CRN == field["crn"] && (name == "" || name == fields[i].Name) {
+				flatten(i, field["prefix"].(bool))
+				break
+			}
+		}
+	}
+	for i := range fields {
+		if !used[i] {
+			flatten(i, false)
+		}
+	}
+	return result
+}
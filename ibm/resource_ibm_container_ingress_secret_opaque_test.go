@@ -0,0 +1,117 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMContainerIngressSecretOpaque_Basic(t *testing.T) {
+	clusterName := fmt.Sprintf("tf-container-ingress-%d", acctest.RandIntRange(10, 100))
+	secretName := fmt.Sprintf("tf-ingress-opaque-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMContainerIngressSecretDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerIngressSecretOpaqueBasic(clusterName, secretName, "field1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_opaque.secret", "type", ingressSecretTypeOpaque),
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_opaque.secret", "fields.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_opaque.secret", "fields.0.name", "field1"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_ingress_secret_opaque.secret", "fields.0.auto_rotate"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerIngressSecretOpaqueBasic(clusterName, secretName, "field2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_opaque.secret", "fields.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_opaque.secret", "fields.0.name", "field2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerIngressSecretOpaqueBasic(clusterName, secretName, fieldName string) string {
+	return fmt.Sprintf(`
+resource "ibm_container_cluster" "testacc_cluster" {
+  name              = "%s"
+  datacenter        = "%s"
+  default_pool_size = 1
+  machine_type      = "%s"
+  hardware          = "shared"
+  public_vlan_id    = "%s"
+  private_vlan_id   = "%s"
+  wait_till         = "MasterNodeReady"
+}
+
+resource "ibm_container_ingress_instance" "instance" {
+  cluster      = ibm_container_cluster.testacc_cluster.id
+  instance_crn = "%s"
+  is_default   = true
+}
+
+resource "ibm_container_ingress_secret_opaque" "secret" {
+  cluster          = ibm_container_ingress_instance.instance.cluster
+  secret_name      = "%s"
+  secret_namespace = "default"
+  fields {
+    crn  = "%s"
+    name = "%s"
+  }
+}`, clusterName, datacenter, machineType, publicVlanID, privateVlanID, secretsManagerInstanceCRN, secretName, secretsManagerSecretCRN, fieldName)
+}
+
+func TestIngressSecretFields(t *testing.T) {
+	state := []interface{}{
+		map[string]interface{}{"crn": "crn1", "name": "user", "prefix": false},
+		map[string]interface{}{"crn": "crn2", "name": "secret2_password", "prefix": true},
+		map[string]interface{}{"crn": "crn3", "name": "token", "prefix": false},
+	}
+	config := []interface{}{
+		map[string]interface{}{"crn": "crn2", "name": "", "prefix": true},
+		map[string]interface{}{"crn": "crn1", "name": "username", "prefix": false},
+		map[string]interface{}{"crn": "crn4", "name": "", "prefix": false},
+	}
+
+	add, remove := diffIngressSecretFields(state, config)
+	assert.DeepEqual(t, []ingressSecretFieldAdd{{Name: "username", CRN: "crn1"}, {CRN: "crn4"}}, add)
+	assert.DeepEqual(t, []ingressSecretFieldRemove{{Name: "user"}, {Name: "token"}}, remove)
+
+	add, remove = diffIngressSecretFields(state, state)
+	assert.Assert(t, is.Len(add, 0))
+	assert.Assert(t, is.Len(remove, 0))
+
+	// The order and prefix of the configuration are kept, unknown fields are appended
+	fields := []ingressSecretField{
+		{Name: "api_key", CRN: "crn4"},
+		{Name: "username", CRN: "crn1", ExpiresOn: "2022-01-01", AutoRotate: true},
+		{Name: "secret2_password", CRN: "crn2"},
+	}
+	flattened := flattenIngressSecretFields(fields, config)
+	assert.Assert(t, is.Len(flattened, 3))
+	assert.Equal(t, "secret2_password", flattened[0]["name"])
+	assert.Equal(t, true, flattened[0]["prefix"])
+	assert.Equal(t, "username", flattened[1]["name"])
+	assert.Equal(t, "2022-01-01", flattened[1]["expires_on"])
+	assert.Equal(t, true, flattened[1]["auto_rotate"])
+	assert.Equal(t, false, flattened[2]["auto_rotate"])
+	assert.Equal(t, "api_key", flattened[2]["name"])
+	assert.Equal(t, false, flattened[2]["prefix"])
+}
@@ -0,0 +1,348 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+const (
+	ingressSecretTypeTLS    = "TLS"
+	ingressSecretTypeOpaque = "Opaque"
+)
+
+// ingressSecret is an ingress secret of a cluster that is synchronized from Secrets Manager
+type ingressSecret struct {
+	Cluster              string               `json:"cluster"`
+	Name                 string               `json:"name"`
+	Namespace            string               `json:"namespace"`
+	Domain               string               `json:"domain"`
+	CRN                  string               `json:"crn"`
+	ExpiresOn            string               `json:"expiresOn"`
+	Status               string               `json:"status"`
+	UserManaged          bool                 `json:"userManaged"`
+	Persistence          bool                 `json:"persistence"`
+	Type                 string               `json:"type"`
+	LastUpdatedTimestamp string               `json:"lastUpdatedTimestamp"`
+	AutoRotate           bool                 `json:"autoRotate"`
+	Fields               []ingressSecretField `json:"fields"`
+}
+
+type ingressSecretField struct {
+	Name                 string `json:"name"`
+	CRN                  string `json:"crn"`
+	ExpiresOn            string `json:"expiresOn"`
+	LastUpdatedTimestamp string `json:"lastUpdatedTimestamp"`
+	AutoRotate           bool   `json:"autoRotate"`
+}
+
+type ingressSecretCreateRequest struct {
+	Cluster     string                  `json:"cluster"`
+	Name        string                  `json:"name"`
+	Namespace   string                  `json:"namespace"`
+	CRN         string                  `json:"crn,omitempty"`
+	Persistence bool                    `json:"persistence"`
+	Type        string                  `json:"type"`
+	FieldsToAdd []ingressSecretFieldAdd `json:"add,omitempty"`
+}
+
+type ingressSecretUpdateRequest struct {
+	Cluster   string `json:"cluster"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	CRN       string `json:"crn,omitempty"`
+}
+
+type ingressSecretDeleteRequest struct {
+	Cluster   string `json:"cluster"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func resourceIBMContainerIngressSecretTLS() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerIngressSecretTLSCreate,
+		Read:     resourceIBMContainerIngressSecretTLSRead,
+		Update:   resourceIBMContainerIngressSecretTLSUpdate,
+		Delete:   resourceIBMContainerIngressSecretTLSDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cluster ID or name",
+			},
+			"secret_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Secret name",
+			},
+			"secret_namespace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Secret namespace",
+			},
+			"cert_crn": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "CRN of the certificate in Secrets Manager",
+			},
+			"persistence": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Persist the secret data in the cluster, the secret is recreated when it is deleted from the cluster",
+			},
+			"update_secret": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Change the value to synchronize the secret with the latest version of the certificate",
+			},
+			"domain_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Domain name of the certificate",
+			},
+			"expires_on": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration date of the certificate",
+			},
+			"last_updated_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time of the last synchronization of the secret, a rotated certificate is synchronized automatically",
+			},
+			"auto_rotate": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The secret is updated automatically when the certificate is rotated in Secrets Manager",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Secret status",
+			},
+			"user_managed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "The secret was created by a user and not by IBM",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the secret",
+			},
+		},
+	}
+}
+
+func resourceIBMContainerIngressSecretTLSCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+
+	cluster := d.Get("cluster").(string)
+	secretName := d.Get("secret_name").(string)
+	namespace := d.Get("secret_namespace").(string)
+	params := ingressSecretCreateRequest{
+		Cluster:     cluster,
+		Name:        secretName,
+		Namespace:   namespace,
+		CRN:         d.Get("cert_crn").(string),
+		Persistence: d.Get("persistence").(bool),
+		Type:        ingressSecretTypeTLS,
+	}
+	if _, err := restClient.Post("/ingress/v2/secret/createSecret", params, nil); err != nil {
+		return fmt.Errorf("Error creating ingress secret (%s) in cluster (%s): %s", secretName, cluster, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", cluster, secretName, namespace))
+	_, err = waitForContainerIngressSecret(d, restClient, cluster, secretName, namespace, schema.TimeoutCreate)
+	if err != nil {
+		return fmt.Errorf("Error waiting for create ingress secret (%s) : %s", d.Id(), err)
+	}
+
+	return resourceIBMContainerIngressSecretTLSRead(d, meta)
+}
+
+func resourceIBMContainerIngressSecretTLSRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	secretName := parts[1]
+	namespace := parts[2]
+
+	secret, err := getIngressSecret(restClient, cluster, secretName, namespace)
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving ingress secret (%s) of cluster (%s): %s", secretName, cluster, err)
+	}
+	if secret.Status == "deleted" {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cluster", cluster)
+	d.Set("secret_name", secret.Name)
+	d.Set("secret_namespace", secret.Namespace)
+	d.Set("cert_crn", secret.CRN)
+	d.Set("persistence", secret.Persistence)
+	d.Set("domain_name", secret.Domain)
+	d.Set("expires_on", secret.ExpiresOn)
+	d.Set("last_updated_timestamp", secret.LastUpdatedTimestamp)
+	d.Set("auto_rotate", secret.AutoRotate)
+	d.Set("status", secret.Status)
+	d.Set("user_managed", secret.UserManaged)
+	d.Set("type", secret.Type)
+	return nil
+}
+
+func resourceIBMContainerIngressSecretTLSUpdate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	secretName := parts[1]
+	namespace := parts[2]
+
+	if d.HasChange("cert_crn") || d.HasChange("update_secret") {
+		params := ingressSecretUpdateRequest{
+			Cluster:   cluster,
+			Name:      secretName,
+			Namespace: namespace,
+			CRN:       d.Get("cert_crn").(string),
+		}
+		if _, err := restClient.Post("/ingress/v2/secret/updateSecret", params, nil); err != nil {
+			return fmt.Errorf("Error updating ingress secret (%s) of cluster (%s): %s", secretName, cluster, err)
+		}
+		_, err = waitForContainerIngressSecret(d, restClient, cluster, secretName, namespace, schema.TimeoutUpdate)
+		if err != nil {
+			return fmt.Errorf("Error waiting for update ingress secret (%s) : %s", d.Id(), err)
+		}
+	}
+	return resourceIBMContainerIngressSecretTLSRead(d, meta)
+}
+
+func resourceIBMContainerIngressSecretTLSDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteContainerIngressSecret(d, meta)
+}
+
+func deleteContainerIngressSecret(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	secretName := parts[1]
+	namespace := parts[2]
+
+	params := ingressSecretDeleteRequest{
+		Cluster:   cluster,
+		Name:      secretName,
+		Namespace: namespace,
+	}
+	if _, err := restClient.Post("/ingress/v2/secret/deleteSecret", params, nil); err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting ingress secret (%s) of cluster (%s): %s", secretName, cluster, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"deleting"},
+		Target:  []string{"deleted"},
+		Refresh: func() (interface{}, string, error) {
+			secret, err := getIngressSecret(restClient, cluster, secretName, namespace)
+			if err != nil {
+				if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+					return secret, "deleted", nil
+				}
+				return nil, "", err
+			}
+			if secret.Status != "deleted" {
+				return secret, "deleting", nil
+			}
+			return secret, "deleted", nil
+		},
+		Timeout:    d.Timeout(schema.TimeoutDelete),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for delete ingress secret (%s) : %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func getIngressSecret(restClient containerRESTClient, cluster, secretName, namespace string) (ingressSecret, error) {
+	secret := ingressSecret{}
+	_, err := restClient.Get(fmt.Sprintf("/ingress/v2/secret/getSecret?cluster=%s&name=%s&namespace=%s", url.QueryEscape(cluster), url.QueryEscape(secretName), url.QueryEscape(namespace)), &secret)
+	return secret, err
+}
+
+func waitForContainerIngressSecret(d *schema.ResourceData, restClient containerRESTClient, cluster, secretName, namespace, timeout string) (interface{}, error) {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"creating"},
+		Target:  []string{"done"},
+		Refresh: func() (interface{}, string, error) {
+			secret, err := getIngressSecret(restClient, cluster, secretName, namespace)
+			if err != nil {
+				if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+					return secret, "creating", nil
+				}
+				return nil, "", err
+			}
+			if strings.Contains(secret.Status, "failed") {
+				return secret, "failed", fmt.Errorf("The ingress secret %s failed: %s", secretName, secret.Status)
+			}
+			if secret.Status != "created" && secret.Status != "updated" {
+				return secret, "creating", nil
+			}
+			return secret, "done", nil
+		},
+		Timeout:    d.Timeout(timeout),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
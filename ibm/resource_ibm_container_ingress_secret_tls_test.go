@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIBMContainerIngressSecretTLS_Basic(t *testing.T) {
+	clusterName := fmt.Sprintf("tf-container-ingress-%d", acctest.RandIntRange(10, 100))
+	secretName := fmt.Sprintf("tf-ingress-tls-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMContainerIngressSecretDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerIngressSecretTLSBasic(clusterName, secretName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_tls.secret", "secret_name", secretName),
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_tls.secret", "cert_crn", secretsManagerCertCRN),
+					resource.TestCheckResourceAttr(
+						"ibm_container_ingress_secret_tls.secret", "type", ingressSecretTypeTLS),
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_ingress_secret_tls.secret", "expires_on"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_ingress_secret_tls.secret", "auto_rotate"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerIngressSecretTLSBasic(clusterName, secretName, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_ingress_secret_tls.secret", "last_updated_timestamp"),
+				),
+			},
+			{
+				ResourceName:            "ibm_container_ingress_secret_tls.secret",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"update_secret"},
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerIngressSecretDestroy(s *terraform.State) error {
+	restClient, err := getContainerRESTClient(testAccProvider.Meta())
+	if err != nil {
+		return err
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_container_ingress_secret_tls" && rs.Type != "ibm_container_ingress_secret_opaque" {
+			continue
+		}
+
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		secret, err := getIngressSecret(restClient, parts[0], parts[1], parts[2])
+		if err == nil && secret.Status != "deleted" {
+			return fmt.Errorf("Ingress secret still exists: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMContainerIngressSecretTLSBasic(clusterName, secretName string, updateSecret int) string {
+	return fmt.Sprintf(`
+resource "ibm_container_cluster" "testacc_cluster" {
+  name              = "%s"
+  datacenter        = "%s"
+  default_pool_size = 1
+  machine_type      = "%s"
+  hardware          = "shared"
+  public_vlan_id    = "%s"
+  private_vlan_id   = "%s"
+  wait_till         = "MasterNodeReady"
+}
+
+resource "ibm_container_ingress_instance" "instance" {
+  cluster      = ibm_container_cluster.testacc_cluster.id
+  instance_crn = "%s"
+  is_default   = true
+}
+
+resource "ibm_container_ingress_secret_tls" "secret" {
+  cluster          = ibm_container_ingress_instance.instance.cluster
+  secret_name      = "%s"
+  secret_namespace = "default"
+  cert_crn         = "%s"
+  update_secret    = %d
+}`, clusterName, datacenter, machineType, publicVlanID, privateVlanID, secretsManagerInstanceCRN, secretName, secretsManagerCertCRN, updateSecret)
+}
@@ -0,0 +1,560 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// nlbDNSConfig is a subdomain of the cluster that is registered for network load balancer IPs of
+// a classic cluster or the load balancer hostname of a VPC cluster
+type nlbDNSConfig struct {
+	Cluster         string   `json:"cluster"`
+	DNSType         string   `json:"dnsType"`
+	LBHostname      string   `json:"lbHostname"`
+	NlbIPArray      []string `json:"nlbIPArray"`
+	NlbSubdomain    string   `json:"nlbSubdomain"`
+	SecretNamespace string   `json:"secretNamespace"`
+	Type            string   `json:"type"`
+}
+
+type nlbDNSListEntry struct {
+	SecretName   string       `json:"secretName"`
+	SecretStatus string       `json:"secretStatus"`
+	Nlb          nlbDNSConfig `json:"Nlb"`
+}
+
+type nlbDNSRegisterRequest struct {
+	Cluster         string   `json:"cluster"`
+	NlbHost         string   `json:"nlbHost,omitempty"`
+	NlbIPArray      []string `json:"nlbIPArray,omitempty"`
+	LBHostname      string   `json:"lbHostname,omitempty"`
+	NlbSubdomain    string   `json:"nlbSubdomain,omitempty"`
+	SecretNamespace string   `json:"secretNamespace,omitempty"`
+	Type            string   `json:"type,omitempty"`
+}
+
+// nlbHealthMonitor is the Cloudflare health check of the IPs of a subdomain
+type nlbHealthMonitor struct {
+	NlbHost          string `json:"nlbHost"`
+	Enable           bool   `json:"enable"`
+	Description      string `json:"description"`
+	Type             string `json:"type"`
+	Method           string `json:"method"`
+	Path             string `json:"path"`
+	Timeout          int    `json:"timeout"`
+	Retries          int    `json:"retries"`
+	Interval         int    `json:"interval"`
+	Port             int    `json:"port"`
+	ExpectedBody     string `json:"expectedBody"`
+	ExpectedCodes    string `json:"expectedCodes"`
+	AllowsInsecure   bool   `json:"allowsInsecure"`
+	FollowsRedirects bool   `json:"followsRedirects"`
+	Status           string `json:"status,omitempty"`
+}
+
+func resourceIBMContainerNlbDNS() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerNlbDNSCreate,
+		Read:     resourceIBMContainerNlbDNSRead,
+		Update:   resourceIBMContainerNlbDNSUpdate,
+		Delete:   resourceIBMContainerNlbDNSDelete,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cluster ID or name",
+			},
+			"nlb_ips": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				Set:          schema.HashString,
+				ExactlyOneOf: []string{"nlb_ips", "lb_hostname"},
+				Description:  "Network load balancer IPs of a classic cluster",
+			},
+			"lb_hostname": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"nlb_ips", "lb_hostname"},
+				Description:  "Hostname of the VPC load balancer of a VPC cluster",
+			},
+			"nlb_host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Existing subdomain to register the IPs with, a new subdomain is created if not set",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "public",
+				ValidateFunc: validateAllowedStringValue([]string{"public", "private"}),
+				Description:  "Type of the subdomain: public or private",
+			},
+			"secret_namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Namespace of the TLS secret of the subdomain",
+			},
+			"monitor": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"lb_hostname"},
+				Description:   "Health check of the network load balancer IPs",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Enable the health check",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Description of the health check",
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "HTTP",
+							ValidateFunc: validateAllowedStringValue([]string{"HTTP", "HTTPS", "TCP"}),
+							Description:  "Protocol of the health check: HTTP, HTTPS or TCP",
+						},
+						"method": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "GET",
+							ValidateFunc: validateAllowedStringValue([]string{"GET", "HEAD"}),
+							Description:  "HTTP method of the health check",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "/",
+							Description: "HTTP path of the health check",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      80,
+							ValidateFunc: validation.IsPortNumber,
+							Description:  "Port of the health check",
+						},
+						"timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5,
+							ValidateFunc: validation.IntBetween(1, 10),
+							Description:  "Timeout of the health check in seconds",
+						},
+						"retries": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      2,
+							ValidateFunc: validation.IntBetween(1, 5),
+							Description:  "Number of retries before an IP is unhealthy",
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      60,
+							ValidateFunc: validation.IntBetween(60, 3600),
+							Description:  "Interval between health checks in seconds",
+						},
+						"expected_body": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Case-insensitive substring of a healthy response body",
+						},
+						"expected_codes": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "2xx",
+							Description: "HTTP codes of a healthy response",
+						},
+						"allows_insecure": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Do not validate the certificate of an HTTPS health check",
+						},
+						"follows_redirects": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Follow the redirects of an HTTP health check",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the health check",
+						},
+					},
+				},
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of the resource group",
+			},
+			"dns_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "DNS provider of the subdomain",
+			},
+			"secret_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the TLS secret of the subdomain",
+			},
+			"secret_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the TLS secret of the subdomain",
+			},
+			"subdomain_created": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the subdomain was created by the resource and is deleted with it",
+			},
+		},
+	}
+}
+
+func resourceIBMContainerNlbDNSCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getWorkerPoolTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+	target := targetEnv.ToMap()
+
+	cluster := d.Get("cluster").(string)
+	nlbHost := d.Get("nlb_host").(string)
+	params := nlbDNSRegisterRequest{
+		Cluster:         cluster,
+		NlbHost:         nlbHost,
+		SecretNamespace: d.Get("secret_namespace").(string),
+		Type:            d.Get("type").(string),
+	}
+
+	if v, ok := d.GetOk("lb_hostname"); ok {
+		params.LBHostname = v.(string)
+		params.NlbSubdomain = nlbHost
+		if nlbHost != "" {
+			_, err = restClient.Put("/v2/nlb-dns/vpc/replaceLBHostname", params, nil, target)
+		} else {
+			_, err = restClient.Post("/v2/nlb-dns/createNlbDNS", params, nil, target)
+		}
+	} else {
+		params.NlbIPArray = expandStringList(d.Get("nlb_ips").(*schema.Set).List())
+		if nlbHost != "" {
+			_, err = restClient.Put(fmt.Sprintf("/v1/nlb-dns/clusters/%s/add", url.PathEscape(cluster)), params, nil, target)
+		} else {
+			_, err = restClient.Post(fmt.Sprintf("/v1/nlb-dns/clusters/%s/register", url.PathEscape(cluster)), params, nil, target)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Error registering the network load balancer with cluster (%s): %s", cluster, err)
+	}
+
+	// The registration does not return the subdomain, it is looked up by the registered IPs or hostname
+	created := nlbHost == ""
+	if created {
+		entries, err := getNlbDNSList(restClient, cluster, target)
+		if err != nil {
+			return err
+		}
+		entry := findNlbDNSByTarget(entries, params.NlbIPArray, params.LBHostname)
+		if entry == nil {
+			return fmt.Errorf("Error finding the subdomain of the network load balancer in cluster (%s)", cluster)
+		}
+		nlbHost = entry.Nlb.NlbSubdomain
+	}
+	d.SetId(fmt.Sprintf("%s/%s", cluster, nlbHost))
+	d.Set("subdomain_created", created)
+
+	if monitor, ok := d.GetOk("monitor"); ok {
+		if err := configureNlbHealthMonitor(restClient, cluster, expandNlbHealthMonitor(nlbHost, monitor.([]interface{})), target); err != nil {
+			return err
+		}
+	}
+
+	return resourceIBMContainerNlbDNSRead(d, meta)
+}
+
+func resourceIBMContainerNlbDNSRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getWorkerPoolTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+	target := targetEnv.ToMap()
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	nlbHost := parts[1]
+
+	entries, err := getNlbDNSList(restClient, cluster, target)
+	if err != nil {
+		return err
+	}
+	entry := findNlbDNS(entries, nlbHost)
+	if entry == nil || (len(entry.Nlb.NlbIPArray) == 0 && entry.Nlb.LBHostname == "") {
+		log.Printf("[WARN] No network load balancer is registered with subdomain %s of cluster %s", nlbHost, cluster)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cluster", cluster)
+	d.Set("nlb_host", entry.Nlb.NlbSubdomain)
+	if entry.Nlb.LBHostname != "" {
+		d.Set("lb_hostname", entry.Nlb.LBHostname)
+	} else {
+		d.Set("nlb_ips", entry.Nlb.NlbIPArray)
+	}
+	if entry.Nlb.Type != "" {
+		d.Set("type", entry.Nlb.Type)
+	}
+	d.Set("secret_namespace", entry.Nlb.SecretNamespace)
+	d.Set("dns_type", entry.Nlb.DNSType)
+	d.Set("secret_name", entry.SecretName)
+	d.Set("secret_status", entry.SecretStatus)
+
+	if _, ok := d.GetOk("monitor"); ok {
+		monitor := nlbHealthMonitor{}
+		_, err := restClient.Get(fmt.Sprintf("/v1/health/clusters/%s/host/%s/config", url.PathEscape(cluster), url.PathEscape(nlbHost)), &monitor, target)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the health monitor of subdomain (%s) of cluster (%s): %s", nlbHost, cluster, err)
+		}
+		d.Set("monitor", flattenNlbHealthMonitor(monitor))
+	}
+	return nil
+}
+
+func resourceIBMContainerNlbDNSUpdate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getWorkerPoolTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+	target := targetEnv.ToMap()
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	nlbHost := parts[1]
+
+	if d.HasChange("nlb_ips") {
+		o, n := d.GetChange("nlb_ips")
+		add := expandStringList(n.(*schema.Set).Difference(o.(*schema.Set)).List())
+		remove := expandStringList(o.(*schema.Set).Difference(n.(*schema.Set)).List())
+		// IPs are added first so that the subdomain always resolves
+		if len(add) > 0 {
+			params := nlbDNSRegisterRequest{
+				Cluster:    cluster,
+				NlbHost:    nlbHost,
+				NlbIPArray: add,
+			}
+			if _, err := restClient.Put(fmt.Sprintf("/v1/nlb-dns/clusters/%s/add", url.PathEscape(cluster)), params, nil, target); err != nil {
+				return fmt.Errorf("Error adding IPs to subdomain (%s) of cluster (%s): %s", nlbHost, cluster, err)
+			}
+		}
+		if err := removeNlbDNSIPs(restClient, cluster, nlbHost, remove, target); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("lb_hostname") {
+		params := nlbDNSRegisterRequest{
+			Cluster:      cluster,
+			LBHostname:   d.Get("lb_hostname").(string),
+			NlbSubdomain: nlbHost,
+		}
+		if _, err := restClient.Put("/v2/nlb-dns/vpc/replaceLBHostname", params, nil, target); err != nil {
+			return fmt.Errorf("Error replacing the load balancer hostname of subdomain (%s) of cluster (%s): %s", nlbHost, cluster, err)
+		}
+	}
+
+	if d.HasChange("monitor") {
+		monitor := expandNlbHealthMonitor(nlbHost, d.Get("monitor").([]interface{}))
+		if err := configureNlbHealthMonitor(restClient, cluster, monitor, target); err != nil {
+			return err
+		}
+	}
+
+	return resourceIBMContainerNlbDNSRead(d, meta)
+}
+
+func resourceIBMContainerNlbDNSDelete(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getWorkerPoolTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+	target := targetEnv.ToMap()
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	nlbHost := parts[1]
+
+	if _, ok := d.GetOk("monitor"); ok {
+		if err := configureNlbHealthMonitor(restClient, cluster, expandNlbHealthMonitor(nlbHost, nil), target); err != nil {
+			return err
+		}
+	}
+
+	if _, ok := d.GetOk("lb_hostname"); ok {
+		// An existing subdomain is kept, only the subdomains created by the resource are deleted
+		if !d.Get("subdomain_created").(bool) {
+			log.Printf("[WARN] Keeping subdomain %s of cluster %s that was not created by terraform", nlbHost, cluster)
+			return nil
+		}
+		params := nlbDNSRegisterRequest{
+			Cluster:      cluster,
+			NlbSubdomain: nlbHost,
+		}
+		if _, err := restClient.Post("/v2/nlb-dns/deleteNlbDNS", params, nil, target); err != nil {
+			return fmt.Errorf("Error deleting subdomain (%s) of cluster (%s): %s", nlbHost, cluster, err)
+		}
+		return nil
+	}
+
+	// The subdomain of a classic cluster cannot be deleted, it is kept without IPs
+	return removeNlbDNSIPs(restClient, cluster, nlbHost, expandStringList(d.Get("nlb_ips").(*schema.Set).List()), target)
+}
+
+func getNlbDNSList(restClient containerRESTClient, cluster string, target map[string]string) ([]nlbDNSListEntry, error) {
+	entries := []nlbDNSListEntry{}
+	if _, err := restClient.Get(fmt.Sprintf("/v2/nlb-dns/getNlbDNSList?cluster=%s", url.QueryEscape(cluster)), &entries, target); err != nil {
+		return nil, fmt.Errorf("Error listing the network load balancer subdomains of cluster (%s): %s", cluster, err)
+	}
+	return entries, nil
+}
+
+func removeNlbDNSIPs(restClient containerRESTClient, cluster, nlbHost string, ips []string, target map[string]string) error {
+	for _, ip := range ips {
+		path := fmt.Sprintf("/v1/nlb-dns/clusters/%s/host/%s/ip/%s/remove", url.PathEscape(cluster), url.PathEscape(nlbHost), url.PathEscape(ip))
+		if _, err := restClient.Delete(path, target); err != nil {
+			return fmt.Errorf("Error removing IP (%s) from subdomain (%s) of cluster (%s): %s", ip, nlbHost, cluster, err)
+		}
+	}
+	return nil
+}
+
+func configureNlbHealthMonitor(restClient containerRESTClient, cluster string, monitor nlbHealthMonitor, target map[string]string) error {
+	if _, err := restClient.Post(fmt.Sprintf("/v1/health/clusters/%s/config", url.PathEscape(cluster)), monitor, nil, target); err != nil {
+		return fmt.Errorf("Error configuring the health monitor of subdomain (%s) of cluster (%s): %s", monitor.NlbHost, cluster, err)
+	}
+	return nil
+}
+
+func findNlbDNS(entries []nlbDNSListEntry, nlbHost string) *nlbDNSListEntry {
+	for i := range entries {
+		if entries[i].Nlb.NlbSubdomain == nlbHost {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// findNlbDNSByTarget returns the subdomain that has all the IPs or the load balancer hostname
+func findNlbDNSByTarget(entries []nlbDNSListEntry, ips []string, lbHostname string) *nlbDNSListEntry {
+	for i := range entries {
+		if lbHostname != "" {
+			if entries[i].Nlb.LBHostname == lbHostname {
+				return &entries[i]
+			}
+			continue
+		}
+		registered := make(map[string]bool, len(entries[i].Nlb.NlbIPArray))
+		for _, ip := range entries[i].Nlb.NlbIPArray {
+			registered[ip] = true
+		}
+		found := len(ips) > 0
+		for _, ip := range ips {
+			found = found && registered[ip]
+		}
+		if found {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+// expandNlbHealthMonitor returns a disabled health monitor when no monitor is configured
+func expandNlbHealthMonitor(nlbHost string, monitors []interface{}) nlbHealthMonitor {
+	if len(monitors) == 0 || monitors[0] == nil {
+		return nlbHealthMonitor{NlbHost: nlbHost, Enable: false}
+	}
+	m := monitors[0].(map[string]interface{})
+	return nlbHealthMonitor{
+		NlbHost:          nlbHost,
+		Enable:           m["enabled"].(bool),
+		Description:      m["description"].(string),
+		Type:             m["type"].(string),
+		Method:           m["method"].(string),
+		Path:             m["path"].(string),
+		Port:             m["port"].(int),
+		Timeout:          m["timeout"].(int),
+		Retries:          m["retries"].(int),
+		Interval:         m["interval"].(int),
+		ExpectedBody:     m["expected_body"].(string),
+		ExpectedCodes:    m["expected_codes"].(string),
+		AllowsInsecure:   m["allows_insecure"].(bool),
+		FollowsRedirects: m["follows_redirects"].(bool),
+	}
+}
+
+func flattenNlbHealthMonitor(monitor nlbHealthMonitor) []map[string]interface{} {
+	return []map[string]interface{}{
+		{
+			"enabled":           monitor.Enable,
+			"description":       monitor.Description,
+			"type":              monitor.Type,
+			"method":            monitor.Method,
+			"path":              monitor.Path,
+			"port":              monitor.Port,
+			"timeout":           monitor.Timeout,
+			"retries":           monitor.Retries,
+			"interval":          monitor.Interval,
+			"expected_body":     monitor.ExpectedBody,
+			"expected_codes":    monitor.ExpectedCodes,
+			"allows_insecure":   monitor.AllowsInsecure,
+			"follows_redirects": monitor.FollowsRedirects,
+			"status":            monitor.Status,
+		},
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMContainerNlbDNS_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerNlbDNSBasic(60),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_nlb_dns.dns", "nlb_ips.#", "1"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_nlb_dns.dns", "nlb_host"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_nlb_dns.dns", "subdomain_created", "true"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_nlb_dns.dns", "monitor.0.interval", "60"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerNlbDNSBasic(120),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_nlb_dns.dns", "monitor.0.interval", "120"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerNlbDNSBasic(interval int) string {
+	return fmt.Sprintf(`
+resource "ibm_container_nlb_dns" "dns" {
+  cluster = "%s"
+  nlb_ips = ["%s"]
+
+  monitor {
+    path     = "/healthz"
+    interval = %d
+  }
+}`, clusterNameOrID, clusterNlbIP, interval)
+}
+
+func TestNlbDNS(t *testing.T) {
+	entries := []nlbDNSListEntry{
+		{SecretName: "secret1", Nlb: nlbDNSConfig{NlbSubdomain: "sub1", NlbIPArray: []string{"10.0.0.1", "10.0.0.2"}}},
+		{SecretName: "secret2", Nlb: nlbDNSConfig{NlbSubdomain: "sub2", LBHostname: "lb.example.com"}},
+	}
+
+	assert.Equal(t, "secret1", findNlbDNS(entries, "sub1").SecretName)
+	assert.Assert(t, is.Nil(findNlbDNS(entries, "sub3")))
+	assert.Equal(t, "sub1", findNlbDNSByTarget(entries, []string{"10.0.0.2"}, "").Nlb.NlbSubdomain)
+	assert.Assert(t, is.Nil(findNlbDNSByTarget(entries, []string{"10.0.0.2", "10.0.0.3"}, "")))
+	assert.Equal(t, "sub2", findNlbDNSByTarget(entries, nil, "lb.example.com").Nlb.NlbSubdomain)
+	assert.Assert(t, is.Nil(findNlbDNSByTarget(entries, nil, "")))
+
+	monitor := expandNlbHealthMonitor("sub1", []interface{}{
+		map[string]interface{}{
+			"enabled":           true,
+			"description":       "",
+			"type":              "HTTPS",
+			"method":            "GET",
+			"path":              "/healthz",
+			"port":              443,
+			"timeout":           5,
+			"retries":           2,
+			"interval":          60,
+			"expected_body":     "",
+			"expected_codes":    "2xx",
+			"allows_insecure":   true,
+			"follows_redirects": false,
+		},
+	})
+	assert.Equal(t, "sub1", monitor.NlbHost)
+	assert.Assert(t, monitor.Enable)
+	assert.Equal(t, 443, monitor.Port)
+	assert.Equal(t, "/healthz", flattenNlbHealthMonitor(monitor)[0]["path"])
+
+	assert.Assert(t, !expandNlbHealthMonitor("sub1", nil).Enable)
+}
@@ -0,0 +1,309 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+const (
+	storageAttachmentAttached = "attached"
+	storageAttachmentDetached = "detached"
+)
+
+// storageAttachment is a VPC block volume attached to a worker of a cluster
+type storageAttachment struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Type   string `json:"type"`
+	Volume struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"volume"`
+	Device struct {
+		ID string `json:"id"`
+	} `json:"device"`
+}
+
+type storageAttachmentRequest struct {
+	Cluster  string `json:"cluster"`
+	Worker   string `json:"worker"`
+	VolumeID string `json:"volumeID"`
+}
+
+func resourceIBMContainerStorageAttachment() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerStorageAttachmentCreate,
+		Read:     resourceIBMContainerStorageAttachmentRead,
+		Delete:   resourceIBMContainerStorageAttachmentDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cluster name or ID",
+			},
+			"worker": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the worker to attach the volume to",
+			},
+			"volume": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the VPC block volume",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of the resource group.",
+			},
+			"volume_attachment_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the volume attachment",
+			},
+			"volume_attachment_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the volume attachment",
+			},
+			"volume_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the VPC block volume",
+			},
+			"device_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the device of the volume on the worker",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the volume attachment",
+			},
+			"volume_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the volume attachment",
+			},
+		},
+	}
+}
+
+func resourceIBMContainerStorageAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	cluster := d.Get("cluster").(string)
+	worker := d.Get("worker").(string)
+	params := storageAttachmentRequest{
+		Cluster:  cluster,
+		Worker:   worker,
+		VolumeID: d.Get("volume").(string),
+	}
+	attachment := storageAttachment{}
+	if _, err := restClient.Post("/v2/storage/vpc/createAttachment", params, &attachment, targetEnv.ToMap()); err != nil {
+		return fmt.Errorf("Error attaching volume (%s) to worker (%s) of cluster (%s): %s", params.VolumeID, worker, cluster, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", cluster, worker, attachment.ID))
+
+	_, err = WaitForStorageAttachmentAvailable(d, meta, cluster, worker, attachment.ID, d.Timeout(schema.TimeoutCreate), targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for volume attachment (%s) to become ready: %s", d.Id(), err)
+	}
+
+	return resourceIBMContainerStorageAttachmentRead(d, meta)
+}
+
+func resourceIBMContainerStorageAttachmentRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	worker := parts[1]
+	attachmentID := parts[2]
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := getStorageAttachment(restClient, cluster, worker, attachmentID, targetEnv.ToMap())
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving volume attachment (%s) of worker (%s) of cluster (%s): %s", attachmentID, worker, cluster, err)
+	}
+	if attachment.Status == storageAttachmentDetached {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cluster", cluster)
+	d.Set("worker", worker)
+	d.Set("volume", attachment.Volume.ID)
+	d.Set("volume_attachment_id", attachment.ID)
+	d.Set("volume_attachment_name", attachment.Name)
+	d.Set("volume_name", attachment.Volume.Name)
+	d.Set("device_id", attachment.Device.ID)
+	d.Set("status", attachment.Status)
+	d.Set("volume_type", attachment.Type)
+	return nil
+}
+
+func resourceIBMContainerStorageAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	worker := parts[1]
+	attachmentID := parts[2]
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("/v2/storage/vpc/deleteAttachment?cluster=%s&worker=%s&volumeAttachmentID=%s", url.QueryEscape(cluster), url.QueryEscape(worker), url.QueryEscape(attachmentID))
+	if _, err := restClient.Delete(path, targetEnv.ToMap()); err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error detaching volume attachment (%s) from worker (%s) of cluster (%s): %s", attachmentID, worker, cluster, err)
+	}
+
+	_, err = WaitForStorageAttachmentDelete(d, meta, cluster, worker, attachmentID, d.Timeout(schema.TimeoutDelete), targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for volume attachment (%s) to be detached: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func getStorageAttachment(restClient containerRESTClient, cluster, worker, attachmentID string, target map[string]string) (storageAttachment, error) {
+	attachment := storageAttachment{}
+	_, err := restClient.Get(fmt.Sprintf("/v2/storage/getAttachment?cluster=%s&worker=%s&volumeAttachmentID=%s", url.QueryEscape(cluster), url.QueryEscape(worker), url.QueryEscape(attachmentID)), &attachment, target)
+	return attachment, err
+}
+
+func getStorageAttachments(restClient containerRESTClient, cluster, worker string, target map[string]string) ([]storageAttachment, error) {
+	attachments := struct {
+		VolumeAttachments []storageAttachment `json:"volume_attachments"`
+	}{}
+	_, err := restClient.Get(fmt.Sprintf("/v2/storage/getAttachments?cluster=%s&worker=%s", url.QueryEscape(cluster), url.QueryEscape(worker)), &attachments, target)
+	return attachments.VolumeAttachments, err
+}
+
+func WaitForStorageAttachmentAvailable(d *schema.ResourceData, meta interface{}, cluster, worker, attachmentID string, timeout time.Duration, target map[string]string) (interface{}, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("Waiting for volume attachment (%s) to be available.", attachmentID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"attaching"},
+		Target:     []string{storageAttachmentAttached},
+		Refresh:    storageAttachmentStateRefreshFunc(restClient, cluster, worker, attachmentID, target),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func storageAttachmentStateRefreshFunc(client containerRESTClient, cluster, worker, attachmentID string, target map[string]string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		attachment, err := getStorageAttachment(client, cluster, worker, attachmentID, target)
+		if err != nil {
+			return nil, "", fmt.Errorf("Error retrieving volume attachment: %s", err)
+		}
+		if strings.Contains(attachment.Status, "fail") {
+			return attachment, attachment.Status, fmt.Errorf("The volume attachment %s failed: %s", attachmentID, attachment.Status)
+		}
+		if attachment.Status != storageAttachmentAttached {
+			log.Printf("volume attachment: %s status: %s", attachmentID, attachment.Status)
+			return attachment, "attaching", nil
+		}
+		return attachment, storageAttachmentAttached, nil
+	}
+}
+
+func WaitForStorageAttachmentDelete(d *schema.ResourceData, meta interface{}, cluster, worker, attachmentID string, timeout time.Duration, target map[string]string) (interface{}, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"detaching"},
+		Target:     []string{storageAttachmentDetached},
+		Refresh:    storageAttachmentDeleteStateRefreshFunc(restClient, cluster, worker, attachmentID, target),
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
+func storageAttachmentDeleteStateRefreshFunc(client containerRESTClient, cluster, worker, attachmentID string, target map[string]string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		attachment, err := getStorageAttachment(client, cluster, worker, attachmentID, target)
+		if err != nil {
+			if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+				return attachment, storageAttachmentDetached, nil
+			}
+			return nil, "", fmt.Errorf("Error retrieving volume attachment: %s", err)
+		}
+		if attachment.Status != storageAttachmentDetached {
+			log.Printf("Detaching volume attachment %s", attachmentID)
+			return attachment, "detaching", nil
+		}
+		return attachment, storageAttachmentDetached, nil
+	}
+}
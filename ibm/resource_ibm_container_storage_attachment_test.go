@@ -0,0 +1,88 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+func TestAccIBMContainerStorageAttachment_Basic(t *testing.T) {
+	name := fmt.Sprintf("tf-volume-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMContainerStorageAttachmentDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerStorageAttachmentBasic(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_storage_attachment.attachment", "status", "attached"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_storage_attachment.attachment", "volume_attachment_id"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_storage_attachment.attachment", "volume_name", name),
+				),
+			},
+			{
+				ResourceName:      "ibm_container_storage_attachment.attachment",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerStorageAttachmentDestroy(s *terraform.State) error {
+	restClient, err := getContainerRESTClient(testAccProvider.Meta())
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_container_storage_attachment" {
+			continue
+		}
+
+		parts, err := idParts(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		attachment, err := getStorageAttachment(restClient, parts[0], parts[1], parts[2], nil)
+		if err == nil {
+			if attachment.Status != storageAttachmentDetached {
+				return fmt.Errorf("Volume attachment still exists: %s", rs.Primary.ID)
+			}
+		} else if apiErr, ok := err.(bmxerror.RequestFailure); !ok || apiErr.StatusCode() != 404 {
+			return fmt.Errorf("Error waiting for volume attachment (%s) to be destroyed: %s", rs.Primary.ID, err)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMContainerStorageAttachmentBasic(name string) string {
+	return fmt.Sprintf(`
+data "ibm_container_vpc_cluster" "cluster" {
+  cluster_name_id = "%[1]s"
+}
+
+resource "ibm_is_volume" "volume" {
+  name    = "%[2]s"
+  profile = "10iops-tier"
+  zone    = "%[3]s"
+}
+
+resource "ibm_container_storage_attachment" "attachment" {
+  cluster = "%[1]s"
+  worker  = data.ibm_container_vpc_cluster.cluster.workers[0]
+  volume  = ibm_is_volume.volume.id
+}`, clusterNameOrID, name, ISZoneName)
+}
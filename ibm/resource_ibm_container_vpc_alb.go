@@ -88,6 +88,12 @@ func resourceIBMContainerVpcALB() *schema.Resource {
 				Computed:    true,
 				Description: "Zone info.",
 			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the ALB image, the ALB is updated to the version when it changes",
+			},
 		},
 	}
 }
@@ -143,6 +149,14 @@ func resourceIBMContainerVpcALBCreate(d *schema.ResourceData, meta interface{})
 			"Error waiting for create resource alb (%s) : %s", d.Id(), err)
 	}
 
+	if v, ok := d.GetOk("version"); ok && enable {
+		err = updateVpcAlbVersion(d, meta, albID, v.(string), schema.TimeoutCreate)
+		if err != nil {
+			return fmt.Errorf(
+				"Error updating the version of alb (%s) : %s", d.Id(), err)
+		}
+	}
+
 	return resourceIBMContainerVpcALBRead(d, meta)
 }
 
@@ -173,6 +187,7 @@ func resourceIBMContainerVpcALBRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("status", albConfig.Status)
 	d.Set("state", albConfig.State)
 	d.Set("load_balancer_hostname", albConfig.LoadBalancerHostname)
+	d.Set("version", albConfig.AlbBuild)
 
 	return nil
 }
@@ -221,9 +236,83 @@ func resourceIBMContainerVpcALBUpdate(d *schema.ResourceData, meta interface{})
 		}
 
 	}
+	if d.HasChange("version") {
+		err = updateVpcAlbVersion(d, meta, d.Id(), d.Get("version").(string), schema.TimeoutUpdate)
+		if err != nil {
+			return fmt.Errorf(
+				"Error updating the version of alb (%s) : %s", d.Id(), err)
+		}
+	}
 	return resourceIBMContainerVpcALBRead(d, meta)
 }
 
+// vpcAlbUpdateRequest updates the ALBs of the list to the version of the ALB image
+type vpcAlbUpdateRequest struct {
+	Cluster  string   `json:"cluster"`
+	AlbBuild string   `json:"albBuild"`
+	AlbList  []string `json:"albList"`
+}
+
+// updateVpcAlbVersion updates the ALB to the version unless it runs the version already
+func updateVpcAlbVersion(d *schema.ResourceData, meta interface{}, albID, version, timeout string) error {
+	albClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return err
+	}
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv := v2.ClusterTargetHeader{}
+	alb, err := albClient.Albs().GetAlb(albID, targetEnv)
+	if err != nil {
+		return err
+	}
+	if alb.AlbBuild == version {
+		return nil
+	}
+
+	params := vpcAlbUpdateRequest{
+		Cluster:  alb.Cluster,
+		AlbBuild: version,
+		AlbList:  []string{albID},
+	}
+	_, err = restClient.Post("/v2/alb/updateAlb", params, nil, targetEnv.ToMap())
+	if err != nil {
+		return err
+	}
+	_, err = waitForVpcContainerALBVersion(d, meta, albID, version, timeout)
+	return err
+}
+
+func waitForVpcContainerALBVersion(d *schema.ResourceData, meta interface{}, albID, version, timeout string) (interface{}, error) {
+	albClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return false, err
+	}
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"updating"},
+		Target:  []string{"updated"},
+		Refresh: func() (interface{}, string, error) {
+			targetEnv := v2.ClusterTargetHeader{}
+			alb, err := albClient.Albs().GetAlb(albID, targetEnv)
+			if err != nil {
+				return nil, "", err
+			}
+			if alb.AlbBuild != version {
+				log.Printf("alb: %s version: %s", albID, alb.AlbBuild)
+				return alb, "updating", nil
+			}
+			return alb, "updated", nil
+		},
+		Timeout:    d.Timeout(timeout),
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
 func waitForVpcContainerALB(d *schema.ResourceData, meta interface{}, albID, timeout string, enable, disableDeployment bool) (interface{}, error) {
 	albClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
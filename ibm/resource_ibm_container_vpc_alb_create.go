@@ -0,0 +1,264 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"time"
+
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// vpcAlbCreateRequest adds the version of the ALB image to the create request of the SDK
+type vpcAlbCreateRequest struct {
+	v2.AlbCreateReq
+	IngressImage string `json:"ingressImage,omitempty"`
+}
+
+type vpcAlbCreateResponse struct {
+	Alb     string `json:"alb"`
+	Cluster string `json:"cluster"`
+}
+
+func resourceIBMContainerVpcALBCreateNew() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerVpcALBCreateNewCreate,
+		Read:     resourceIBMContainerVpcALBCreateNewRead,
+		Update:   resourceIBMContainerVpcALBCreateNewUpdate,
+		Delete:   resourceIBMContainerVpcALBCreateNewDelete,
+		Importer: &schema.ResourceImporter{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Cluster name or ID",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: InvokeValidator("ibm_container_vpc_alb_create", "type"),
+				Description:  "Type of the ALB, public or private",
+			},
+			"zone": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Zone of the ALB",
+			},
+			"enable": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Enable the ALB instance in the cluster",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the ALB image, the ALB is updated to the version when it changes",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "ID of the resource group.",
+			},
+			"alb_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ALB ID",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ALB name",
+			},
+			"load_balancer_hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Load balancer host name",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ALB state",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the ALB",
+			},
+		},
+	}
+}
+
+func resourceIBMContainerVpcALBCreateNewValidator() *ResourceValidator {
+
+	validateSchema := make([]ValidateSchema, 1)
+
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 "type",
+			ValidateFunctionIdentifier: ValidateAllowedStringValue,
+			Type:                       TypeString,
+			Required:                   true,
+			AllowedValues:              "public, private"})
+
+	ibmContainerVpcALBCreateNewResourceValidator := ResourceValidator{ResourceName: "ibm_container_vpc_alb_create", Schema: validateSchema}
+	return &ibmContainerVpcALBCreateNewResourceValidator
+}
+
+func resourceIBMContainerVpcALBCreateNewCreate(d *schema.ResourceData, meta interface{}) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	cluster := d.Get("cluster").(string)
+	enable := d.Get("enable").(bool)
+	params := vpcAlbCreateRequest{
+		AlbCreateReq: v2.AlbCreateReq{
+			Cluster:         cluster,
+			EnableByDefault: enable,
+			Type:            d.Get("type").(string),
+			ZoneAlb:         d.Get("zone").(string),
+		},
+		IngressImage: d.Get("version").(string),
+	}
+	res := vpcAlbCreateResponse{}
+	if _, err := restClient.Post("/v2/alb/vpc/createAlb", params, &res, targetEnv.ToMap()); err != nil {
+		return fmt.Errorf("Error creating %s alb in zone (%s) of cluster (%s): %s", params.Type, params.ZoneAlb, cluster, err)
+	}
+
+	d.SetId(res.Alb)
+	_, err = waitForVpcContainerALB(d, meta, res.Alb, schema.TimeoutCreate, enable, !enable)
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for create resource alb (%s) : %s", d.Id(), err)
+	}
+
+	return resourceIBMContainerVpcALBCreateNewRead(d, meta)
+}
+
+func resourceIBMContainerVpcALBCreateNewRead(d *schema.ResourceData, meta interface{}) error {
+	albClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	albID := d.Id()
+	albConfig, err := albClient.Albs().GetAlb(albID, targetEnv)
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving alb (%s): %s", albID, err)
+	}
+
+	d.Set("cluster", albConfig.Cluster)
+	d.Set("type", albConfig.AlbType)
+	d.Set("zone", albConfig.ZoneAlb)
+	d.Set("enable", albConfig.Enable)
+	d.Set("version", albConfig.AlbBuild)
+	d.Set("alb_id", albID)
+	d.Set("name", albConfig.Name)
+	d.Set("load_balancer_hostname", albConfig.LoadBalancerHostname)
+	d.Set("state", albConfig.State)
+	d.Set("status", albConfig.Status)
+	return nil
+}
+
+func resourceIBMContainerVpcALBCreateNewUpdate(d *schema.ResourceData, meta interface{}) error {
+	albClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	albID := d.Id()
+	if d.HasChange("enable") {
+		enable := d.Get("enable").(bool)
+		params := v2.AlbConfig{
+			AlbID:  albID,
+			Enable: enable,
+		}
+		if enable {
+			err = albClient.Albs().EnableAlb(params, targetEnv)
+		} else {
+			err = albClient.Albs().DisableAlb(params, targetEnv)
+		}
+		if err != nil {
+			return err
+		}
+		_, err = waitForVpcContainerALB(d, meta, albID, schema.TimeoutUpdate, enable, !enable)
+		if err != nil {
+			return fmt.Errorf(
+				"Error waiting for updating resource alb (%s) : %s", d.Id(), err)
+		}
+	}
+	if d.HasChange("version") {
+		err = updateVpcAlbVersion(d, meta, albID, d.Get("version").(string), schema.TimeoutUpdate)
+		if err != nil {
+			return fmt.Errorf(
+				"Error updating the version of alb (%s) : %s", d.Id(), err)
+		}
+	}
+	return resourceIBMContainerVpcALBCreateNewRead(d, meta)
+}
+
+func resourceIBMContainerVpcALBCreateNewDelete(d *schema.ResourceData, meta interface{}) error {
+	albClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return err
+	}
+	targetEnv, err := getVpcClusterTargetHeader(d, meta)
+	if err != nil {
+		return err
+	}
+
+	// ALBs can not be deleted, the ALB is disabled instead
+	albID := d.Id()
+	params := v2.AlbConfig{
+		AlbID:  albID,
+		Enable: false,
+	}
+	if err := albClient.Albs().DisableAlb(params, targetEnv); err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error disabling alb (%s): %s", albID, err)
+	}
+	_, err = waitForVpcContainerALB(d, meta, albID, schema.TimeoutDelete, false, true)
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for disabling resource alb (%s) : %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
+)
+
+func TestAccIBMContainerVpcALBCreate_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMContainerVpcALBCreateDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMContainerVpcALBCreateBasic(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_vpc_alb_create.alb", "type", "private"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_vpc_alb_create.alb", "enable", "true"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_container_vpc_alb_create.alb", "version"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerVpcALBCreateBasic(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_vpc_alb_create.alb", "enable", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerVpcALBCreateDestroy(s *terraform.State) error {
+	csClient, err := testAccProvider.Meta().(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_container_vpc_alb_create" {
+			continue
+		}
+
+		alb, err := csClient.Albs().GetAlb(rs.Primary.ID, v2.ClusterTargetHeader{})
+		if err != nil {
+			return fmt.Errorf("Error retrieving alb (%s): %s", rs.Primary.ID, err)
+		}
+		if alb.Enable {
+			return fmt.Errorf("ALB is still enabled: %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckIBMContainerVpcALBCreateBasic(enable bool) string {
+	return fmt.Sprintf(`
+resource "ibm_container_vpc_alb_create" "alb" {
+  cluster = "%s"
+  type    = "private"
+  zone    = "%s"
+  enable  = %t
+}`, clusterNameOrID, ISZoneName, enable)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/IBM/go-sdk-core/v3/core"
 	"github.com/IBM/vpc-go-sdk/vpcclassicv1"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -36,17 +37,23 @@ const (
 
 func resourceIBMContainerVpcCluster() *schema.Resource {
 	return &schema.Resource{
-		Create:   resourceIBMContainerVpcClusterCreate,
-		Read:     resourceIBMContainerVpcClusterRead,
-		Update:   resourceIBMContainerVpcClusterUpdate,
-		Delete:   resourceIBMContainerVpcClusterDelete,
-		Exists:   resourceIBMContainerVpcClusterExists,
-		Importer: &schema.ResourceImporter{},
+		Create:      resourceIBMContainerVpcClusterCreate,
+		ReadContext: resourceIBMContainerVpcClusterReadContext,
+		Update:      resourceIBMContainerVpcClusterUpdate,
+		Delete:      resourceIBMContainerVpcClusterDelete,
+		Exists:      resourceIBMContainerVpcClusterExists,
+		Importer:    &schema.ResourceImporter{},
 
 		CustomizeDiff: customdiff.Sequence(
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 				return resourceTagsCustomizeDiff(diff)
 			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceWorkerUpdateCustomizeDiff(diff, nil)
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceKubeVersionCustomizeDiff(diff, v)
+			},
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -126,7 +133,7 @@ func resourceIBMContainerVpcCluster() *schema.Resource {
 				Computed: true,
 				Optional: true,
 				DiffSuppressFunc: func(k, o, n string, d *schema.ResourceData) bool {
-					if o == "" {
+					if o == "" || isKubeVersionChannel(n) {
 						return false
 					}
 					new := strings.Split(n, ".")
@@ -137,7 +144,22 @@ func resourceIBMContainerVpcCluster() *schema.Resource {
 					}
 					return false
 				},
-				Description: "Kubernetes version",
+				Description: "Kubernetes version, or a channel that resolves to the default version, the latest version or the latest patch of a minor version: default, latest or major.minor.x",
+			},
+
+			"auto_upgrade_minor_version": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Upgrade the master to the next minor version when the default or latest kube_version channel moves to a newer minor version",
+			},
+
+			"end_of_support_warning_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      30,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Warn when the Kubernetes version of the master reaches its end of support within the number of days, 0 disables the warning",
 			},
 
 			"update_all_workers": {
@@ -166,6 +188,10 @@ func resourceIBMContainerVpcCluster() *schema.Resource {
 				Description: "Wait for worker node to update during kube version update.",
 			},
 
+			"update_strategy": workerUpdateStrategySchema(true),
+
+			"worker_update_status": workerUpdateStatusSchema(),
+
 			"service_subnet": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -189,6 +215,30 @@ func resourceIBMContainerVpcCluster() *schema.Resource {
 				Description: "Number of worker nodes in the cluster",
 			},
 
+			"host_pool_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the dedicated host pool that the workers of the default worker pool are placed on",
+			},
+
+			"security_groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the VPC security groups that are attached to the workers of the cluster instead of the default security group of the VPC",
+			},
+
+			"disable_outbound_traffic_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Allow the workers to reach the public network. The API enables the outbound traffic protection on the clusters it creates unless this is set, clusters created before the protection was available are not protected",
+			},
+
 			"worker_labels": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -400,11 +450,6 @@ func resourceIBMContainerVpcClusterCreate(d *schema.ResourceData, meta interface
 		vpcProvider = "vpc-gen2"
 	}
 
-	csClient, err := meta.(ClientSession).VpcContainerAPI()
-	if err != nil {
-		return err
-	}
-
 	disablePublicServiceEndpoint := d.Get("disable_public_service_endpoint").(bool)
 	name := d.Get("name").(string)
 	var kubeVersion string
@@ -478,8 +523,19 @@ func resourceIBMContainerVpcClusterCreate(d *schema.ResourceData, meta interface
 		return err
 	}
 
-	cls, err := csClient.Clusters().Create(params, targetEnv)
+	req := vpcClusterCreateRequest{
+		ClusterCreateRequest: params,
+		WorkerPools: vpcWorkerPoolConfig{
+			WorkerPoolConfig: params.WorkerPools,
+			HostPoolID:       d.Get("host_pool_id").(string),
+		},
+		DisableOutboundTrafficProtection: d.Get("disable_outbound_traffic_protection").(bool),
+	}
+	if v, ok := d.GetOk("security_groups"); ok {
+		req.SecurityGroupIDs = expandStringList(v.(*schema.Set).List())
+	}
 
+	cls, err := createVpcCluster(meta, req, targetEnv)
 	if err != nil {
 		return err
 	}
@@ -573,7 +629,14 @@ func resourceIBMContainerVpcClusterUpdate(d *schema.ResourceData, meta interface
 
 	}
 
-	if (d.HasChange("kube_version") || d.HasChange("update_all_workers") || d.HasChange("patch_version") || d.HasChange("retry_patch_version")) && !d.IsNewResource() {
+	if d.HasChange("disable_outbound_traffic_protection") && !d.IsNewResource() {
+		err := setVpcClusterOutboundTrafficProtection(meta, clusterID, d.Get("disable_outbound_traffic_protection").(bool), targetEnv)
+		if err != nil {
+			return fmt.Errorf("Error updating the outbound traffic protection of cluster (%s): %s", clusterID, err)
+		}
+	}
+
+	if (d.HasChange("kube_version") || d.HasChange("update_all_workers") || d.HasChange("patch_version") || d.HasChange("retry_patch_version") || d.HasChange("worker_update_status")) && !d.IsNewResource() {
 
 		if d.HasChange("kube_version") {
 			ClusterClient, err := meta.(ClientSession).ContainerAPI()
@@ -622,70 +685,22 @@ func resourceIBMContainerVpcClusterUpdate(d *schema.ResourceData, meta interface
 		}
 
 		// Update the worker nodes after master node kube-version is updated.
-		// workers will store the existing workers info to identify the replaced node
-		workersInfo := make(map[string]int, 0)
-
 		updateAllWorkers := d.Get("update_all_workers").(bool)
-		if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") {
+		if updateAllWorkers || d.HasChange("patch_version") || d.HasChange("retry_patch_version") || d.HasChange("worker_update_status") {
 
 			patchVersion := d.Get("patch_version").(string)
 			workers, err := csClient.Workers().ListWorkers(clusterID, false, targetEnv)
 			if err != nil {
-				d.Set("patch_version", nil)
+				keepAppliedPatchVersion(d)
 				return fmt.Errorf("Error retrieving workers for cluster: %s", err)
 			}
 
-			for index, worker := range workers {
-				workersInfo[worker.ID] = index
-			}
-			workersCount := len(workers)
-
 			waitForWorkerUpdate := d.Get("wait_for_worker_update").(bool)
-
-			for _, worker := range workers {
-				// check if change is present in MAJOR.MINOR version or in PATCH version
-				if strings.Split(worker.KubeVersion.Actual, "_")[0] != strings.Split(cls.MasterKubeVersion, "_")[0] || (strings.Split(worker.KubeVersion.Actual, ".")[2] != patchVersion && patchVersion == strings.Split(worker.KubeVersion.Target, ".")[2]) {
-					_, err := csClient.Workers().ReplaceWokerNode(clusterID, worker.ID, targetEnv)
-					// As API returns http response 204 NO CONTENT, error raised will be exempted.
-					if err != nil && !strings.Contains(err.Error(), "EmptyResponseBody") {
-						d.Set("patch_version", nil)
-						return fmt.Errorf("Error replacing the worker node from the cluster: %s", err)
-					}
-
-					if waitForWorkerUpdate {
-						//1. wait for worker node to delete
-						_, deleteError := waitForWorkerNodetoDelete(d, meta, targetEnv, worker.ID)
-						if deleteError != nil {
-							d.Set("patch_version", nil)
-							return fmt.Errorf("Worker node - %s is failed to replace", worker.ID)
-						}
-
-						//2. wait for new workerNode
-						_, newWorkerError := waitForNewWorker(d, meta, targetEnv, workersCount)
-						if newWorkerError != nil {
-							d.Set("patch_version", nil)
-							return fmt.Errorf("Failed to spawn new worker node")
-						}
-
-						//3. Get new worker node ID and update the map
-						newWorkerID, index, newNodeError := getNewWorkerID(d, meta, targetEnv, workersInfo)
-						if newNodeError != nil {
-							d.Set("patch_version", nil)
-							return fmt.Errorf("Unable to find the new worker node info")
-						}
-
-						delete(workersInfo, worker.ID)
-						workersInfo[newWorkerID] = index
-
-						//4. wait for the worker's version update and normal state
-						_, Err := WaitForVpcClusterWokersVersionUpdate(d, meta, targetEnv, cls.MasterKubeVersion, newWorkerID)
-						if Err != nil {
-							d.Set("patch_version", nil)
-							return fmt.Errorf(
-								"Error waiting for cluster (%s) worker nodes kube version to be updated: %s", d.Id(), Err)
-						}
-					}
-				}
+			status, err := updateVpcWorkers(d, meta, targetEnv, clusterID, workers, cls.MasterKubeVersion, patchVersion, waitForWorkerUpdate)
+			d.Set("worker_update_status", flattenWorkerUpdateStatus(status))
+			if err != nil {
+				keepAppliedPatchVersion(d)
+				return err
 			}
 		}
 	}
@@ -789,6 +804,96 @@ func resourceIBMContainerVpcClusterUpdate(d *schema.ResourceData, meta interface
 
 	return resourceIBMContainerVpcClusterRead(d, meta)
 }
+
+// updateVpcWorkers replaces the workers that are behind the master version following the
+// update_strategy. Replaced workers get new IDs.
+func updateVpcWorkers(d *schema.ResourceData, meta interface{}, targetEnv v2.ClusterTargetHeader, clusterID string, workers []v2.Worker, masterVersion, patchVersion string, waitForWorkerUpdate bool) (workerUpdateStatus, error) {
+	status := workerUpdateStatus{targetVersion: masterVersion}
+	csClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return status, err
+	}
+
+	strategy := expandWorkerUpdateStrategy(d)
+	batches, skipped := planWorkerUpdate(vpcWorkerUpdateCandidates(workers, masterVersion, patchVersion), strategy)
+	status.skipped = skipped
+
+	err = rollWorkerUpdate(batches, strategy, &status, func(ids []string) ([]string, []string, error) {
+		// workersInfo stores the existing workers info to identify the replaced nodes
+		allWorkers, err := csClient.Workers().ListWorkers(clusterID, false, targetEnv)
+		if err != nil {
+			return nil, ids, fmt.Errorf("Error retrieving workers for cluster: %s", err)
+		}
+		workersInfo := make(map[string]int, len(allWorkers))
+		for index, worker := range allWorkers {
+			workersInfo[worker.ID] = index
+		}
+		workersCount := len(allWorkers)
+
+		for i, id := range ids {
+			_, err := csClient.Workers().ReplaceWokerNode(clusterID, id, targetEnv)
+			// As API returns http response 204 NO CONTENT, error raised will be exempted.
+			if err != nil && !strings.Contains(err.Error(), "EmptyResponseBody") {
+				return ids[:i], ids[i:], fmt.Errorf("Error replacing the worker node from the cluster: %s", err)
+			}
+		}
+		if !waitForWorkerUpdate {
+			return ids, nil, nil
+		}
+
+		//1. wait for worker nodes to delete
+		for _, id := range ids {
+			if _, err := waitForWorkerNodetoDelete(d, meta, targetEnv, clusterID, id); err != nil {
+				return nil, ids, fmt.Errorf("Worker node - %s is failed to replace", id)
+			}
+		}
+
+		//2. wait for new workerNodes
+		if _, err := waitForNewWorker(d, meta, targetEnv, clusterID, workersCount); err != nil {
+			return nil, ids, fmt.Errorf("Failed to spawn new worker node")
+		}
+
+		//3. Get new worker node IDs and update the map
+		newWorkerIDs := []string{}
+		for _, id := range ids {
+			newWorkerID, index, err := getNewWorkerID(d, meta, targetEnv, clusterID, workersInfo)
+			if err != nil {
+				return newWorkerIDs, ids[len(newWorkerIDs):], fmt.Errorf("Unable to find the new worker node info")
+			}
+			delete(workersInfo, id)
+			workersInfo[newWorkerID] = index
+			newWorkerIDs = append(newWorkerIDs, newWorkerID)
+		}
+
+		//4. wait for the workers' version update and normal state
+		for i, newWorkerID := range newWorkerIDs {
+			if _, err := WaitForVpcClusterWokersVersionUpdate(d, meta, targetEnv, clusterID, masterVersion, newWorkerID); err != nil {
+				return newWorkerIDs[:i], ids[i:], fmt.Errorf(
+					"Error waiting for cluster (%s) worker nodes kube version to be updated: %s", clusterID, err)
+			}
+		}
+		return newWorkerIDs, nil, nil
+	})
+	return status, err
+}
+
+// vpcWorkerUpdateCandidates checks if a change is present in MAJOR.MINOR version or in PATCH version
+// of the workers. The pool name and ID let update_strategy pools select the workers.
+func vpcWorkerUpdateCandidates(workers []v2.Worker, masterVersion, patchVersion string) []workerUpdateCandidate {
+	candidates := make([]workerUpdateCandidate, 0, len(workers))
+	for _, worker := range workers {
+		candidates = append(candidates, workerUpdateCandidate{
+			id:          worker.ID,
+			pool:        worker.PoolName,
+			poolID:      worker.PoolID,
+			zone:        worker.Location,
+			normal:      worker.Health.State == workerNormal,
+			needsUpdate: workerNeedsUpdate(worker.KubeVersion.Actual, worker.KubeVersion.Target, masterVersion, patchVersion),
+		})
+	}
+	return candidates
+}
+
 func WaitForV2WorkerZoneDeleted(clusterNameOrID, workerPoolNameOrID, zone string, meta interface{}, timeout time.Duration, target v2.ClusterTargetHeader) (interface{}, error) {
 	csClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
@@ -822,6 +927,13 @@ func workerPoolV2ZoneDeleteStateRefreshFunc(client v2.Workers, instanceID, worke
 		return workerFields, workerDeleteState, nil
 	}
 }
+func resourceIBMContainerVpcClusterReadContext(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := resourceIBMContainerVpcClusterRead(d, meta); err != nil {
+		return diag.FromErr(err)
+	}
+	return kubeVersionSupportDiagnostics(d, meta)
+}
+
 func resourceIBMContainerVpcClusterRead(d *schema.ResourceData, meta interface{}) error {
 
 	csClient, err := meta.(ClientSession).VpcContainerAPI()
@@ -872,6 +984,19 @@ func resourceIBMContainerVpcClusterRead(d *schema.ResourceData, meta interface{}
 	}
 	d.Set("worker_count", workerPool.WorkerCount)
 	d.Set("worker_labels", IgnoreSystemLabels(workerPool.Labels))
+	vpcConfig, err := getVpcWorkerPoolConfig(meta, clusterID, "default", targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving the dedicated host pool and security groups of the default worker pool of cluster (%s): %s", clusterID, err)
+	}
+	d.Set("host_pool_id", vpcConfig.HostPoolID)
+	d.Set("security_groups", vpcConfig.SecurityGroupIDs)
+	outboundProtection, err := getVpcClusterOutboundTrafficProtection(meta, clusterID, targetEnv)
+	if err != nil {
+		return fmt.Errorf("Error retrieving the outbound traffic protection of cluster (%s): %s", clusterID, err)
+	}
+	if outboundProtection != nil {
+		d.Set("disable_outbound_traffic_protection", !*outboundProtection)
+	}
 	if cls.Vpcs != nil {
 		d.Set("vpc_id", cls.Vpcs[0])
 	}
@@ -911,6 +1036,56 @@ func resourceIBMContainerVpcClusterRead(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
+// vpcClusterCreateRequest adds the security groups, the outbound traffic protection and the dedicated
+// host pool of the default worker pool to the SDK request
+type vpcClusterCreateRequest struct {
+	v2.ClusterCreateRequest
+	WorkerPools                      vpcWorkerPoolConfig `json:"workerPool"`
+	SecurityGroupIDs                 []string            `json:"securityGroupIDs,omitempty"`
+	DisableOutboundTrafficProtection bool                `json:"disableOutboundTrafficProtection,omitempty"`
+}
+
+func createVpcCluster(meta interface{}, req vpcClusterCreateRequest, target v2.ClusterTargetHeader) (v2.ClusterCreateResponse, error) {
+	cls := v2.ClusterCreateResponse{}
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return cls, err
+	}
+	_, err = restClient.Post("/v2/vpc/createCluster", req, &cls, target.ToMap())
+	return cls, err
+}
+
+// getVpcClusterOutboundTrafficProtection returns nil when the API does not report the outbound
+// traffic protection of the cluster
+func getVpcClusterOutboundTrafficProtection(meta interface{}, clusterID string, target v2.ClusterTargetHeader) (*bool, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	cluster := struct {
+		OutboundTrafficProtection *bool `json:"outboundTrafficProtection"`
+	}{}
+	_, err = restClient.Get(fmt.Sprintf("/v2/vpc/getCluster?cluster=%s", clusterID), &cluster, target.ToMap())
+	return cluster.OutboundTrafficProtection, err
+}
+
+func setVpcClusterOutboundTrafficProtection(meta interface{}, clusterID string, disable bool, target v2.ClusterTargetHeader) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	operation := "enable-outbound-protection"
+	if disable {
+		operation = "disable-outbound-protection"
+	}
+	params := map[string]string{
+		"cluster":   clusterID,
+		"operation": operation,
+	}
+	_, err = restClient.Post("/network/v2/outbound-traffic-protection", params, nil, target.ToMap())
+	return err
+}
+
 func resourceIBMContainerVpcClusterDelete(d *schema.ResourceData, meta interface{}) error {
 
 	targetEnv, err := getVpcClusterTargetHeader(d, meta)
@@ -1176,7 +1351,7 @@ func waitForVpcClusterIngressAvailable(d *schema.ResourceData, meta interface{})
 	return createStateConf.WaitForState()
 }
 
-func getVpcClusterTargetHeader(d *schema.ResourceData, meta interface{}) (v2.ClusterTargetHeader, error) {
+func getVpcClusterTargetHeader(d dataRetriever, meta interface{}) (v2.ClusterTargetHeader, error) {
 	targetEnv := v2.ClusterTargetHeader{}
 	var resourceGroup string
 	if rg, ok := d.GetOk("resource_group_id"); ok {
@@ -1249,14 +1424,13 @@ func vpcClusterVersionRefreshFunc(client v2.Clusters, instanceID string, d *sche
 }
 
 // WaitForVpcClusterWokersVersionUpdate Waits for Cluster version Update
-func WaitForVpcClusterWokersVersionUpdate(d *schema.ResourceData, meta interface{}, target v2.ClusterTargetHeader, masterVersion, workerID string) (interface{}, error) {
+func WaitForVpcClusterWokersVersionUpdate(d *schema.ResourceData, meta interface{}, target v2.ClusterTargetHeader, clusterID, masterVersion, workerID string) (interface{}, error) {
 	csClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
 		return nil, err
 	}
 
 	log.Printf("Waiting for worker (%s) version to be updated.", workerID)
-	clusterID := d.Id()
 	stateConf := &resource.StateChangeConf{
 		Pending:                   []string{"retry", versionUpdating},
 		Target:                    []string{workerNormal},
@@ -1284,14 +1458,13 @@ func vpcClusterWorkersVersionRefreshFunc(client v2.Workers, workerID, clusterID
 	}
 }
 
-func waitForWorkerNodetoDelete(d *schema.ResourceData, meta interface{}, targetEnv v2.ClusterTargetHeader, workerID string) (interface{}, error) {
+func waitForWorkerNodetoDelete(d *schema.ResourceData, meta interface{}, targetEnv v2.ClusterTargetHeader, clusterID, workerID string) (interface{}, error) {
 
 	csClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
 		return nil, err
 	}
 
-	clusterID := d.Id()
 	deleteStateConf := &resource.StateChangeConf{
 		Pending: []string{workerDeletePending},
 		Target:  []string{workerDeleteState},
@@ -1313,13 +1486,12 @@ func waitForWorkerNodetoDelete(d *schema.ResourceData, meta interface{}, targetE
 	return deleteStateConf.WaitForState()
 }
 
-func waitForNewWorker(d *schema.ResourceData, meta interface{}, targetEnv v2.ClusterTargetHeader, workersCount int) (interface{}, error) {
+func waitForNewWorker(d *schema.ResourceData, meta interface{}, targetEnv v2.ClusterTargetHeader, clusterID string, workersCount int) (interface{}, error) {
 	csClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
 		return nil, err
 	}
 
-	clusterID := d.Id()
 	stateConf := &resource.StateChangeConf{
 		Pending: []string{"creating"},
 		Target:  []string{"created"},
@@ -1341,14 +1513,12 @@ func waitForNewWorker(d *schema.ResourceData, meta interface{}, targetEnv v2.Clu
 	return stateConf.WaitForState()
 }
 
-func getNewWorkerID(d *schema.ResourceData, meta interface{}, targetEnv v2.ClusterTargetHeader, workersInfo map[string]int) (string, int, error) {
+func getNewWorkerID(d *schema.ResourceData, meta interface{}, targetEnv v2.ClusterTargetHeader, clusterID string, workersInfo map[string]int) (string, int, error) {
 	csClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
 		return "", -1, err
 	}
 
-	clusterID := d.Id()
-
 	workers, err := csClient.Workers().ListWorkers(clusterID, false, targetEnv)
 	if err != nil {
 		return "", -1, fmt.Errorf("Error in retriving the list of worker nodes")
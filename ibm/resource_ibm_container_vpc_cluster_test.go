@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
 
 	bluemix "github.com/IBM-Cloud/bluemix-go"
 	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
@@ -99,6 +100,26 @@ func TestAccIBMContainerOpenshiftClusterBasic(t *testing.T) {
 		},
 	})
 }
+func TestVpcWorkerUpdateCandidates(t *testing.T) {
+	worker := func(id, pool, poolID, zone, actual string) v2.Worker {
+		w := v2.Worker{ID: id, PoolName: pool, PoolID: poolID, Location: zone}
+		w.KubeVersion.Actual = actual
+		w.KubeVersion.Target = "1.20.7_1535"
+		w.Health.State = workerNormal
+		return w
+	}
+	workers := []v2.Worker{
+		worker("w1", "default", "p1", "us-south-1", "1.19.11_1540"),
+		worker("w2", "default", "p1", "us-south-2", "1.19.11_1540"),
+		worker("w3", "edge", "p2", "us-south-1", "1.19.11_1540"),
+		worker("w4", "edge", "p2", "us-south-2", "1.20.7_1535"),
+	}
+
+	candidates := vpcWorkerUpdateCandidates(workers, "1.20.7_1535", "")
+	batches, _ := planWorkerUpdate(candidates, workerUpdateStrategy{maxUnavailable: 2, pools: []string{"edge", "p1"}})
+	assert.DeepEqual(t, [][]string{{"w3"}, {"w1", "w2"}}, batches)
+}
+
 func testAccCheckIBMContainerVpcClusterDestroy(s *terraform.State) error {
 	csClient, err := testAccProvider.Meta().(ClientSession).VpcContainerAPI()
 	if err != nil {
@@ -4,11 +4,13 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -32,9 +34,16 @@ func resourceIBMContainerVpcWorkerPool() *schema.Resource {
 		Importer: &schema.ResourceImporter{},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(90 * time.Minute),
 			Delete: schema.DefaultTimeout(90 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceIBMContainerVpcWorkerPoolCustomizeDiff(diff, v)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 			"cluster": {
 				Type:        schema.TypeString,
@@ -110,6 +119,30 @@ func resourceIBMContainerVpcWorkerPool() *schema.Resource {
 				DiffSuppressFunc: applyOnce,
 				Description:      "Entitlement option reduces additional OCP Licence cost in Openshift Clusters",
 			},
+			"host_pool_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of the dedicated host pool that the workers of the worker pool are placed on",
+			},
+			"security_groups": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "IDs of the VPC security groups that are attached to the workers of the worker pool instead of the default security group of the VPC",
+			},
+			"taints": workerPoolTaintsSchema(),
+			"update_all_workers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Updates the worker nodes of the worker pool to the Kubernetes version of the master",
+			},
+			"update_strategy":      workerUpdateStrategySchema(false),
+			"worker_update_status": workerUpdateStatusSchema(),
 			ResourceControllerURL: {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -121,11 +154,6 @@ func resourceIBMContainerVpcWorkerPool() *schema.Resource {
 
 func resourceIBMContainerVpcWorkerPoolCreate(d *schema.ResourceData, meta interface{}) error {
 
-	wpClient, err := meta.(ClientSession).VpcContainerAPI()
-	if err != nil {
-		return err
-	}
-
 	clusterNameorID := d.Get("cluster").(string)
 	var zonei []interface{}
 
@@ -176,13 +204,23 @@ func resourceIBMContainerVpcWorkerPoolCreate(d *schema.ResourceData, meta interf
 		workerPoolConfig.Entitlement = v.(string)
 	}
 
-	workerPoolsAPI := wpClient.WorkerPools()
 	targetEnv, err := getVpcClusterTargetHeader(d, meta)
 	if err != nil {
 		return err
 	}
 
-	res, err := workerPoolsAPI.CreateWorkerPool(params, targetEnv)
+	req := vpcWorkerPoolRequest{
+		Cluster: params.Cluster,
+		vpcWorkerPoolConfig: vpcWorkerPoolConfig{
+			WorkerPoolConfig: params.WorkerPoolConfig,
+			HostPoolID:       d.Get("host_pool_id").(string),
+		},
+	}
+	if v, ok := d.GetOk("security_groups"); ok {
+		req.SecurityGroupIDs = expandStringList(v.(*schema.Set).List())
+	}
+
+	res, err := createVpcWorkerPool(meta, req, targetEnv)
 	if err != nil {
 		return err
 	}
@@ -313,9 +351,83 @@ func resourceIBMContainerVpcWorkerPoolUpdate(d *schema.ResourceData, meta interf
 			}
 		}
 	}
+	if d.HasChange("taints") {
+		clusterNameOrID := d.Get("cluster").(string)
+		workerPoolName := d.Get("worker_pool_name").(string)
+		targetEnv, err := getVpcClusterTargetHeader(d, meta)
+		if err != nil {
+			return err
+		}
+		taints := expandWorkerPoolTaints(d.Get("taints").(*schema.Set))
+		err = setWorkerPoolTaints(meta, clusterNameOrID, workerPoolName, taints, targetEnv.ToMap())
+		if err != nil {
+			return fmt.Errorf(
+				"Error updating the taints: %s", err)
+		}
+	}
+
+	if d.HasChange("worker_update_status") && !d.IsNewResource() {
+		clusterID := d.Get("cluster").(string)
+		workerPoolName := d.Get("worker_pool_name").(string)
+		targetEnv, err := getVpcClusterTargetHeader(d, meta)
+		if err != nil {
+			return err
+		}
+		csClient, err := meta.(ClientSession).VpcContainerAPI()
+		if err != nil {
+			return err
+		}
+		cls, err := csClient.Clusters().GetCluster(clusterID, targetEnv)
+		if err != nil {
+			return fmt.Errorf("Error retrieving conatiner vpc cluster: %s", err)
+		}
+		workers, err := csClient.Workers().ListByWorkerPool(clusterID, workerPoolName, false, targetEnv)
+		if err != nil {
+			return fmt.Errorf("Error retrieving workers of worker pool (%s) of cluster (%s): %s", workerPoolName, clusterID, err)
+		}
+		status, err := updateVpcWorkers(d, meta, targetEnv, clusterID, workers, cls.MasterKubeVersion, "", true)
+		d.Set("worker_update_status", flattenWorkerUpdateStatus(status))
+		if err != nil {
+			return err
+		}
+	}
 	return resourceIBMContainerVpcWorkerPoolRead(d, meta)
 }
 
+// resourceIBMContainerVpcWorkerPoolCustomizeDiff plans the update of the workers when
+// update_all_workers is set and workers of the pool are behind the master version.
+func resourceIBMContainerVpcWorkerPoolCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.Get("update_all_workers").(bool) {
+		return nil
+	}
+	return resourceWorkerUpdateCustomizeDiff(diff, func() (bool, error) {
+		clusterID := diff.Get("cluster").(string)
+		workerPoolName := diff.Get("worker_pool_name").(string)
+		targetEnv, err := getVpcClusterTargetHeader(diff, meta)
+		if err != nil {
+			return false, err
+		}
+		csClient, err := meta.(ClientSession).VpcContainerAPI()
+		if err != nil {
+			return false, err
+		}
+		cls, err := csClient.Clusters().GetCluster(clusterID, targetEnv)
+		if err != nil {
+			return false, err
+		}
+		workers, err := csClient.Workers().ListByWorkerPool(clusterID, workerPoolName, false, targetEnv)
+		if err != nil {
+			return false, err
+		}
+		for _, worker := range workers {
+			if workerNeedsUpdate(worker.KubeVersion.Actual, worker.KubeVersion.Target, cls.MasterKubeVersion, "") {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
 func resourceIBMContainerVpcWorkerPoolRead(d *schema.ResourceData, meta interface{}) error {
 	wpClient, err := meta.(ClientSession).VpcContainerAPI()
 	if err != nil {
@@ -361,6 +473,17 @@ func resourceIBMContainerVpcWorkerPoolRead(d *schema.ResourceData, meta interfac
 	// d.Set("provider", workerPool.Provider)
 	d.Set("labels", IgnoreSystemLabels(workerPool.Labels))
 	d.Set("zones", zones)
+	taints, err := getWorkerPoolTaints(meta, cluster, workerPoolID, targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving the taints of worker pool (%s) of cluster (%s): %s", workerPoolID, cluster, err)
+	}
+	d.Set("taints", flattenWorkerPoolTaints(taints))
+	vpcConfig, err := getVpcWorkerPoolConfig(meta, cluster, workerPoolID, targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving the dedicated host pool and security groups of worker pool (%s) of cluster (%s): %s", workerPoolID, cluster, err)
+	}
+	d.Set("host_pool_id", vpcConfig.HostPoolID)
+	d.Set("security_groups", vpcConfig.SecurityGroupIDs)
 	d.Set("resource_group_id", cls.ResourceGroupID)
 	d.Set("cluster", cluster)
 	d.Set("vpc_id", workerPool.VpcID)
@@ -513,3 +636,37 @@ func vpcworkerPoolDeleteStateRefreshFunc(client v2.Workers, instanceID, workerPo
 		return workerFields, workerDeleteState, nil
 	}
 }
+
+// vpcWorkerPoolConfig adds the dedicated host pool and the security groups to the worker pool config of the SDK
+type vpcWorkerPoolConfig struct {
+	v2.WorkerPoolConfig
+	HostPoolID       string   `json:"dedicatedHostPoolId,omitempty"`
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
+}
+
+type vpcWorkerPoolRequest struct {
+	Cluster string `json:"cluster"`
+	vpcWorkerPoolConfig
+}
+
+func createVpcWorkerPool(meta interface{}, params vpcWorkerPoolRequest, target v2.ClusterTargetHeader) (v2.WorkerPoolResponse, error) {
+	res := v2.WorkerPoolResponse{}
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return res, err
+	}
+	_, err = restClient.Post("/v2/vpc/createWorkerPool", params, &res, target.ToMap())
+	return res, err
+}
+
+// getVpcWorkerPoolConfig returns the dedicated host pool and the security groups of the worker pool,
+// which the worker pool of the SDK does not have
+func getVpcWorkerPoolConfig(meta interface{}, clusterNameOrID, workerPoolNameOrID string, target map[string]string) (vpcWorkerPoolConfig, error) {
+	workerPool := vpcWorkerPoolConfig{}
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return workerPool, err
+	}
+	_, err = restClient.Get(fmt.Sprintf("/v2/vpc/getWorkerPool?cluster=%s&workerpool=%s", clusterNameOrID, workerPoolNameOrID), &workerPool, target)
+	return workerPool, err
+}
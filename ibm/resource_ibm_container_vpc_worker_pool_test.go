@@ -4,13 +4,17 @@
 package ibm
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
 
 	v2 "github.com/IBM-Cloud/bluemix-go/api/container/containerv2"
 )
@@ -203,3 +207,153 @@ func testAccCheckIBMVpcContainerWorkerPoolUpdate(name string) string {
 	}
 		`, name)
 }
+
+func TestVpcWorkerPoolHostPoolRequest(t *testing.T) {
+	config := v2.WorkerPoolConfig{
+		Name:        "pool",
+		Flavor:      "bx2d.4x16",
+		WorkerCount: 1,
+	}
+
+	body, err := json.Marshal(vpcWorkerPoolRequest{
+		Cluster: "cluster",
+		vpcWorkerPoolConfig: vpcWorkerPoolConfig{
+			WorkerPoolConfig: config,
+			HostPoolID:       "dh-123",
+		},
+	})
+	assert.NilError(t, err)
+	workerPool := map[string]interface{}{}
+	assert.NilError(t, json.Unmarshal(body, &workerPool))
+	assert.Equal(t, "cluster", workerPool["cluster"])
+	assert.Equal(t, "pool", workerPool["name"])
+	assert.Equal(t, "dh-123", workerPool["dedicatedHostPoolId"])
+
+	body, err = json.Marshal(vpcClusterCreateRequest{
+		ClusterCreateRequest: v2.ClusterCreateRequest{Name: "cluster", WorkerPools: config},
+		WorkerPools: vpcWorkerPoolConfig{
+			WorkerPoolConfig: config,
+			HostPoolID:       "dh-123",
+		},
+	})
+	assert.NilError(t, err)
+	cluster := struct {
+		Name       string                 `json:"name"`
+		WorkerPool map[string]interface{} `json:"workerPool"`
+	}{}
+	assert.NilError(t, json.Unmarshal(body, &cluster))
+	assert.Equal(t, "cluster", cluster.Name)
+	assert.Equal(t, "bx2d.4x16", cluster.WorkerPool["flavor"])
+	assert.Equal(t, "dh-123", cluster.WorkerPool["dedicatedHostPoolId"])
+}
+
+func TestVpcClusterSecurityGroupsRequest(t *testing.T) {
+	config := v2.WorkerPoolConfig{
+		Name:        "pool",
+		Flavor:      "bx2d.4x16",
+		WorkerCount: 1,
+	}
+	params := v2.ClusterCreateRequest{Name: "cluster", WorkerPools: config}
+
+	// Without the extensions the request is the one of the SDK
+	sdkBody, err := json.Marshal(params)
+	assert.NilError(t, err)
+	body, err := json.Marshal(vpcClusterCreateRequest{
+		ClusterCreateRequest: params,
+		WorkerPools:          vpcWorkerPoolConfig{WorkerPoolConfig: config},
+	})
+	assert.NilError(t, err)
+	sdkRequest, request := map[string]interface{}{}, map[string]interface{}{}
+	assert.NilError(t, json.Unmarshal(sdkBody, &sdkRequest))
+	assert.NilError(t, json.Unmarshal(body, &request))
+	assert.DeepEqual(t, sdkRequest, request)
+
+	body, err = json.Marshal(vpcClusterCreateRequest{
+		ClusterCreateRequest:             params,
+		WorkerPools:                      vpcWorkerPoolConfig{WorkerPoolConfig: config},
+		SecurityGroupIDs:                 []string{"r006-sg1", "r006-sg2"},
+		DisableOutboundTrafficProtection: true,
+	})
+	assert.NilError(t, err)
+	cluster := struct {
+		SecurityGroupIDs                 []string `json:"securityGroupIDs"`
+		DisableOutboundTrafficProtection bool     `json:"disableOutboundTrafficProtection"`
+	}{}
+	assert.NilError(t, json.Unmarshal(body, &cluster))
+	assert.DeepEqual(t, []string{"r006-sg1", "r006-sg2"}, cluster.SecurityGroupIDs)
+	assert.Assert(t, cluster.DisableOutboundTrafficProtection)
+
+	body, err = json.Marshal(vpcWorkerPoolRequest{
+		Cluster: "cluster",
+		vpcWorkerPoolConfig: vpcWorkerPoolConfig{
+			WorkerPoolConfig: config,
+			SecurityGroupIDs: []string{"r006-sg1"},
+		},
+	})
+	assert.NilError(t, err)
+	workerPool := map[string]interface{}{}
+	assert.NilError(t, json.Unmarshal(body, &workerPool))
+	assert.DeepEqual(t, []interface{}{"r006-sg1"}, workerPool["securityGroupIDs"])
+	_, ok := workerPool["dedicatedHostPoolId"]
+	assert.Assert(t, !ok)
+
+	// The worker pool of getWorkerPool is read back into the extended config
+	vpcConfig := vpcWorkerPoolConfig{}
+	assert.NilError(t, json.Unmarshal([]byte(`{"flavor":"bx2d.4x16","dedicatedHostPoolId":"dh-123","securityGroupIDs":["r006-sg1"]}`), &vpcConfig))
+	assert.Equal(t, "bx2d.4x16", vpcConfig.Flavor)
+	assert.Equal(t, "dh-123", vpcConfig.HostPoolID)
+	assert.DeepEqual(t, []string{"r006-sg1"}, vpcConfig.SecurityGroupIDs)
+}
+
+func TestVpcSecurityGroupsUnset(t *testing.T) {
+	raw := map[string]interface{}{
+		"cluster":          "cluster",
+		"flavor":           "bx2d.4x16",
+		"worker_pool_name": "pool",
+		"vpc_id":           "r006-vpc",
+		"worker_count":     1,
+		"zones": []interface{}{
+			map[string]interface{}{"name": "us-south-1", "subnet_id": "subnet1"},
+		},
+	}
+	pool := resourceIBMContainerVpcWorkerPool()
+	d := schema.TestResourceDataRaw(t, pool.Schema, raw)
+	d.SetId("cluster/pool")
+	// The security group of the cluster is always reported by the API
+	assert.NilError(t, d.Set("security_groups", []string{"kube-cluster"}))
+
+	diff, err := pool.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), nil)
+	assert.NilError(t, err)
+	if diff != nil {
+		_, ok := diff.Attributes["security_groups.#"]
+		assert.Assert(t, !ok)
+		assert.Assert(t, !diff.RequiresNew())
+	}
+
+	raw["security_groups"] = []interface{}{"r006-sg1"}
+	diff, err = pool.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), nil)
+	assert.NilError(t, err)
+	assert.Assert(t, diff.RequiresNew())
+
+	// The security groups of a cluster are read back from the default worker pool
+	raw = map[string]interface{}{
+		"name":   "cluster",
+		"flavor": "bx2d.4x16",
+		"vpc_id": "r006-vpc",
+		"zones": []interface{}{
+			map[string]interface{}{"name": "us-south-1", "subnet_id": "subnet1"},
+		},
+	}
+	cluster := resourceIBMContainerVpcCluster()
+	d = schema.TestResourceDataRaw(t, cluster.Schema, raw)
+	d.SetId("cluster")
+	assert.NilError(t, d.Set("security_groups", []string{"kube-cluster"}))
+
+	diff, err = cluster.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), nil)
+	assert.NilError(t, err)
+	if diff != nil {
+		_, ok := diff.Attributes["security_groups.#"]
+		assert.Assert(t, !ok)
+		assert.Assert(t, !diff.RequiresNew())
+	}
+}
@@ -4,14 +4,18 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
 	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceIBMContainerWorkerPool() *schema.Resource {
@@ -27,6 +31,12 @@ func resourceIBMContainerWorkerPool() *schema.Resource {
 			Update: schema.DefaultTimeout(90 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceIBMContainerWorkerPoolCustomizeDiff(diff, v)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 			"cluster": {
 				Type:        schema.TypeString,
@@ -122,6 +132,19 @@ func resourceIBMContainerWorkerPool() *schema.Resource {
 				Description: "list of labels to worker pool",
 			},
 
+			"taints": workerPoolTaintsSchema(),
+
+			"update_all_workers": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Updates the worker nodes of the worker pool to the Kubernetes version of the master",
+			},
+
+			"update_strategy": workerUpdateStrategySchema(false),
+
+			"worker_update_status": workerUpdateStatusSchema(),
+
 			"region": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -202,6 +225,13 @@ func resourceIBMContainerWorkerPoolCreate(d *schema.ResourceData, meta interface
 
 	d.SetId(fmt.Sprintf("%s/%s", clusterNameorID, res.ID))
 
+	if t, ok := d.GetOk("taints"); ok {
+		err = setWorkerPoolTaints(meta, clusterNameorID, res.ID, expandWorkerPoolTaints(t.(*schema.Set)), targetEnv.ToMap())
+		if err != nil {
+			return fmt.Errorf("Error setting the taints of worker pool (%s) of cluster (%s): %s", res.ID, clusterNameorID, err)
+		}
+	}
+
 	return resourceIBMContainerWorkerPoolRead(d, meta)
 }
 
@@ -245,6 +275,11 @@ func resourceIBMContainerWorkerPoolRead(d *schema.ResourceData, meta interface{}
 	d.Set("state", workerPool.State)
 	d.Set("labels", IgnoreSystemLabels(workerPool.Labels))
 	d.Set("zones", flattenZones(workerPool.Zones))
+	taints, err := getWorkerPoolTaints(meta, cluster, workerPoolID, targetEnv.ToMap())
+	if err != nil {
+		return fmt.Errorf("Error retrieving the taints of worker pool (%s) of cluster (%s): %s", workerPoolID, cluster, err)
+	}
+	d.Set("taints", flattenWorkerPoolTaints(taints))
 	d.Set("cluster", cluster)
 	if strings.Contains(machineType, "encrypted") {
 		d.Set("disk_encryption", true)
@@ -307,9 +342,97 @@ func resourceIBMContainerWorkerPoolUpdate(d *schema.ResourceData, meta interface
 		}
 	}
 
+	if d.HasChange("taints") {
+		taints := expandWorkerPoolTaints(d.Get("taints").(*schema.Set))
+		err = setWorkerPoolTaints(meta, clusterNameorID, workerPoolNameorID, taints, targetEnv.ToMap())
+		if err != nil {
+			return fmt.Errorf("Error setting the taints of worker pool (%s) of cluster (%s): %s", workerPoolNameorID, clusterNameorID, err)
+		}
+	}
+
+	if d.HasChange("worker_update_status") {
+		wrkAPI := csClient.Workers()
+		cluster, err := csClient.Clusters().Find(clusterNameorID, targetEnv)
+		if err != nil {
+			return fmt.Errorf("Error retrieving cluster %s: %s", clusterNameorID, err)
+		}
+		workerFields, err := wrkAPI.ListByWorkerPool(clusterNameorID, workerPoolNameorID, false, targetEnv)
+		if err != nil {
+			return fmt.Errorf("Error retrieving workers of worker pool (%s) of cluster (%s): %s", workerPoolNameorID, clusterNameorID, err)
+		}
+		workers := make([]workerUpdateCandidate, 0, len(workerFields))
+		for _, w := range workerFields {
+			workers = append(workers, workerUpdateCandidate{
+				id:          w.ID,
+				pool:        w.PoolName,
+				poolID:      w.PoolID,
+				zone:        w.Location,
+				normal:      w.State == workerNormal,
+				needsUpdate: workerNeedsUpdate(w.KubeVersion, w.TargetVersion, cluster.MasterKubeVersion, ""),
+			})
+		}
+		strategy := expandWorkerUpdateStrategy(d)
+		batches, skipped := planWorkerUpdate(workers, strategy)
+		status := workerUpdateStatus{targetVersion: cluster.MasterKubeVersion, skipped: skipped}
+		err = rollWorkerUpdate(batches, strategy, &status, func(ids []string) ([]string, []string, error) {
+			for i, id := range ids {
+				err := wrkAPI.Update(clusterNameorID, id, v1.WorkerUpdateParam{Action: "update"}, targetEnv)
+				if err != nil {
+					return ids[:i], ids[i:], fmt.Errorf("Error updating worker %s: %s", id, err)
+				}
+			}
+			_, err := WaitForWorkerNormal(clusterNameorID, workerPoolNameorID, meta, d.Timeout(schema.TimeoutUpdate), targetEnv)
+			if err != nil {
+				return nil, ids, fmt.Errorf(
+					"Error waiting for workers of worker pool (%s) of cluster (%s) to become ready: %s", workerPoolNameorID, clusterNameorID, err)
+			}
+			return ids, nil, nil
+		})
+		d.Set("worker_update_status", flattenWorkerUpdateStatus(status))
+		if err != nil {
+			return err
+		}
+	}
+
 	return resourceIBMContainerWorkerPoolRead(d, meta)
 }
 
+// resourceIBMContainerWorkerPoolCustomizeDiff plans the update of the workers when
+// update_all_workers is set and workers of the pool are behind the master version.
+func resourceIBMContainerWorkerPoolCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.Get("update_all_workers").(bool) {
+		return nil
+	}
+	return resourceWorkerUpdateCustomizeDiff(diff, func() (bool, error) {
+		parts, err := idParts(diff.Id())
+		if err != nil {
+			return false, err
+		}
+		targetEnv, err := getWorkerPoolTargetHeader(diff, meta)
+		if err != nil {
+			return false, err
+		}
+		csClient, err := meta.(ClientSession).ContainerAPI()
+		if err != nil {
+			return false, err
+		}
+		cluster, err := csClient.Clusters().Find(parts[0], targetEnv)
+		if err != nil {
+			return false, err
+		}
+		workerFields, err := csClient.Workers().ListByWorkerPool(parts[0], parts[1], false, targetEnv)
+		if err != nil {
+			return false, err
+		}
+		for _, w := range workerFields {
+			if workerNeedsUpdate(w.KubeVersion, w.TargetVersion, cluster.MasterKubeVersion, "") {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
 func resourceIBMContainerWorkerPoolDelete(d *schema.ResourceData, meta interface{}) error {
 	csClient, err := meta.(ClientSession).ContainerAPI()
 	if err != nil {
@@ -443,7 +566,7 @@ func workerPoolDeleteStateRefreshFunc(client v1.Workers, instanceID, workerPoolN
 	}
 }
 
-func getWorkerPoolTargetHeader(d *schema.ResourceData, meta interface{}) (v1.ClusterTargetHeader, error) {
+func getWorkerPoolTargetHeader(d dataRetriever, meta interface{}) (v1.ClusterTargetHeader, error) {
 
 	_, err := meta.(ClientSession).BluemixSession()
 	if err != nil {
@@ -467,3 +590,111 @@ func getWorkerPoolTargetHeader(d *schema.ResourceData, meta interface{}) (v1.Clu
 	}
 	return targetEnv, nil
 }
+
+// containerRESTClient is the REST client embedded in the container service API. It is used for the
+// endpoints that are not covered by bluemix-go.
+type containerRESTClient interface {
+	Get(path string, respV interface{}, extraHeader ...interface{}) (*http.Response, error)
+	Post(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*http.Response, error)
+	Put(path string, data interface{}, respV interface{}, extraHeader ...interface{}) (*http.Response, error)
+	Delete(path string, extraHeader ...interface{}) (*http.Response, error)
+}
+
+func getContainerRESTClient(meta interface{}) (containerRESTClient, error) {
+	csClient, err := meta.(ClientSession).VpcContainerAPI()
+	if err != nil {
+		return nil, err
+	}
+	restClient, ok := csClient.(containerRESTClient)
+	if !ok {
+		return nil, fmt.Errorf("The container service API does not expose its REST client")
+	}
+	return restClient, nil
+}
+
+func workerPoolTaintsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Kubernetes taints applied to the worker nodes of the worker pool",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Key of the taint",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Value of the taint",
+				},
+				"effect": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}, false),
+					Description:  "Effect of the taint: NoSchedule, PreferNoSchedule or NoExecute",
+				},
+			},
+		},
+	}
+}
+
+// expandWorkerPoolTaints converts the taints to the key: "value:effect" format of the API
+func expandWorkerPoolTaints(taints *schema.Set) map[string]string {
+	result := make(map[string]string, taints.Len())
+	for _, t := range taints.List() {
+		taint := t.(map[string]interface{})
+		result[taint["key"].(string)] = fmt.Sprintf("%s:%s", taint["value"].(string), taint["effect"].(string))
+	}
+	return result
+}
+
+func flattenWorkerPoolTaints(taints map[string]string) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(taints))
+	for key, taint := range taints {
+		value, effect := "", taint
+		if i := strings.LastIndex(taint, ":"); i >= 0 {
+			value, effect = taint[:i], taint[i+1:]
+		}
+		result = append(result, map[string]interface{}{
+			"key":    key,
+			"value":  value,
+			"effect": effect,
+		})
+	}
+	return result
+}
+
+type workerPoolTaintsRequest struct {
+	Cluster    string            `json:"cluster"`
+	WorkerPool string            `json:"workerpool"`
+	Taints     map[string]string `json:"taints"`
+}
+
+// setWorkerPoolTaints replaces the taints of the worker pool, an empty map removes all taints
+func setWorkerPoolTaints(meta interface{}, clusterNameOrID, workerPoolNameOrID string, taints map[string]string, target map[string]string) error {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	params := workerPoolTaintsRequest{
+		Cluster:    clusterNameOrID,
+		WorkerPool: workerPoolNameOrID,
+		Taints:     taints,
+	}
+	_, err = restClient.Post("/v2/setWorkerPoolTaints", params, nil, target)
+	return err
+}
+
+func getWorkerPoolTaints(meta interface{}, clusterNameOrID, workerPoolNameOrID string, target map[string]string) (map[string]string, error) {
+	restClient, err := getContainerRESTClient(meta)
+	if err != nil {
+		return nil, err
+	}
+	workerPool := struct {
+		Taints map[string]string `json:"taints"`
+	}{}
+	_, err = restClient.Get(fmt.Sprintf("/v2/getWorkerPool?cluster=%s&workerpool=%s", clusterNameOrID, workerPoolNameOrID), &workerPool, target)
+	return workerPool.Taints, err
+}
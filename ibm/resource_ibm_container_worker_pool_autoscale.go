@@ -0,0 +1,406 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
+)
+
+const (
+	autoscalerNamespace     = "kube-system"
+	autoscalerConfigMap     = "iks-ca-configmap"
+	autoscalerWorkerPoolKey = "workerPoolsConfig.json"
+)
+
+func resourceIBMContainerWorkerPoolAutoscale() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMContainerWorkerPoolAutoscaleCreate,
+		Read:     resourceIBMContainerWorkerPoolAutoscaleRead,
+		Update:   resourceIBMContainerWorkerPoolAutoscaleUpdate,
+		Delete:   resourceIBMContainerWorkerPoolAutoscaleDelete,
+		Importer: &schema.ResourceImporter{},
+
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceWorkerPoolAutoscaleCustomizeDiff(diff)
+			},
+		),
+
+		Schema: map[string]*schema.Schema{
+			"cluster": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name or ID of the cluster",
+			},
+			"worker_pool": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the worker pool",
+			},
+			"min_size": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum number of worker nodes per zone",
+			},
+			"max_size": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Maximum number of worker nodes per zone",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Let the cluster autoscaler scale the worker pool",
+			},
+			"resource_group_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "ID of the resource group",
+			},
+		},
+	}
+}
+
+func resourceIBMContainerWorkerPoolAutoscaleCreate(d *schema.ResourceData, meta interface{}) error {
+	cluster := d.Get("cluster").(string)
+	workerPool := d.Get("worker_pool").(string)
+
+	if err := applyWorkerPoolAutoscale(d, meta, cluster, workerPool); err != nil {
+		return fmt.Errorf("Error configuring the autoscaling of worker pool (%s) of cluster (%s): %s", workerPool, cluster, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", cluster, workerPool))
+	return resourceIBMContainerWorkerPoolAutoscaleRead(d, meta)
+}
+
+func resourceIBMContainerWorkerPoolAutoscaleRead(d *schema.ResourceData, meta interface{}) error {
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+	cluster := parts[0]
+	workerPool := parts[1]
+
+	kube, err := getClusterKubeAPIClient(d, meta, cluster)
+	if err != nil {
+		return err
+	}
+	config, err := readAutoscalerPoolConfig(kube, workerPool)
+	if err != nil {
+		if isKubeNotFound(err) {
+			log.Printf("[WARN] The cluster autoscaler of cluster (%s) is not installed", cluster)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving the autoscaling of worker pool (%s) of cluster (%s): %s", workerPool, cluster, err)
+	}
+	if config == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("cluster", cluster)
+	d.Set("worker_pool", workerPool)
+	d.Set("min_size", autoscalerInt(config["minSize"]))
+	d.Set("max_size", autoscalerInt(config["maxSize"]))
+	enabled, _ := config["enabled"].(bool)
+	d.Set("enabled", enabled)
+	return nil
+}
+
+func resourceIBMContainerWorkerPoolAutoscaleUpdate(d *schema.ResourceData, meta interface{}) error {
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("min_size") || d.HasChange("max_size") || d.HasChange("enabled") {
+		if err := applyWorkerPoolAutoscale(d, meta, parts[0], parts[1]); err != nil {
+			return fmt.Errorf("Error updating the autoscaling of worker pool (%s) of cluster (%s): %s", parts[1], parts[0], err)
+		}
+	}
+	return resourceIBMContainerWorkerPoolAutoscaleRead(d, meta)
+}
+
+func resourceIBMContainerWorkerPoolAutoscaleDelete(d *schema.ResourceData, meta interface{}) error {
+	parts, err := idParts(d.Id())
+	if err != nil {
+		return err
+	}
+
+	kube, err := getClusterKubeAPIClient(d, meta, parts[0])
+	if err != nil {
+		return err
+	}
+	// The autoscaler keeps the pool at its current size once it is disabled
+	err = writeAutoscalerPoolConfig(kube, parts[1], func(config map[string]interface{}) {
+		config["enabled"] = false
+	})
+	if err != nil && !isKubeNotFound(err) {
+		return fmt.Errorf("Error disabling the autoscaling of worker pool (%s) of cluster (%s): %s", parts[1], parts[0], err)
+	}
+	return nil
+}
+
+func applyWorkerPoolAutoscale(d *schema.ResourceData, meta interface{}, cluster, workerPool string) error {
+	minSize := d.Get("min_size").(int)
+	maxSize := d.Get("max_size").(int)
+	kube, err := getClusterKubeAPIClient(d, meta, cluster)
+	if err != nil {
+		return err
+	}
+	err = writeAutoscalerPoolConfig(kube, workerPool, func(config map[string]interface{}) {
+		config["minSize"] = minSize
+		config["maxSize"] = maxSize
+		config["enabled"] = d.Get("enabled").(bool)
+	})
+	if isKubeNotFound(err) {
+		return fmt.Errorf("ConfigMap %s/%s not found, install the cluster-autoscaler add-on first", autoscalerNamespace, autoscalerConfigMap)
+	}
+	return err
+}
+
+// resourceWorkerPoolAutoscaleCustomizeDiff rejects a min_size greater than max_size at plan time.
+// Sizes that are not known until apply are checked when the plan is made again during apply.
+func resourceWorkerPoolAutoscaleCustomizeDiff(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown("min_size") || !diff.NewValueKnown("max_size") {
+		return nil
+	}
+	minSize := diff.Get("min_size").(int)
+	maxSize := diff.Get("max_size").(int)
+	if minSize > maxSize {
+		return fmt.Errorf("min_size %d is greater than max_size %d", minSize, maxSize)
+	}
+	return nil
+}
+
+// readAutoscalerPoolConfig returns the autoscaler configuration of the worker pool, or nil when
+// the worker pool is not configured
+func readAutoscalerPoolConfig(kube *kubeAPIClient, workerPool string) (map[string]interface{}, error) {
+	configMap := map[string]interface{}{}
+	if err := kube.do("GET", autoscalerConfigMapPath(), nil, &configMap); err != nil {
+		return nil, err
+	}
+	pools, err := autoscalerPools(configMap)
+	if err != nil {
+		return nil, err
+	}
+	for _, pool := range pools {
+		if pool["name"] == workerPool {
+			return pool, nil
+		}
+	}
+	return nil, nil
+}
+
+// writeAutoscalerPoolConfig updates the configuration of the worker pool and keeps the
+// configuration of the other worker pools. The update is retried when the ConfigMap is changed
+// concurrently.
+func writeAutoscalerPoolConfig(kube *kubeAPIClient, workerPool string, update func(config map[string]interface{})) error {
+	for attempt := 0; ; attempt++ {
+		configMap := map[string]interface{}{}
+		if err := kube.do("GET", autoscalerConfigMapPath(), nil, &configMap); err != nil {
+			return err
+		}
+		pools, err := autoscalerPools(configMap)
+		if err != nil {
+			return err
+		}
+
+		var config map[string]interface{}
+		for _, pool := range pools {
+			if pool["name"] == workerPool {
+				config = pool
+			}
+		}
+		if config == nil {
+			config = map[string]interface{}{"name": workerPool}
+			pools = append(pools, config)
+		}
+		update(config)
+
+		data, err := json.MarshalIndent(pools, "", " ")
+		if err != nil {
+			return err
+		}
+		if configMap["data"] == nil {
+			configMap["data"] = map[string]interface{}{}
+		}
+		configMap["data"].(map[string]interface{})[autoscalerWorkerPoolKey] = string(data)
+
+		// The resourceVersion of the ConfigMap makes the update fail with a conflict when the
+		// ConfigMap was changed since it was read
+		err = kube.do("PUT", autoscalerConfigMapPath(), configMap, nil)
+		if kubeErr, ok := err.(kubeAPIError); ok && kubeErr.StatusCode == http.StatusConflict && attempt < 5 {
+			log.Printf("[DEBUG] ConfigMap %s/%s changed concurrently, retrying", autoscalerNamespace, autoscalerConfigMap)
+			continue
+		}
+		return err
+	}
+}
+
+func autoscalerConfigMapPath() string {
+	return fmt.Sprintf("/api/v1/namespaces/%s/configmaps/%s", autoscalerNamespace, autoscalerConfigMap)
+}
+
+func autoscalerPools(configMap map[string]interface{}) ([]map[string]interface{}, error) {
+	pools := []map[string]interface{}{}
+	data, _ := configMap["data"].(map[string]interface{})
+	raw, _ := data[autoscalerWorkerPoolKey].(string)
+	if strings.TrimSpace(raw) == "" {
+		return pools, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &pools); err != nil {
+		return nil, fmt.Errorf("Error parsing %s of ConfigMap %s/%s: %s", autoscalerWorkerPoolKey, autoscalerNamespace, autoscalerConfigMap, err)
+	}
+	return pools, nil
+}
+
+func autoscalerInt(v interface{}) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	}
+	return 0
+}
+
+// kubeAPIClient calls the Kubernetes API of a cluster
+type kubeAPIClient struct {
+	host   string
+	token  string
+	client *http.Client
+}
+
+type kubeAPIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e kubeAPIError) Error() string {
+	return fmt.Sprintf("Kubernetes API returned %d: %s", e.StatusCode, e.Message)
+}
+
+func isKubeNotFound(err error) bool {
+	kubeErr, ok := err.(kubeAPIError)
+	return ok && kubeErr.StatusCode == http.StatusNotFound
+}
+
+func newKubeAPIClient(host, caCertificate, clientCertificate, clientKey, token string) (*kubeAPIClient, error) {
+	tlsConfig := &tls.Config{}
+	if caCertificate != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(caCertificate)) {
+			return nil, fmt.Errorf("Invalid CA certificate of the cluster")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if clientCertificate != "" && clientKey != "" {
+		cert, err := tls.X509KeyPair([]byte(clientCertificate), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("Invalid admin certificate of the cluster: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return &kubeAPIClient{
+		host:  strings.TrimSuffix(host, "/"),
+		token: token,
+		client: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			Timeout:   60 * time.Second,
+		},
+	}, nil
+}
+
+// getClusterKubeAPIClient authenticates with the admin configuration of the cluster. The
+// configuration is downloaded to a temporary directory that is removed right away.
+func getClusterKubeAPIClient(d dataRetriever, meta interface{}, clusterNameOrID string) (*kubeAPIClient, error) {
+	csClient, err := meta.(ClientSession).ContainerAPI()
+	if err != nil {
+		return nil, err
+	}
+	targetEnv, err := getWorkerPoolTargetHeader(d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := ioutil.TempDir("", "ibm-cluster-config")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	config, err := csClient.Clusters().GetClusterConfigDetail(clusterNameOrID, dir, true, v1.ClusterTargetHeader(targetEnv))
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving the config of cluster (%s): %s", clusterNameOrID, err)
+	}
+	return newKubeAPIClient(config.Host, config.ClusterCACertificate, config.Admin, config.AdminKey, config.Token)
+}
+
+func (k *kubeAPIClient) do(method, path string, body interface{}, result interface{}) error {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		if reqBody, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	req, err := http.NewRequest(method, k.host+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	if k.token != "" {
+		req.Header.Set("Authorization", "Bearer "+k.token)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		status := struct {
+			Message string `json:"message"`
+		}{}
+		json.Unmarshal(respBody, &status)
+		if status.Message == "" {
+			status.Message = http.StatusText(resp.StatusCode)
+		}
+		return kubeAPIError{StatusCode: resp.StatusCode, Message: status.Message}
+	}
+	if result != nil {
+		return json.Unmarshal(respBody, result)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMContainerWorkerPoolAutoscaleBasic(t *testing.T) {
+	name := fmt.Sprintf("tf-vpc-autoscale-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccCheckIBMContainerWorkerPoolAutoscaleBasic(name, 3, 2, true),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("min_size 3 is greater than max_size 2"),
+			},
+			{
+				Config: testAccCheckIBMContainerWorkerPoolAutoscaleBasic(name, 1, 2, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_worker_pool_autoscale.autoscale", "min_size", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_worker_pool_autoscale.autoscale", "max_size", "2"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_worker_pool_autoscale.autoscale", "enabled", "true"),
+				),
+			},
+			{
+				Config: testAccCheckIBMContainerWorkerPoolAutoscaleBasic(name, 1, 3, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_container_worker_pool_autoscale.autoscale", "max_size", "3"),
+					resource.TestCheckResourceAttr(
+						"ibm_container_worker_pool_autoscale.autoscale", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMContainerWorkerPoolAutoscaleBasic(name string, minSize, maxSize int, enabled bool) string {
+	return fmt.Sprintf(`
+	provider "ibm"{
+		region = "eu-de"
+	}
+	resource "ibm_is_vpc" "vpc" {
+		name = "%[1]s"
+	}
+	resource "ibm_is_subnet" "subnet" {
+		name                     = "%[1]s"
+		vpc                      = ibm_is_vpc.vpc.id
+		zone                     = "eu-de-1"
+		total_ipv4_address_count = 256
+	}
+	resource "ibm_container_vpc_cluster" "cluster" {
+		name              = "%[1]s"
+		vpc_id            = ibm_is_vpc.vpc.id
+		flavor            = "cx2.2x4"
+		worker_count      = 1
+		wait_till         = "OneWorkerNodeReady"
+		zones {
+			subnet_id = ibm_is_subnet.subnet.id
+			name      = "eu-de-1"
+		}
+	}
+	resource "ibm_container_addons" "addons" {
+		cluster = ibm_container_vpc_cluster.cluster.id
+		addons {
+			name    = "cluster-autoscaler"
+		}
+	}
+	resource "ibm_container_worker_pool_autoscale" "autoscale" {
+		cluster     = ibm_container_addons.addons.cluster
+		worker_pool = "default"
+		min_size    = %[2]d
+		max_size    = %[3]d
+		enabled     = %[4]t
+	}`, name, minSize, maxSize, enabled)
+}
+
+// kubeConfigMapStub serves a single ConfigMap the way the Kubernetes API does, including the
+// conflict on a stale resourceVersion
+type kubeConfigMapStub struct {
+	sync.Mutex
+	server    *httptest.Server
+	configMap map[string]interface{}
+	version   int
+	conflicts int
+}
+
+func newKubeConfigMapStub(data map[string]interface{}) *kubeConfigMapStub {
+	stub := &kubeConfigMapStub{}
+	if data != nil {
+		stub.configMap = map[string]interface{}{
+			"metadata": map[string]interface{}{"name": autoscalerConfigMap, "namespace": autoscalerNamespace},
+			"data":     data,
+		}
+	}
+	stub.server = httptest.NewServer(http.HandlerFunc(stub.handle))
+	return stub
+}
+
+func (s *kubeConfigMapStub) handle(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+	if r.URL.Path != autoscalerConfigMapPath() || s.configMap == nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"kind":"Status","message":"configmaps not found"}`))
+		return
+	}
+	switch r.Method {
+	case "GET":
+		s.configMap["metadata"].(map[string]interface{})["resourceVersion"] = strconv.Itoa(s.version)
+		json.NewEncoder(w).Encode(s.configMap)
+	case "PUT":
+		body, _ := ioutil.ReadAll(r.Body)
+		configMap := map[string]interface{}{}
+		json.Unmarshal(body, &configMap)
+		if s.conflicts > 0 || configMap["metadata"].(map[string]interface{})["resourceVersion"] != strconv.Itoa(s.version) {
+			s.conflicts--
+			s.version++
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"kind":"Status","message":"the object has been modified"}`))
+			return
+		}
+		s.version++
+		s.configMap = configMap
+		json.NewEncoder(w).Encode(configMap)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func TestWorkerPoolAutoscaleConfig(t *testing.T) {
+	stub := newKubeConfigMapStub(map[string]interface{}{
+		autoscalerWorkerPoolKey: `[{"name":"default","minSize":1,"maxSize":2,"enabled":true,"custom":"kept"}]`,
+		"other":                 "kept",
+	})
+	defer stub.server.Close()
+	stub.conflicts = 1
+
+	kube, err := newKubeAPIClient(stub.server.URL, "", "", "", "token")
+	assert.NilError(t, err)
+
+	config, err := readAutoscalerPoolConfig(kube, "pool1")
+	assert.NilError(t, err)
+	assert.Assert(t, is.Nil(config))
+
+	// A conflicting update is retried with the latest ConfigMap
+	err = writeAutoscalerPoolConfig(kube, "pool1", func(config map[string]interface{}) {
+		config["minSize"] = 2
+		config["maxSize"] = 5
+		config["enabled"] = true
+	})
+	assert.NilError(t, err)
+
+	config, err = readAutoscalerPoolConfig(kube, "pool1")
+	assert.NilError(t, err)
+	assert.Equal(t, 2, autoscalerInt(config["minSize"]))
+	assert.Equal(t, 5, autoscalerInt(config["maxSize"]))
+	assert.Equal(t, true, config["enabled"])
+
+	config, err = readAutoscalerPoolConfig(kube, "default")
+	assert.NilError(t, err)
+	assert.Equal(t, 2, autoscalerInt(config["maxSize"]))
+	assert.Equal(t, "kept", config["custom"])
+	assert.Equal(t, "kept", stub.configMap["data"].(map[string]interface{})["other"])
+
+	err = writeAutoscalerPoolConfig(kube, "default", func(config map[string]interface{}) {
+		config["enabled"] = false
+	})
+	assert.NilError(t, err)
+	config, err = readAutoscalerPoolConfig(kube, "default")
+	assert.NilError(t, err)
+	assert.Equal(t, false, config["enabled"])
+	assert.Equal(t, 1, autoscalerInt(config["minSize"]))
+}
+
+func TestWorkerPoolAutoscaleNotInstalled(t *testing.T) {
+	stub := newKubeConfigMapStub(nil)
+	defer stub.server.Close()
+
+	kube, err := newKubeAPIClient(stub.server.URL, "", "", "", "")
+	assert.NilError(t, err)
+
+	_, err = readAutoscalerPoolConfig(kube, "default")
+	assert.Assert(t, isKubeNotFound(err))
+	err = writeAutoscalerPoolConfig(kube, "default", func(config map[string]interface{}) {})
+	assert.Assert(t, isKubeNotFound(err))
+}
@@ -12,7 +12,9 @@ import (
 	v1 "github.com/IBM-Cloud/bluemix-go/api/container/containerv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
 )
 
 func TestAccIBMContainerWorkerPoolBasic(t *testing.T) {
@@ -192,3 +194,19 @@ resource "ibm_container_worker_pool" "test_pool" {
   }
 }`, workerPoolName, machineType, clusterName)
 }
+
+func TestWorkerPoolTaints(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceIBMContainerWorkerPool().Schema, map[string]interface{}{
+		"taints": []interface{}{
+			map[string]interface{}{"key": "dedicated", "value": "edge", "effect": "NoSchedule"},
+			map[string]interface{}{"key": "gpu", "effect": "NoExecute"},
+		},
+	})
+	taints := expandWorkerPoolTaints(d.Get("taints").(*schema.Set))
+	assert.DeepEqual(t, map[string]string{"dedicated": "edge:NoSchedule", "gpu": ":NoExecute"}, taints)
+
+	assert.NilError(t, d.Set("taints", flattenWorkerPoolTaints(map[string]string{"dedicated": "edge:host:NoSchedule"})))
+	taint := d.Get("taints").(*schema.Set).List()[0].(map[string]interface{})
+	assert.Equal(t, "edge:host", taint["value"])
+	assert.Equal(t, "NoSchedule", taint["effect"])
+}
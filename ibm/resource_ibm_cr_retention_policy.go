@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/IBM-Cloud/bluemix-go/bmxerror"
+)
+
+// crRetentionPolicy is the image retention policy of a Container Registry namespace
+type crRetentionPolicy struct {
+	Namespace      string `json:"namespace"`
+	ImagesPerRepo  int    `json:"images_per_repo"`
+	RetainUntagged bool   `json:"retain_untagged"`
+}
+
+func resourceIBMCrRetentionPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMCrRetentionPolicyCreate,
+		Read:     resourceIBMCrRetentionPolicyRead,
+		Update:   resourceIBMCrRetentionPolicyUpdate,
+		Delete:   resourceIBMCrRetentionPolicyDelete,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Container Registry Namespace",
+			},
+			"images_per_repo": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Number of images that are retained in each repository of the namespace, -1 retains all images",
+				ValidateFunc: InvokeValidator("ibm_cr_retention_policy", "images_per_repo"),
+			},
+			"retain_untagged": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Retain all untagged images, untagged images are deleted by the policy by default",
+			},
+		},
+	}
+}
+
+func resourceIBMCrRetentionPolicyValidator() *ResourceValidator {
+
+	validateSchema := make([]ValidateSchema, 1)
+
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 "images_per_repo",
+			ValidateFunctionIdentifier: IntBetween,
+			Type:                       TypeInt,
+			Required:                   true,
+			MinValue:                   "-1",
+			MaxValue:                   "100"})
+
+	ibmCrRetentionPolicyResourceValidator := ResourceValidator{ResourceName: "ibm_cr_retention_policy", Schema: validateSchema}
+	return &ibmCrRetentionPolicyResourceValidator
+}
+
+func resourceIBMCrRetentionPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	namespace := d.Get("namespace").(string)
+	policy := crRetentionPolicy{
+		Namespace:      namespace,
+		ImagesPerRepo:  d.Get("images_per_repo").(int),
+		RetainUntagged: d.Get("retain_untagged").(bool),
+	}
+	if err := setCrRetentionPolicy(meta, policy); err != nil {
+		return fmt.Errorf("Error setting the retention policy of namespace (%s): %s", namespace, err)
+	}
+
+	d.SetId(namespace)
+	return resourceIBMCrRetentionPolicyRead(d, meta)
+}
+
+func resourceIBMCrRetentionPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	restClient, target, err := getContainerRegistryRESTClient(meta)
+	if err != nil {
+		return err
+	}
+
+	namespace := d.Id()
+	policy := crRetentionPolicy{}
+	_, err = restClient.Get(fmt.Sprintf("/api/v1/retentions/%s", url.PathEscape(namespace)), &policy, target)
+	if err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving the retention policy of namespace (%s): %s", namespace, err)
+	}
+
+	d.Set("namespace", namespace)
+	d.Set("images_per_repo", policy.ImagesPerRepo)
+	d.Set("retain_untagged", policy.RetainUntagged)
+	return nil
+}
+
+func resourceIBMCrRetentionPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("images_per_repo") || d.HasChange("retain_untagged") {
+		policy := crRetentionPolicy{
+			Namespace:      d.Id(),
+			ImagesPerRepo:  d.Get("images_per_repo").(int),
+			RetainUntagged: d.Get("retain_untagged").(bool),
+		}
+		if err := setCrRetentionPolicy(meta, policy); err != nil {
+			return fmt.Errorf("Error setting the retention policy of namespace (%s): %s", d.Id(), err)
+		}
+	}
+	return resourceIBMCrRetentionPolicyRead(d, meta)
+}
+
+func resourceIBMCrRetentionPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	// A namespace always has a retention policy, the default policy retains all images
+	policy := crRetentionPolicy{
+		Namespace:      d.Id(),
+		ImagesPerRepo:  -1,
+		RetainUntagged: true,
+	}
+	if err := setCrRetentionPolicy(meta, policy); err != nil {
+		if apiErr, ok := err.(bmxerror.RequestFailure); ok && apiErr.StatusCode() == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error resetting the retention policy of namespace (%s): %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func setCrRetentionPolicy(meta interface{}, policy crRetentionPolicy) error {
+	restClient, target, err := getContainerRegistryRESTClient(meta)
+	if err != nil {
+		return err
+	}
+	_, err = restClient.Post("/api/v1/retentions", policy, nil, target)
+	return err
+}
+
+// getContainerRegistryRESTClient returns the REST client of the Container Registry API with the
+// account header for endpoints that the SDK does not cover
+func getContainerRegistryRESTClient(meta interface{}) (containerRESTClient, map[string]string, error) {
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		return nil, nil, err
+	}
+	crClient, err := meta.(ClientSession).ContainerRegistryAPI()
+	if err != nil {
+		return nil, nil, err
+	}
+	restClient, ok := crClient.(containerRESTClient)
+	if !ok {
+		return nil, nil, fmt.Errorf("The container registry API does not expose its REST client")
+	}
+	target := map[string]string{
+		"Account": userDetails.userAccount,
+	}
+	return restClient, target, nil
+}
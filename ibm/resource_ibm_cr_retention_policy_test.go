@@ -0,0 +1,60 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIBMCrRetentionPolicyBasic(t *testing.T) {
+	namespaceName := fmt.Sprintf("terraform-tf-%d", acctest.RandIntRange(10, 100))
+	resourceName := "ibm_cr_retention_policy.policy"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMCrNamespaceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMCrRetentionPolicyBasic(namespaceName, 10, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						resourceName, "namespace", namespaceName),
+					resource.TestCheckResourceAttr(
+						resourceName, "images_per_repo", "10"),
+					resource.TestCheckResourceAttr(
+						resourceName, "retain_untagged", "false"),
+				),
+			},
+			{
+				Config: testAccCheckIBMCrRetentionPolicyBasic(namespaceName, 5, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						resourceName, "images_per_repo", "5"),
+					resource.TestCheckResourceAttr(
+						resourceName, "retain_untagged", "true"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckIBMCrRetentionPolicyBasic(namespaceName string, imagesPerRepo int, retainUntagged bool) string {
+	return testAccCheckIBMCrNamespaceBasic(namespaceName) + fmt.Sprintf(`
+	resource "ibm_cr_retention_policy" "policy" {
+		namespace       = ibm_cr_namespace.test_namespace.id
+		images_per_repo = %d
+		retain_untagged = %t
+	}
+`, imagesPerRepo, retainUntagged)
+}
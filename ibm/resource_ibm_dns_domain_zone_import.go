@@ -0,0 +1,183 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+func resourceIBMDNSDomainZoneImport() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIBMDNSDomainZoneImportCreate,
+		Read:   resourceIBMDNSDomainZoneImportRead,
+		Delete: resourceIBMDNSDomainZoneImportDelete,
+		Exists: resourceIBMDNSDomainZoneImportExists,
+
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the DNS domain to import the records into",
+			},
+			"file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Path of the BIND zone file to import",
+			},
+			"include_apex_ns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Import the NS records of the domain apex, which are managed by the DNS service by default",
+			},
+			"records_parsed": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of records parsed from the zone file",
+			},
+			"records_added": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of records added to the domain",
+			},
+			"record_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "IDs of the records added to the domain",
+			},
+		},
+	}
+}
+
+func resourceIBMDNSDomainZoneImportCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	domainID := d.Get("domain_id").(int)
+	file := d.Get("file").(string)
+
+	zone, err := ioutil.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("Error reading zone file %s: %s", file, err)
+	}
+
+	domain, err := services.GetDnsDomainService(sess).Id(domainID).Mask("id,name").GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Dns Domain %d: %s", domainID, err)
+	}
+
+	records, err := parseDNSZoneFile(string(zone), sl.Get(domain.Name, "").(string))
+	if err != nil {
+		return fmt.Errorf("Error parsing zone file %s: %s", file, err)
+	}
+
+	recordIDs := make([]int, 0, len(records))
+	for _, record := range records {
+		// The SOA record and the apex name servers belong to the DNS service
+		if record["type"] == "soa" || (record["type"] == "ns" && record["host"] == "@" && !d.Get("include_apex_ns").(bool)) {
+			log.Printf("[INFO] Skipping %s record of the domain apex", record["type"])
+			continue
+		}
+		record["domain_id"] = domainID
+		opts := expandDNSRecord(record)
+		id, err := createDNSRecord(sess, opts)
+		if err != nil {
+			// Keep track of the records already added, so that they are removed with the resource
+			if len(recordIDs) > 0 {
+				d.SetId(strconv.Itoa(domainID))
+				d.Set("record_ids", recordIDs)
+			}
+			return fmt.Errorf("Error creating DNS Resource %s Record %s: %s", *opts.Type, *opts.Host, err)
+		}
+		recordIDs = append(recordIDs, id)
+	}
+
+	d.SetId(strconv.Itoa(domainID))
+	d.Set("records_parsed", len(records))
+	d.Set("records_added", len(recordIDs))
+	d.Set("record_ids", recordIDs)
+	log.Printf("[INFO] Imported %d of %d records into Dns Domain %d", len(recordIDs), len(records), domainID)
+
+	return resourceIBMDNSDomainZoneImportRead(d, meta)
+}
+
+func resourceIBMDNSDomainZoneImportRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+
+	domainID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	records, err := services.GetDnsDomainService(sess).Id(domainID).Mask("id").GetResourceRecords()
+	if err != nil {
+		return fmt.Errorf("Error retrieving resource records of Dns Domain %d: %s", domainID, err)
+	}
+	existing := make(map[int]bool, len(records))
+	for _, record := range records {
+		if record.Id != nil {
+			existing[*record.Id] = true
+		}
+	}
+
+	// Records removed outside of terraform are no longer managed by the import
+	recordIDs := []int{}
+	for _, id := range d.Get("record_ids").([]interface{}) {
+		if existing[id.(int)] {
+			recordIDs = append(recordIDs, id.(int))
+		}
+	}
+
+	d.Set("domain_id", domainID)
+	d.Set("record_ids", recordIDs)
+
+	return nil
+}
+
+func resourceIBMDNSDomainZoneImportDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetDnsDomainResourceRecordService(sess)
+
+	for _, id := range d.Get("record_ids").([]interface{}) {
+		_, err := service.Id(id.(int)).DeleteObject()
+		if err != nil {
+			if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+				continue
+			}
+			return fmt.Errorf("Error deleting DNS Resource Record %d: %s", id.(int), err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMDNSDomainZoneImportExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ClientSession).SoftLayerSession()
+
+	domainID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	result, err := services.GetDnsDomainService(sess).Id(domainID).GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok {
+			if apiErr.StatusCode == 404 {
+				return false, nil
+			}
+		}
+		return false, fmt.Errorf("Error retrieving domain info: %s", err)
+	}
+	return result.Id != nil && *result.Id == domainID, nil
+}
@@ -0,0 +1,172 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/sl"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+const testDNSZoneFile = `$ORIGIN example.com.
+$TTL 1h
+@	IN	SOA	ns1.softlayer.com. root.softlayer.com. (
+		2021010101 ; serial
+		7200       ; refresh
+		600        ; retry
+		1728000    ; expire
+		43200 )    ; minimum
+	IN	NS	ns1.softlayer.com.
+www	300	IN	A	10.0.0.1
+	IN	AAAA	2001:db8::1
+ftp	IN	CNAME	www
+@	IN 300	MX	10 mail
+txt		TXT	"v=spf1 include:example.net" " -all" ; comment
+_sip._tcp.voip	IN	SRV	10 20 5060 sip.example.net.
+`
+
+func TestParseDNSZoneFile(t *testing.T) {
+	records, err := parseDNSZoneFile(testDNSZoneFile, "example.com")
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(records, 8))
+
+	assert.Equal(t, "soa", records[0]["type"])
+	assert.Equal(t, "ns1.softlayer.com.", records[0]["data"])
+	assert.Equal(t, "root.softlayer.com.", records[0]["responsible_person"])
+	assert.Equal(t, 7200, records[0]["refresh"])
+	assert.Equal(t, 43200, records[0]["minimum_ttl"])
+
+	assert.Equal(t, "@", records[1]["host"])
+	assert.Equal(t, "ns", records[1]["type"])
+	assert.Equal(t, 3600, records[1]["ttl"])
+
+	assert.DeepEqual(t, dnsZoneRecord{"host": "www", "ttl": 300, "type": "a", "data": "10.0.0.1",
+		"mx_priority": 0, "priority": 0, "weight": 0}, records[2])
+	assert.Equal(t, "www", records[3]["host"])
+	assert.Equal(t, "www.example.com.", records[4]["data"])
+	assert.Equal(t, 10, records[5]["mx_priority"])
+	assert.Equal(t, 300, records[5]["ttl"])
+	assert.Equal(t, "mail.example.com.", records[5]["data"])
+	assert.Equal(t, "v=spf1 include:example.net -all", records[6]["data"])
+
+	srv := records[7]
+	assert.Equal(t, "_sip", srv["service"])
+	assert.Equal(t, "_tcp", srv["protocol"])
+	assert.Equal(t, "voip", srv["host"])
+	assert.Equal(t, 5060, srv["port"])
+
+	srv["domain_id"] = 1
+	opts := expandDNSRecord(srv)
+	assert.Equal(t, "_sip", *opts.Service)
+	assert.Equal(t, 20, *opts.Weight)
+}
+
+func TestParseDNSZoneFileBlankOwner(t *testing.T) {
+	// A record without owner on the first line belongs to the domain
+	records, err := parseDNSZoneFile("\tIN A 10.0.0.1\n", "example.com")
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(records, 1))
+	assert.Equal(t, "@", records[0]["host"])
+}
+
+func TestParseDNSZoneFileErrors(t *testing.T) {
+	_, err := parseDNSZoneFile("$INCLUDE other.zone\n", "example.com")
+	assert.Assert(t, err != nil)
+	_, err = parseDNSZoneFile("www IN HINFO PC Linux\n", "example.com")
+	assert.Assert(t, err != nil)
+	_, err = parseDNSZoneFile("www.example.net. IN A 10.0.0.1\n", "example.com")
+	assert.Assert(t, err != nil)
+	_, err = parseDNSZoneFile("@ IN MX mail.example.com.\n", "example.com")
+	assert.Assert(t, err != nil)
+}
+
+func TestFormatDNSZoneRecord(t *testing.T) {
+	records, err := parseDNSZoneFile(testDNSZoneFile, "example.com")
+	assert.NilError(t, err)
+
+	lines := []string{}
+	for _, record := range records {
+		record["domain_id"] = 1
+		opts := expandDNSRecord(record)
+		opts.Dns_Domain_ResourceRecord.Service = opts.Service
+		opts.Dns_Domain_ResourceRecord.Protocol = opts.Protocol
+		opts.Dns_Domain_ResourceRecord.Priority = opts.Priority
+		opts.Dns_Domain_ResourceRecord.Weight = opts.Weight
+		opts.Dns_Domain_ResourceRecord.Port = opts.Port
+		lines = append(lines, formatDNSZoneRecord(opts.Dns_Domain_ResourceRecord, "2021010101"))
+	}
+	assert.Equal(t, "@\t3600\tIN\tSOA\tns1.softlayer.com. root.softlayer.com. ( 2021010101 7200 600 1728000 43200 )", lines[0])
+	assert.Equal(t, "@\t300\tIN\tMX\t10 mail.example.com.", lines[5])
+	assert.Equal(t, "txt\t3600\tIN\tTXT\t\"v=spf1 include:example.net -all\"", lines[6])
+	assert.Equal(t, "_sip._tcp.voip\t3600\tIN\tSRV\t10 20 5060 sip.example.net.", lines[7])
+
+	// The rendered zone parses back to the same records
+	reparsed, err := parseDNSZoneFile("$ORIGIN example.com.\n"+strings.Join(lines, "\n"), "example.com")
+	assert.NilError(t, err)
+	for i := range reparsed {
+		reparsed[i]["domain_id"] = 1
+	}
+	assert.DeepEqual(t, records, reparsed)
+
+	long := strings.Repeat("a", 300)
+	text := formatDNSZoneRecord(datatypes.Dns_Domain_ResourceRecord{
+		Host: sl.String("@"),
+		Ttl:  sl.Int(60),
+		Type: sl.String("txt"),
+		Data: sl.String(long),
+	}, "1")
+	assert.Equal(t, fmt.Sprintf("@\t60\tIN\tTXT\t%q %q", long[:255], long[255:]), text)
+}
+
+func TestAccIBMDNSDomainZoneImport_Basic(t *testing.T) {
+	domainName := fmt.Sprintf("tfuatdomainz%s.ibm.com", acctest.RandString(10))
+	zoneFile, err := ioutil.TempFile("", "zone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(zoneFile.Name())
+	zone := strings.Replace(testDNSZoneFile, "example.com.", domainName+".", 1)
+	if _, err := zoneFile.WriteString(zone); err != nil {
+		t.Fatal(err)
+	}
+	zoneFile.Close()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMDNSDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMDNSDomainZoneImportConfig(domainName, zoneFile.Name()),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_dns_domain_zone_import.import", "records_parsed", "8"),
+					resource.TestCheckResourceAttr("ibm_dns_domain_zone_import.import", "records_added", "6"),
+					resource.TestCheckResourceAttr("ibm_dns_domain_zone_import.import", "record_ids.#", "6"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMDNSDomainZoneImportConfig(domainName, file string) string {
+	return fmt.Sprintf(`
+resource "ibm_dns_domain" "zone_import" {
+	name = "%s"
+}
+
+resource "ibm_dns_domain_zone_import" "import" {
+	domain_id = ibm_dns_domain.zone_import.id
+	file      = "%s"
+}
+`, domainName, file)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
 	"github.com/softlayer/softlayer-go/sl"
 )
 
@@ -192,8 +193,26 @@ func resourceIBMDNSRecord() *schema.Resource {
 //  https://sldn.softlayer.com/reference/services/SoftLayer_Dns_Domain_ResourceRecord/createObject
 func resourceIBMDNSRecordCreate(d *schema.ResourceData, meta interface{}) error {
 	sess := meta.(ClientSession).SoftLayerSession()
-	service := services.GetDnsDomainResourceRecordService(sess.SetRetries(0))
 
+	opts := expandDNSRecord(d)
+
+	log.Printf("[INFO] Creating DNS Resource %s Record for '%d' dns domain", *opts.Type, d.Get("domain_id").(int))
+
+	id, err := createDNSRecord(sess, opts)
+	if err != nil {
+		return fmt.Errorf("Error creating DNS Resource %s Record: %s", *opts.Type, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d", id))
+
+	log.Printf("[INFO] Dns Resource %s Record ID: %s", *opts.Type, d.Id())
+
+	return resourceIBMDNSRecordRead(d, meta)
+}
+
+// expandDNSRecord builds the record from the resource arguments. The SRV specific
+// fields are only set for srv records.
+func expandDNSRecord(d dataRetriever) datatypes.Dns_Domain_ResourceRecord_SrvType {
 	opts := datatypes.Dns_Domain_ResourceRecord{
 		Data:     sl.String(d.Get("data").(string)),
 		DomainId: sl.Int(d.Get("domain_id").(int)),
@@ -246,11 +265,14 @@ func resourceIBMDNSRecordCreate(d *schema.ResourceData, meta interface{}) error
 		}
 	}
 
-	log.Printf("[INFO] Creating DNS Resource %s Record for '%d' dns domain", *opts.Type, d.Get("domain_id").(int))
+	return optsSrv
+}
 
+// createDNSRecord creates the record with the service matching its type and returns its ID
+func createDNSRecord(sess *session.Session, optsSrv datatypes.Dns_Domain_ResourceRecord_SrvType) (int, error) {
 	var err error
 	var id int
-	if *opts.Type == "srv" {
+	if *optsSrv.Type == "srv" {
 		var record datatypes.Dns_Domain_ResourceRecord_SrvType
 		serviceSrv := services.GetDnsDomainResourceRecordSrvTypeService(sess)
 		record, err = serviceSrv.CreateObject(&optsSrv)
@@ -259,21 +281,13 @@ func resourceIBMDNSRecordCreate(d *schema.ResourceData, meta interface{}) error
 		}
 	} else {
 		var record datatypes.Dns_Domain_ResourceRecord
-		record, err = service.CreateObject(&opts)
+		service := services.GetDnsDomainResourceRecordService(sess.SetRetries(0))
+		record, err = service.CreateObject(&optsSrv.Dns_Domain_ResourceRecord)
 		if record.Id != nil {
 			id = *record.Id
 		}
 	}
-
-	if err != nil {
-		return fmt.Errorf("Error creating DNS Resource %s Record: %s", *opts.Type, err)
-	}
-
-	d.SetId(fmt.Sprintf("%d", id))
-
-	log.Printf("[INFO] Dns Resource %s Record ID: %s", *opts.Type, d.Id())
-
-	return resourceIBMDNSRecordRead(d, meta)
+	return id, err
 }
 
 //  Reads DNS Domain Resource Record from SL system
@@ -95,6 +95,13 @@ func resourceIBMFirewallPolicy() *schema.Resource {
 				},
 			},
 
+			"exclusive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the policy owns every rule of the firewall. When false, rules that are not declared are kept after the declared rules",
+			},
+
 			"tags": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -162,7 +169,10 @@ func resourceIBMFirewallPolicyCreate(d *schema.ResourceData, meta interface{}) e
 	sess := meta.(ClientSession).SoftLayerSession()
 
 	fwId := d.Get("firewall_id").(int)
-	rules := prepareRules(d)
+	rules, err := prepareFirewallPolicyRules(d, sess, fwId, nil)
+	if err != nil {
+		return fmt.Errorf("Error during creation of dedicated hardware firewall rules: %s", err)
+	}
 
 	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, sess)
 	if err != nil {
@@ -204,24 +214,17 @@ func resourceIBMFirewallPolicyRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error retrieving firewall rules: %s", err)
 	}
 
+	declared := map[string]bool{}
+	for _, r := range d.Get("rules").([]interface{}) {
+		declared[firewallRuleKey(r.(map[string]interface{}))] = true
+	}
+
 	rules := make([]map[string]interface{}, 0, len(fw.Rules))
 	for _, rule := range fw.Rules {
-		r := make(map[string]interface{})
-		r["action"] = *rule.Action
-		r["src_ip_address"] = *rule.SourceIpAddress
-		r["src_ip_cidr"] = *rule.SourceIpCidr
-		r["dst_ip_address"] = *rule.DestinationIpAddress
-		r["dst_ip_cidr"] = *rule.DestinationIpCidr
-		if rule.DestinationPortRangeStart != nil {
-			r["dst_port_range_start"] = *rule.DestinationPortRangeStart
-		}
-		if rule.DestinationPortRangeEnd != nil {
-			r["dst_port_range_end"] = *rule.DestinationPortRangeEnd
-		}
-		r["protocol"] = *rule.Protocol
-		//Check if notes is not nil
-		if rule.Notes != nil {
-			r["notes"] = *rule.Notes
+		r := flattenFirewallRule(rule)
+		// Without exclusive ownership, only the declared rules are tracked
+		if !d.Get("exclusive").(bool) && !declared[firewallRuleKey(r)] {
+			continue
 		}
 		rules = append(rules, r)
 	}
@@ -232,6 +235,93 @@ func resourceIBMFirewallPolicyRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
+func flattenFirewallRule(rule datatypes.Network_Vlan_Firewall_Rule) map[string]interface{} {
+	r := make(map[string]interface{})
+	r["action"] = *rule.Action
+	r["src_ip_address"] = *rule.SourceIpAddress
+	r["src_ip_cidr"] = *rule.SourceIpCidr
+	r["dst_ip_address"] = *rule.DestinationIpAddress
+	r["dst_ip_cidr"] = *rule.DestinationIpCidr
+	if rule.DestinationPortRangeStart != nil {
+		r["dst_port_range_start"] = *rule.DestinationPortRangeStart
+	}
+	if rule.DestinationPortRangeEnd != nil {
+		r["dst_port_range_end"] = *rule.DestinationPortRangeEnd
+	}
+	r["protocol"] = *rule.Protocol
+	//Check if notes is not nil
+	if rule.Notes != nil {
+		r["notes"] = *rule.Notes
+	}
+	return r
+}
+
+// firewallRuleKey identifies a rule by what it matches and does, notes and order are ignored
+func firewallRuleKey(r map[string]interface{}) string {
+	ipAddress := func(v interface{}) string {
+		if ip := net.ParseIP(v.(string)); ip != nil {
+			return ip.String()
+		}
+		return v.(string)
+	}
+	port := func(v interface{}) int {
+		if v == nil {
+			return 0
+		}
+		return v.(int)
+	}
+	return fmt.Sprintf("%s-%s/%d-%s/%d-%d-%d-%s", r["action"], ipAddress(r["src_ip_address"]), r["src_ip_cidr"],
+		ipAddress(r["dst_ip_address"]), r["dst_ip_cidr"], port(r["dst_port_range_start"]), port(r["dst_port_range_end"]),
+		r["protocol"])
+}
+
+// prepareFirewallPolicyRules returns the rules of the update request. Without exclusive ownership, the
+// rules of the firewall that are not managed by the policy are kept after the declared rules. Rules in
+// previous are the rules the policy managed before, they are removed when they are no longer declared.
+func prepareFirewallPolicyRules(d *schema.ResourceData, sess *session.Session, fwId int, previous []interface{}) ([]datatypes.Network_Firewall_Update_Request_Rule, error) {
+	rules := prepareRules(d)
+	if d.Get("exclusive").(bool) {
+		return rules, nil
+	}
+	return appendUnmanagedFirewallRules(rules, sess, fwId, append(d.Get("rules").([]interface{}), previous...))
+}
+
+// appendUnmanagedFirewallRules appends the rules of the firewall that do not match any of the managed rules
+func appendUnmanagedFirewallRules(rules []datatypes.Network_Firewall_Update_Request_Rule, sess *session.Session, fwId int, managedRules []interface{}) ([]datatypes.Network_Firewall_Update_Request_Rule, error) {
+	managed := map[string]bool{}
+	for _, r := range managedRules {
+		managed[firewallRuleKey(r.(map[string]interface{}))] = true
+	}
+
+	current, err := services.GetNetworkVlanFirewallService(sess).Id(fwId).GetRules()
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range current {
+		// The any open rules left by a deleted policy are not kept
+		if rule.Notes != nil && strings.HasPrefix(*rule.Notes, "terraform-default-anyopen-") {
+			continue
+		}
+		if managed[firewallRuleKey(flattenFirewallRule(rule))] {
+			continue
+		}
+		rules = append(rules, datatypes.Network_Firewall_Update_Request_Rule{
+			OrderValue:                sl.Int(len(rules) + 1),
+			Action:                    rule.Action,
+			SourceIpAddress:           rule.SourceIpAddress,
+			SourceIpCidr:              rule.SourceIpCidr,
+			DestinationIpAddress:      rule.DestinationIpAddress,
+			DestinationIpCidr:         rule.DestinationIpCidr,
+			DestinationPortRangeStart: rule.DestinationPortRangeStart,
+			DestinationPortRangeEnd:   rule.DestinationPortRangeEnd,
+			Protocol:                  rule.Protocol,
+			Notes:                     rule.Notes,
+			Version:                   rule.Version,
+		})
+	}
+	return rules, nil
+}
+
 func appendAnyOpenRule(rules []datatypes.Network_Firewall_Update_Request_Rule, protocol string) []datatypes.Network_Firewall_Update_Request_Rule {
 	ruleAnyOpen := datatypes.Network_Firewall_Update_Request_Rule{
 		OrderValue:                sl.Int(len(rules) + 1),
@@ -265,7 +355,15 @@ func resourceIBMFirewallPolicyUpdate(d *schema.ResourceData, meta interface{}) e
 	if err != nil {
 		return fmt.Errorf("Not a valid firewall ID, must be an integer: %s", err)
 	}
-	rules := prepareRules(d)
+	// Enabling exclusive ownership needs no request, the rules that are not declared show up as drift
+	if !d.HasChange("rules") {
+		return resourceIBMFirewallPolicyRead(d, meta)
+	}
+	oldRules, _ := d.GetChange("rules")
+	rules, err := prepareFirewallPolicyRules(d, sess, fwId, oldRules.([]interface{}))
+	if err != nil {
+		return fmt.Errorf("Error during updating of dedicated hardware firewall rules: %s", err)
+	}
 
 	fwContextACLId, err := getFirewallContextAccessControlListId(fwId, sess)
 	if err != nil {
@@ -304,6 +402,25 @@ func resourceIBMFirewallPolicyDelete(d *schema.ResourceData, meta interface{}) e
 		FirewallContextAccessControlListId: sl.Int(fwContextACLId),
 	}
 
+	// Without exclusive ownership, only the declared rules are removed
+	if !d.Get("exclusive").(bool) {
+		ruleTemplate.Rules, err = appendUnmanagedFirewallRules(nil, sess, fwId, d.Get("rules").([]interface{}))
+		if err != nil {
+			return fmt.Errorf("Error during deleting of dedicated hardware firewall rules: %s", err)
+		}
+	}
+	if len(ruleTemplate.Rules) > 0 {
+		log.Println("[INFO] Deleting dedicated hardware firewall rules")
+
+		_, err = services.GetNetworkFirewallUpdateRequestService(sess.SetRetries(0)).CreateObject(&ruleTemplate)
+		if err != nil {
+			return fmt.Errorf("Error during deleting of dedicated hardware firewall rules: %s", err)
+		}
+		time.Sleep(time.Minute)
+
+		return nil
+	}
+
 	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "tcp")
 	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "udp")
 	ruleTemplate.Rules = appendAnyOpenRule(ruleTemplate.Rules, "icmp")
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"gotest.tools/assert"
 )
 
 func TestAccIBMFirewallPolicy_Basic(t *testing.T) {
@@ -340,3 +341,19 @@ resource "ibm_firewall_policy" "rules" {
 
 `, hostname)
 }
+
+func TestFirewallRuleKey(t *testing.T) {
+	declared := map[string]interface{}{
+		"action": "permit", "src_ip_address": "2401:c900:1501:0032:0000:0000:0000:0000", "src_ip_cidr": 64,
+		"dst_ip_address": "any", "dst_ip_cidr": 0, "dst_port_range_start": 0, "dst_port_range_end": 0,
+		"protocol": "icmp", "notes": "declared",
+	}
+	read := map[string]interface{}{
+		"action": "permit", "src_ip_address": "2401:c900:1501:32::", "src_ip_cidr": 64,
+		"dst_ip_address": "any", "dst_ip_cidr": 0, "protocol": "icmp",
+	}
+	assert.Equal(t, firewallRuleKey(declared), firewallRuleKey(read))
+
+	read["action"] = "deny"
+	assert.Assert(t, firewallRuleKey(declared) != firewallRuleKey(read))
+}
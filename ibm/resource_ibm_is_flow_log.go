@@ -10,6 +10,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/IBM-Cloud/bluemix-go/api/iampap/iampapv1"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -30,6 +31,11 @@ const (
 	isFlowLogAutoDelete            = "auto_delete"
 	isFlowLogVpc                   = "vpc"
 	isFlowLogTags                  = "tags"
+	isFlowLogCreateAuthorization   = "create_authorization"
+	isFlowLogAuthorizationPolicy   = "authorization_policy"
+	isFlowLogHealthReasons         = "health_reasons"
+	isFlowLogHealthReasonCode      = "code"
+	isFlowLogHealthReasonMessage   = "message"
 )
 
 func resourceIBMISFlowLog() *schema.Resource {
@@ -51,6 +57,9 @@ func resourceIBMISFlowLog() *schema.Resource {
 			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
 				return resourceTagsCustomizeDiff(diff)
 			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceFlowLogAuthorizationCustomizeDiff(diff, v)
+			},
 		),
 
 		Schema: map[string]*schema.Schema{
@@ -127,6 +136,39 @@ func resourceIBMISFlowLog() *schema.Resource {
 				Description: "The lifecycle state of the flow log collector",
 			},
 
+			isFlowLogCreateAuthorization: {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If set to true, a Writer authorization from is.flow-log-collector to cloud-object-storage is created when it does not exist",
+			},
+
+			isFlowLogAuthorizationPolicy: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the authorization policy that allows the flow log collector to write to the storage bucket",
+			},
+
+			isFlowLogHealthReasons: {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The reasons why the flow log collector might not be producing flow logs",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						isFlowLogHealthReasonCode: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "A snake case string succinctly identifying the reason",
+						},
+						isFlowLogHealthReasonMessage: {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "An explanation of the reason",
+						},
+					},
+				},
+			},
+
 			isFlowLogTags: {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -222,6 +264,12 @@ func resourceIBMISFlowLogCreate(d *schema.ResourceData, meta interface{}) error
 	FlowLogCollectorTargetModel.ID = &target
 	createFlowLogCollectorOptionsModel.Target = FlowLogCollectorTargetModel
 
+	policyID, err := ensureFlowLogAuthorizationPolicy(meta, d.Get(isFlowLogCreateAuthorization).(bool))
+	if err != nil {
+		return err
+	}
+	d.Set(isFlowLogAuthorizationPolicy, policyID)
+
 	bucketname := d.Get(isFlowLogStorageBucket).(string)
 	cloudObjectStorageBucketIdentityModel := new(vpcv1.CloudObjectStorageBucketIdentityByName)
 	cloudObjectStorageBucketIdentityModel.Name = &bucketname
@@ -304,6 +352,14 @@ func resourceIBMISFlowLogRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set(isFlowLogStorageBucket, *bucket.Name)
 	}
 
+	policyID, err := findFlowLogAuthorizationPolicy(meta)
+	if err != nil {
+		log.Printf(
+			"[WARN] Error on get of resource vpc flow log (%s) authorization policy: %s", d.Id(), err)
+	}
+	d.Set(isFlowLogAuthorizationPolicy, policyID)
+	d.Set(isFlowLogHealthReasons, flattenFlowLogHealthReasons(flowlogCollector, err == nil && policyID == ""))
+
 	tags, err := GetTagsUsingCRN(meta, *flowlogCollector.CRN)
 	if err != nil {
 		log.Printf(
@@ -419,3 +475,162 @@ func resourceIBMISFlowLogExists(d *schema.ResourceData, meta interface{}) (bool,
 	}
 	return true, nil
 }
+
+// resourceFlowLogAuthorizationCustomizeDiff fails the plan of a new flow log collector that could not
+// write to the bucket because no authorization policy exists and create_authorization is not set
+func resourceFlowLogAuthorizationCustomizeDiff(diff *schema.ResourceDiff, meta interface{}) error {
+	if diff.Id() != "" || diff.Get(isFlowLogCreateAuthorization).(bool) {
+		return nil
+	}
+	_, err := ensureFlowLogAuthorizationPolicy(meta, false)
+	return err
+}
+
+// findFlowLogAuthorizationPolicy returns the ID of an authorization policy that lets
+// is.flow-log-collector write to cloud-object-storage, or an empty string if there is none.
+// Policies granted to all resource types of the is service also match.
+func findFlowLogAuthorizationPolicy(meta interface{}) (string, error) {
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		return "", err
+	}
+
+	writerRoles, err := getAuthorizationRolesByName([]string{"Writer"}, "is", "cloud-object-storage", meta)
+	if err != nil {
+		return "", err
+	}
+	writerRoleIDs := make(map[string]bool, len(writerRoles))
+	for _, role := range writerRoles {
+		writerRoleIDs[role.ID.String()] = true
+	}
+
+	iampapClient, err := meta.(ClientSession).IAMPAPAPI()
+	if err != nil {
+		return "", err
+	}
+
+	policies, err := iampapClient.V1Policy().List(iampapv1.SearchParams{
+		AccountID: userDetails.userAccount,
+		Type:      iampapv1.AuthorizationPolicyType,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving authorization policies: %s", err)
+	}
+
+	for _, policy := range policies {
+		if len(policy.Subjects) == 0 || len(policy.Resources) == 0 {
+			continue
+		}
+		source := policy.Subjects[0]
+		target := policy.Resources[0]
+		if source.ServiceName() != "is" || target.ServiceName() != "cloud-object-storage" {
+			continue
+		}
+		if resourceType := source.ResourceType(); resourceType != "" && resourceType != "flow-log-collector" {
+			continue
+		}
+		for _, role := range policy.Roles {
+			if writerRoleIDs[role.RoleID] {
+				return policy.ID, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func ensureFlowLogAuthorizationPolicy(meta interface{}, create bool) (string, error) {
+	policyID, err := findFlowLogAuthorizationPolicy(meta)
+	if err != nil {
+		return "", fmt.Errorf("Error verifying the flow log collector authorization policy: %s", err)
+	}
+	if policyID != "" {
+		return policyID, nil
+	}
+	if !create {
+		return "", fmt.Errorf("No authorization policy grants is.flow-log-collector the Writer role on cloud-object-storage. " +
+			"Create an ibm_iam_authorization_policy with source_service_name \"is\", source_resource_type \"flow-log-collector\", " +
+			"target_service_name \"cloud-object-storage\" and the Writer role, or set create_authorization to true")
+	}
+
+	userDetails, err := meta.(ClientSession).BluemixUserDetails()
+	if err != nil {
+		return "", err
+	}
+
+	iampapClient, err := meta.(ClientSession).IAMPAPAPI()
+	if err != nil {
+		return "", err
+	}
+
+	policy := iampapv1.Policy{
+		Type: iampapv1.AuthorizationPolicyType,
+		Subjects: []iampapv1.Subject{
+			{
+				Attributes: []iampapv1.Attribute{
+					{
+						Name:  "accountId",
+						Value: userDetails.userAccount,
+					},
+					{
+						Name:  "serviceName",
+						Value: "is",
+					},
+				},
+			},
+		},
+		Resources: []iampapv1.Resource{
+			{
+				Attributes: []iampapv1.Attribute{
+					{
+						Name:  "accountId",
+						Value: userDetails.userAccount,
+					},
+					{
+						Name:  "serviceName",
+						Value: "cloud-object-storage",
+					},
+				},
+			},
+		},
+	}
+	policy.Subjects[0].SetResourceType("flow-log-collector")
+
+	roles, err := getAuthorizationRolesByName([]string{"Writer"}, "is", "cloud-object-storage", meta)
+	if err != nil {
+		return "", err
+	}
+	policy.Roles = iampapv1.ConvertRoleModels(roles)
+
+	authPolicy, err := iampapClient.V1Policy().Create(policy)
+	if err != nil {
+		return "", fmt.Errorf("Error creating flow log collector authorization policy: %s", err)
+	}
+	log.Printf("[INFO] Created flow log collector authorization policy : %s", authPolicy.ID)
+	return authPolicy.ID, nil
+}
+
+func flattenFlowLogHealthReasons(flowlogCollector *vpcv1.FlowLogCollector, missingAuthorization bool) []map[string]interface{} {
+	reasons := make([]map[string]interface{}, 0)
+	if missingAuthorization {
+		reasons = append(reasons, map[string]interface{}{
+			isFlowLogHealthReasonCode:    "missing_authorization",
+			isFlowLogHealthReasonMessage: "No authorization policy grants is.flow-log-collector the Writer role on cloud-object-storage",
+		})
+	}
+	if flowlogCollector.Active != nil && !*flowlogCollector.Active {
+		reasons = append(reasons, map[string]interface{}{
+			isFlowLogHealthReasonCode:    "collector_inactive",
+			isFlowLogHealthReasonMessage: "The flow log collector is not active",
+		})
+	}
+	if flowlogCollector.LifecycleState != nil {
+		switch *flowlogCollector.LifecycleState {
+		case vpcv1.FlowLogCollectorLifecycleStateFailedConst, vpcv1.FlowLogCollectorLifecycleStateSuspendedConst:
+			reasons = append(reasons, map[string]interface{}{
+				isFlowLogHealthReasonCode:    "collector_" + *flowlogCollector.LifecycleState,
+				isFlowLogHealthReasonMessage: fmt.Sprintf("The flow log collector is %s, check that the storage bucket exists and is writable", *flowlogCollector.LifecycleState),
+			})
+		}
+	}
+	return reasons
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -42,19 +43,22 @@ ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVE
 
 			resource.TestStep{
 				//Create test case
-				Config: testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass, true),
+				Config: testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass, true, true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckIBMISFlowLogExists("ibm_is_flow_log.test_flow_log", instance),
 					resource.TestCheckResourceAttr("ibm_is_flow_log.test_flow_log", "name", flowlogname),
+					resource.TestCheckResourceAttrSet("ibm_is_flow_log.test_flow_log", "authorization_policy"),
+					resource.TestCheckResourceAttr("ibm_is_flow_log.test_flow_log", "health_reasons.#", "0"),
 				),
 			},
 			//update
 			resource.TestStep{
-				Config: testAccCheckIBMISFlowLogConfig(vpcname, name, newflowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass, false),
+				Config: testAccCheckIBMISFlowLogConfig(vpcname, name, newflowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass, false, true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckIBMISFlowLogExists("ibm_is_flow_log.test_flow_log", instance),
 					resource.TestCheckResourceAttr("ibm_is_flow_log.test_flow_log", "name", newflowlogname),
 					resource.TestCheckResourceAttr("ibm_is_flow_log.test_flow_log", "active", "false"),
+					resource.TestCheckResourceAttr("ibm_is_flow_log.test_flow_log", "health_reasons.0.code", "collector_inactive"),
 				),
 			},
 		},
@@ -62,7 +66,34 @@ ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVE
 	)
 }
 
-func testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass string, isActive bool) string {
+// TestAccIBMISFlowLog_missingAuthorization needs an account without an authorization policy that
+// grants is.flow-log-collector the Writer role on cloud-object-storage
+func TestAccIBMISFlowLog_missingAuthorization(t *testing.T) {
+	vpcname := fmt.Sprintf("flowlog-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("resource-instance-%d", acctest.RandIntRange(10, 100))
+	flowlogname := fmt.Sprintf("flowlog-instance-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("flowlog-subnet-%d", acctest.RandIntRange(10, 100))
+	publicKey := strings.TrimSpace(`
+ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVERRN7/9484SOBJ3HSKxxNG5JN8owAjy5f9yYwcUg+JaUVuytn5Pv3aeYROHGGg+5G346xaq3DAwX6Y5ykr2fvjObgncQBnuU5KHWCECO/4h8uWuwh/kfniXPVjFToc+gnkqA+3RKpAecZhFXwfalQ9mMuYGFxn+fwn8cYEApsJbsEmb0iJwPiZ5hjFC8wREuiTlhPHDgkBLOiycd20op2nXzDbHfCHInquEe/gYxEitALONxm0swBOwJZwlTDOB7C6y2dzlrtxr1L59m7pCkWI4EtTRLvleehBoj3u7jB4usR
+`)
+	sshname := fmt.Sprintf("tf-ssh-%d", acctest.RandIntRange(10, 100))
+	serviceName := fmt.Sprintf("terraform_%d", acctest.RandIntRange(10, 100))
+	bucketName := fmt.Sprintf("terraform%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				// The plan fails before anything is created
+				Config:      testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicKey, subnetname, serviceName, bucketName, "cross_region_location", "us-south", "standard", true, false),
+				ExpectError: regexp.MustCompile("No authorization policy grants is.flow-log-collector the Writer role"),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass string, isActive, createAuthorization bool) string {
 	return fmt.Sprintf(`	  	
 	
 	resource "ibm_is_vpc" "testacc_vpc" {
@@ -118,9 +149,10 @@ func testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicK
 		target = ibm_is_instance.testacc_instance.id
 		storage_bucket = ibm_cos_bucket.bucket2.bucket_name
 		active = %v
+		create_authorization = %v
 	  } 
 	  
-	  `, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName, serviceName, bucketName, bucketRegion, bucketClass, flowlogname, isActive)
+	  `, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName, serviceName, bucketName, bucketRegion, bucketClass, flowlogname, isActive, createAuthorization)
 
 }
 func testAccCheckIBMISFlowLogDestroy(s *terraform.State) error {
@@ -192,7 +224,7 @@ ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVE
 		CheckDestroy: testAccCheckIBMISFlowLogDestroy,
 		Steps: []resource.TestStep{
 			resource.TestStep{
-				Config: testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass, false),
+				Config: testAccCheckIBMISFlowLogConfig(vpcname, name, flowlogname, sshname, publicKey, subnetname, serviceName, bucketName, bucketRegionType, bucketRegion, bucketClass, false, true),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckIBMISFlowLogExists("ibm_is_flow_log.test_flow_log", instance),
 					resource.TestCheckResourceAttr("ibm_is_flow_log.test_flow_log", "name", flowlogname),
@@ -5,11 +5,14 @@ package ibm
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcclassicv1"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
@@ -29,6 +32,10 @@ const (
 	isInstanceNicAllowIPSpoofing      = "allow_ip_spoofing"
 	isInstanceNicPrimaryIpv4Address   = "primary_ipv4_address"
 	isInstanceNicPrimaryIpv6Address   = "primary_ipv6_address"
+	isInstanceNicPrimaryIP            = "primary_ip"
+	isInstanceNicReservedIP           = "reserved_ip"
+	isInstanceNicReservedIPAddress    = "address"
+	isInstanceNicReservedIPName       = "name"
 	isInstanceNicSecondaryAddress     = "secondary_addresses"
 	isInstanceNicSecurityGroups       = "security_groups"
 	isInstanceNicSubnet               = "subnet"
@@ -229,6 +236,7 @@ func resourceIBMISInstance() *schema.Resource {
 							Optional: true,
 							Computed: true,
 						},
+						isInstanceNicPrimaryIP: instanceNicPrimaryIPSchema(),
 						isInstanceNicSecurityGroups: {
 							Type:     schema.TypeSet,
 							Optional: true,
@@ -271,6 +279,7 @@ func resourceIBMISInstance() *schema.Resource {
 							Optional: true,
 							Computed: true,
 						},
+						isInstanceNicPrimaryIP: instanceNicPrimaryIPSchema(),
 						isInstanceNicSecurityGroups: {
 							Type:     schema.TypeSet,
 							Optional: true,
@@ -712,6 +721,8 @@ func instanceCreate(d *schema.ResourceData, meta interface{}, profile, name, vpc
 		}
 	}
 
+	primaryIP := ""
+	nicPrimaryIPs := []string{}
 	if primnicintf, ok := d.GetOk(isInstancePrimaryNetworkInterface); ok {
 		primnic := primnicintf.([]interface{})[0].(map[string]interface{})
 		subnetintf, _ := primnic[isInstanceNicSubnet]
@@ -730,6 +741,10 @@ func instanceCreate(d *schema.ResourceData, meta interface{}, profile, name, vpc
 		if ipv4str != "" {
 			primnicobj.PrimaryIpv4Address = &ipv4str
 		}
+		primaryIP = expandInstanceNicReservedIP(primnic)
+		if primaryIP != "" && ipv4str != "" {
+			return fmt.Errorf("%s and %s.0.%s can not both be set on the primary network interface", isInstanceNicPrimaryIpv4Address, isInstanceNicPrimaryIP, isInstanceNicReservedIP)
+		}
 		allowIPSpoofing, ok := primnic[isInstanceNicAllowIPSpoofing]
 		allowIPSpoofingbool := allowIPSpoofing.(bool)
 		if ok {
@@ -773,6 +788,11 @@ func instanceCreate(d *schema.ResourceData, meta interface{}, profile, name, vpc
 			if ipv4str != "" {
 				nwInterface.PrimaryIpv4Address = &ipv4str
 			}
+			reservedIP := expandInstanceNicReservedIP(nic)
+			if reservedIP != "" && ipv4str != "" {
+				return fmt.Errorf("%s and %s.0.%s can not both be set on a network interface", isInstanceNicPrimaryIpv4Address, isInstanceNicPrimaryIP, isInstanceNicReservedIP)
+			}
+			nicPrimaryIPs = append(nicPrimaryIPs, reservedIP)
 			allowIPSpoofing, ok := nic[isInstanceNicAllowIPSpoofing]
 			allowIPSpoofingbool := allowIPSpoofing.(bool)
 			if ok {
@@ -822,11 +842,16 @@ func instanceCreate(d *schema.ResourceData, meta interface{}, profile, name, vpc
 
 	}
 
-	options := &vpcv1.CreateInstanceOptions{
-		InstancePrototype: instanceproto,
+	var instance *vpcv1.Instance
+	var response *core.DetailedResponse
+	if primaryIP != "" || strings.Join(nicPrimaryIPs, "") != "" {
+		instance, response, err = createInstanceWithPrimaryIPs(sess, instanceproto, primaryIP, nicPrimaryIPs)
+	} else {
+		options := &vpcv1.CreateInstanceOptions{
+			InstancePrototype: instanceproto,
+		}
+		instance, response, err = sess.CreateInstance(options)
 	}
-
-	instance, response, err := sess.CreateInstance(options)
 	if err != nil {
 		log.Printf("[DEBUG] Instance err %s\n%s", err, response)
 		return err
@@ -1244,6 +1269,11 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 	// }
 	d.Set(isInstanceGpu, gpuList)
 
+	primaryIPs, err := getInstanceNicPrimaryIPs(instanceC, id)
+	if err != nil {
+		return err
+	}
+
 	if instance.PrimaryNetworkInterface != nil {
 		primaryNicList := make([]map[string]interface{}, 0)
 		currentPrimNic := map[string]interface{}{}
@@ -1258,6 +1288,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 		if err != nil {
 			return fmt.Errorf("Error getting network interfaces attached to the instance %s\n%s", err, response)
 		}
+		currentPrimNic[isInstanceNicPrimaryIP] = primaryIPs[*instance.PrimaryNetworkInterface.ID]
 		currentPrimNic[isInstanceNicAllowIPSpoofing] = *insnic.AllowIPSpoofing
 		currentPrimNic[isInstanceNicSubnet] = *insnic.Subnet.ID
 		if len(insnic.SecurityGroups) != 0 {
@@ -1288,6 +1319,7 @@ func instanceGet(d *schema.ResourceData, meta interface{}, id string) error {
 				if err != nil {
 					return fmt.Errorf("Error getting network interfaces attached to the instance %s\n%s", err, response)
 				}
+				currentNic[isInstanceNicPrimaryIP] = primaryIPs[*intfc.ID]
 				currentNic[isInstanceNicAllowIPSpoofing] = *insnic.AllowIPSpoofing
 				currentNic[isInstanceNicSubnet] = *insnic.Subnet.ID
 				if len(insnic.SecurityGroups) != 0 {
@@ -2412,3 +2444,121 @@ func isWaitForInstanceVolumeDetached(instanceC *vpcv1.VpcV1, d *schema.ResourceD
 
 	return stateConf.WaitForState()
 }
+
+// instanceNicPrimaryIPSchema is the reserved IP bound as primary IP of an instance network interface
+func instanceNicPrimaryIPSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Description: "The reserved IP bound as primary IP of the network interface, only supported in Generation 2",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				isInstanceNicReservedIP: {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Computed:    true,
+					ForceNew:    true,
+					Description: "The ID of an unbound reserved IP of the subnet to bind as primary IP",
+				},
+				isInstanceNicReservedIPAddress: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The IP address of the reserved IP",
+				},
+				isInstanceNicReservedIPName: {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The name of the reserved IP",
+				},
+			},
+		},
+	}
+}
+
+func expandInstanceNicReservedIP(nic map[string]interface{}) string {
+	primaryIPs, ok := nic[isInstanceNicPrimaryIP].([]interface{})
+	if !ok || len(primaryIPs) == 0 || primaryIPs[0] == nil {
+		return ""
+	}
+	return primaryIPs[0].(map[string]interface{})[isInstanceNicReservedIP].(string)
+}
+
+// instanceNicPrimaryIPPrototype binds the reserved IPs as primary IPs of the network interfaces of the
+// instance prototype. nicPrimaryIPs follows the order of the network interfaces, empty IDs are skipped.
+func instanceNicPrimaryIPPrototype(instanceproto *vpcv1.InstancePrototype, primaryIP string, nicPrimaryIPs []string) (map[string]interface{}, error) {
+	body, err := json.Marshal(instanceproto)
+	if err != nil {
+		return nil, err
+	}
+	prototype := map[string]interface{}{}
+	if err := json.Unmarshal(body, &prototype); err != nil {
+		return nil, err
+	}
+	if primaryIP != "" {
+		nic := prototype[isInstancePrimaryNetworkInterface].(map[string]interface{})
+		nic[isInstanceNicPrimaryIP] = map[string]interface{}{"id": primaryIP}
+	}
+	nics, _ := prototype[isInstanceNetworkInterfaces].([]interface{})
+	for i, reservedIP := range nicPrimaryIPs {
+		if reservedIP != "" && i < len(nics) {
+			nics[i].(map[string]interface{})[isInstanceNicPrimaryIP] = map[string]interface{}{"id": reservedIP}
+		}
+	}
+	return prototype, nil
+}
+
+// createInstanceWithPrimaryIPs creates the instance with a plain request, because the network interface
+// prototype of the vpc sdk can not bind a reserved IP as primary IP
+func createInstanceWithPrimaryIPs(sess *vpcv1.VpcV1, instanceproto *vpcv1.InstancePrototype, primaryIP string, nicPrimaryIPs []string) (*vpcv1.Instance, *core.DetailedResponse, error) {
+	prototype, err := instanceNicPrimaryIPPrototype(instanceproto, primaryIP, nicPrimaryIPs)
+	if err != nil {
+		return nil, nil, err
+	}
+	var rawResponse map[string]json.RawMessage
+	response, err := vpcPlainRequest(sess, core.POST, "/instances", nil, "", prototype, &rawResponse)
+	if err != nil {
+		return nil, response, err
+	}
+	var instance *vpcv1.Instance
+	if err := core.UnmarshalModel(rawResponse, "", &instance, vpcv1.UnmarshalInstance); err != nil {
+		return nil, response, err
+	}
+	return instance, response, nil
+}
+
+// getInstanceNicPrimaryIPs returns the primary IP of every network interface of the instance by
+// network interface ID, the vpc sdk does not have the primary_ip of network interfaces
+func getInstanceNicPrimaryIPs(sess *vpcv1.VpcV1, id string) (map[string][]map[string]interface{}, error) {
+	type reservedIPReference struct {
+		ID      string `json:"id"`
+		Address string `json:"address"`
+		Name    string `json:"name"`
+	}
+	type networkInterface struct {
+		ID        string               `json:"id"`
+		PrimaryIP *reservedIPReference `json:"primary_ip"`
+	}
+	var result struct {
+		PrimaryNetworkInterface networkInterface   `json:"primary_network_interface"`
+		NetworkInterfaces       []networkInterface `json:"network_interfaces"`
+	}
+	response, err := vpcPlainRequest(sess, core.GET, "/instances/{id}", map[string]string{"id": id}, "", nil, &result)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the primary IPs of the instance (%s) network interfaces: %s\n%s", id, err, response)
+	}
+	primaryIPs := map[string][]map[string]interface{}{}
+	for _, nic := range append(result.NetworkInterfaces, result.PrimaryNetworkInterface) {
+		primaryIPs[nic.ID] = make([]map[string]interface{}, 0)
+		if nic.PrimaryIP != nil {
+			primaryIPs[nic.ID] = append(primaryIPs[nic.ID], map[string]interface{}{
+				isInstanceNicReservedIP:        nic.PrimaryIP.ID,
+				isInstanceNicReservedIPAddress: nic.PrimaryIP.Address,
+				isInstanceNicReservedIPName:    nic.PrimaryIP.Name,
+			})
+		}
+	}
+	return primaryIPs, nil
+}
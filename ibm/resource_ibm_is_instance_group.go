@@ -102,6 +102,38 @@ func resourceIBMISInstanceGroup() *schema.Resource {
 				Description: "load balancer pool ID",
 			},
 
+			"rolling_update": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Replace existing memberships batch by batch when the instance template changes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_unavailable": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: InvokeValidator("ibm_is_instance_group", "max_unavailable"),
+							Description:  "The maximum number of memberships that can be unavailable during the replacement, including the memberships that are already unhealthy",
+						},
+						"batch_size": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: InvokeValidator("ibm_is_instance_group", "batch_size"),
+							Description:  "The number of memberships replaced in each batch",
+						},
+						"health_wait": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      300,
+							ValidateFunc: InvokeValidator("ibm_is_instance_group", "health_wait"),
+							Description:  "The time in seconds to wait for a replaced batch to become healthy",
+						},
+					},
+				},
+			},
+
 			"managers": {
 				Type:        schema.TypeList,
 				Elem:        &schema.Schema{Type: schema.TypeString},
@@ -165,6 +197,27 @@ func resourceIBMISInstanceGroupValidator() *ResourceValidator {
 			Type:                       TypeInt,
 			MinValue:                   "1",
 			MaxValue:                   "65535"})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 "max_unavailable",
+			ValidateFunctionIdentifier: IntBetween,
+			Type:                       TypeInt,
+			MinValue:                   "1",
+			MaxValue:                   "1000"})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 "batch_size",
+			ValidateFunctionIdentifier: IntBetween,
+			Type:                       TypeInt,
+			MinValue:                   "1",
+			MaxValue:                   "1000"})
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 "health_wait",
+			ValidateFunctionIdentifier: IntBetween,
+			Type:                       TypeInt,
+			MinValue:                   "0",
+			MaxValue:                   "3600"})
 	validateSchema = append(validateSchema,
 		ValidateSchema{
 			Identifier:                 "tag",
@@ -343,6 +396,15 @@ func resourceIBMISInstanceGroupUpdate(d *schema.ResourceData, meta interface{})
 			return healthError
 		}
 	}
+
+	if d.HasChange("instance_template") {
+		if _, ok := d.GetOk("rolling_update"); ok {
+			err = rollInstanceGroupMemberships(d, meta)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	return resourceIBMISInstanceGroupRead(d, meta)
 }
 
@@ -514,3 +576,216 @@ func waitForInstanceGroupDelete(d *schema.ResourceData, meta interface{}) (inter
 	return healthStateConf.WaitForState()
 
 }
+
+// rollInstanceGroupMemberships deletes the memberships still running an old instance template in batches,
+// letting the instance group recreate them from the current template before moving to the next batch.
+func rollInstanceGroupMemberships(d *schema.ResourceData, meta interface{}) error {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return err
+	}
+
+	instanceGroupID := d.Id()
+	instanceTemplate := d.Get("instance_template").(string)
+	maxUnavailable := d.Get("rolling_update.0.max_unavailable").(int)
+	batchSize := d.Get("rolling_update.0.batch_size").(int)
+	healthWait := time.Duration(d.Get("rolling_update.0.health_wait").(int)) * time.Second
+
+	// The membership count is owned by the instance group manager when the group autoscales, so the
+	// count to restore is read from the group instead of instance_count.
+	getInstanceGroupOptions := vpcv1.GetInstanceGroupOptions{ID: &instanceGroupID}
+	instanceGroup, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
+	if err != nil || instanceGroup == nil {
+		return fmt.Errorf("Error Getting InstanceGroup: %s\n%s", err, response)
+	}
+	var membershipCount int64
+	if instanceGroup.MembershipCount != nil {
+		membershipCount = *instanceGroup.MembershipCount
+	}
+
+	memberships, err := listInstanceGroupMemberships(sess, instanceGroupID)
+	if err != nil {
+		return err
+	}
+	outdated := make([]string, 0)
+	for _, membership := range memberships {
+		if membership.InstanceTemplate != nil && *membership.InstanceTemplate.ID != instanceTemplate {
+			outdated = append(outdated, *membership.ID)
+		}
+	}
+
+	for len(outdated) > 0 {
+		memberships, err := listInstanceGroupMemberships(sess, instanceGroupID)
+		if err != nil {
+			return err
+		}
+		size := instanceGroupRollingBatchSize(batchSize, maxUnavailable, unavailableInstanceGroupMemberships(memberships, int(membershipCount)))
+		if size == 0 {
+			log.Printf("[INFO] Waiting for the unavailable memberships of instance group (%s) before replacing the next batch", instanceGroupID)
+			_, healthError := waitForHealthyInstanceGroupMemberships(d, meta, int(membershipCount), healthWait)
+			if healthError != nil {
+				return healthError
+			}
+			continue
+		}
+		batch := outdated
+		if len(batch) > size {
+			batch = outdated[:size]
+		}
+		outdated = outdated[len(batch):]
+
+		for _, membershipID := range batch {
+			log.Printf("[INFO] Replacing instance group (%s) membership %s", instanceGroupID, membershipID)
+			deleteInstanceGroupMembershipOptions := vpcv1.DeleteInstanceGroupMembershipOptions{
+				InstanceGroupID: &instanceGroupID,
+				ID:              &membershipID,
+			}
+			response, err := sess.DeleteInstanceGroupMembership(&deleteInstanceGroupMembershipOptions)
+			if err != nil && (response == nil || response.StatusCode != 404) {
+				return fmt.Errorf("Error Deleting InstanceGroup Membership %s: %s\n%s", membershipID, err, response)
+			}
+		}
+
+		// Deleting a membership lowers the membership count, restore it so that the group recreates the batch.
+		instanceGroup, response, err := sess.GetInstanceGroup(&getInstanceGroupOptions)
+		if err != nil || instanceGroup == nil {
+			return fmt.Errorf("Error Getting InstanceGroup: %s\n%s", err, response)
+		}
+		if instanceGroup.MembershipCount != nil && *instanceGroup.MembershipCount < membershipCount {
+			instanceGroupPatchModel := vpcv1.InstanceGroupPatch{MembershipCount: &membershipCount}
+			instanceGroupPatch, err := instanceGroupPatchModel.AsPatch()
+			if err != nil {
+				return fmt.Errorf("Error calling asPatch for InstanceGroupPatch: %s", err)
+			}
+			instanceGroupUpdateOptions := vpcv1.UpdateInstanceGroupOptions{
+				ID:                 &instanceGroupID,
+				InstanceGroupPatch: instanceGroupPatch,
+			}
+			_, response, err := sess.UpdateInstanceGroup(&instanceGroupUpdateOptions)
+			if err != nil {
+				return fmt.Errorf("Error Updating InstanceGroup: %s\n%s", err, response)
+			}
+		}
+
+		_, healthError := waitForHealthyInstanceGroup(d, meta, d.Timeout(schema.TimeoutUpdate))
+		if healthError != nil {
+			return healthError
+		}
+		_, healthError = waitForHealthyInstanceGroupMemberships(d, meta, int(membershipCount), healthWait)
+		if healthError != nil {
+			return healthError
+		}
+	}
+	return nil
+}
+
+// instanceGroupRollingBatchSize returns the number of memberships that can be replaced next without
+// exceeding max_unavailable, or 0 when the unavailable memberships must recover first
+func instanceGroupRollingBatchSize(batchSize, maxUnavailable, unavailable int) int {
+	size := maxUnavailable - unavailable
+	if size > batchSize {
+		size = batchSize
+	}
+	if size < 0 {
+		return 0
+	}
+	return size
+}
+
+// unavailableInstanceGroupMemberships counts the memberships that are not healthy and the missing ones
+func unavailableInstanceGroupMemberships(memberships []vpcv1.InstanceGroupMembership, membershipCount int) int {
+	unavailable := 0
+	if len(memberships) < membershipCount {
+		unavailable = membershipCount - len(memberships)
+	}
+	for _, membership := range memberships {
+		if membership.Status == nil || *membership.Status != vpcv1.InstanceGroupMembershipStatusHealthyConst {
+			unavailable++
+		}
+	}
+	return unavailable
+}
+
+func waitForHealthyInstanceGroupMemberships(d *schema.ResourceData, meta interface{}, membershipCount int, timeout time.Duration) (interface{}, error) {
+	sess, err := vpcClient(meta)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceGroupID := d.Id()
+	lbID := d.Get("load_balancer").(string)
+	lbPoolID := d.Get("load_balancer_pool").(string)
+
+	healthStateConf := &resource.StateChangeConf{
+		Pending: []string{vpcv1.InstanceGroupMembershipStatusPendingConst},
+		Target:  []string{vpcv1.InstanceGroupMembershipStatusHealthyConst},
+		Refresh: func() (interface{}, string, error) {
+			memberships, err := listInstanceGroupMemberships(sess, instanceGroupID)
+			if err != nil {
+				return nil, vpcv1.InstanceGroupMembershipStatusPendingConst, err
+			}
+			if len(memberships) < membershipCount {
+				return memberships, vpcv1.InstanceGroupMembershipStatusPendingConst, nil
+			}
+			for _, membership := range memberships {
+				switch *membership.Status {
+				case vpcv1.InstanceGroupMembershipStatusHealthyConst:
+				case vpcv1.InstanceGroupMembershipStatusFailedConst:
+					return memberships, *membership.Status, fmt.Errorf("Instance group (%s) membership %s failed", instanceGroupID, *membership.ID)
+				default:
+					return memberships, vpcv1.InstanceGroupMembershipStatusPendingConst, nil
+				}
+				if lbID == "" || lbPoolID == "" {
+					continue
+				}
+				if membership.PoolMember == nil {
+					return memberships, vpcv1.InstanceGroupMembershipStatusPendingConst, nil
+				}
+				getLoadBalancerPoolMemberOptions := vpcv1.GetLoadBalancerPoolMemberOptions{
+					LoadBalancerID: &lbID,
+					PoolID:         &lbPoolID,
+					ID:             membership.PoolMember.ID,
+				}
+				poolMember, response, err := sess.GetLoadBalancerPoolMember(&getLoadBalancerPoolMemberOptions)
+				if err != nil || poolMember == nil {
+					return nil, vpcv1.InstanceGroupMembershipStatusPendingConst, fmt.Errorf("Error Getting Load Balancer Pool Member: %s\n%s", err, response)
+				}
+				log.Printf("[DEBUG] Instance group membership %s pool member health: %s", *membership.ID, *poolMember.Health)
+				if *poolMember.Health != vpcv1.LoadBalancerPoolMemberHealthOkConst {
+					return memberships, vpcv1.InstanceGroupMembershipStatusPendingConst, nil
+				}
+			}
+			return memberships, vpcv1.InstanceGroupMembershipStatusHealthyConst, nil
+		},
+		Timeout:      timeout,
+		Delay:        10 * time.Second,
+		MinTimeout:   5 * time.Second,
+		PollInterval: 10 * time.Second,
+	}
+
+	return healthStateConf.WaitForState()
+}
+
+func listInstanceGroupMemberships(sess *vpcv1.VpcV1, instanceGroupID string) ([]vpcv1.InstanceGroupMembership, error) {
+	// Support for pagination
+	start := ""
+	allrecs := []vpcv1.InstanceGroupMembership{}
+	for {
+		listInstanceGroupMembershipsOptions := vpcv1.ListInstanceGroupMembershipsOptions{
+			InstanceGroupID: &instanceGroupID,
+		}
+		if start != "" {
+			listInstanceGroupMembershipsOptions.Start = &start
+		}
+		instanceGroupMembershipsCollection, response, err := sess.ListInstanceGroupMemberships(&listInstanceGroupMembershipsOptions)
+		if err != nil {
+			return nil, fmt.Errorf("Error Getting InstanceGroup Memberships %s\n%s", err, response)
+		}
+		start = GetNext(instanceGroupMembershipsCollection.Next)
+		allrecs = append(allrecs, instanceGroupMembershipsCollection.Memberships...)
+		if start == "" {
+			break
+		}
+	}
+	return allrecs, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
 )
 
 func TestAccIBMISInstanceGroup_basic(t *testing.T) {
@@ -42,6 +43,98 @@ func TestAccIBMISInstanceGroup_basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMISInstanceGroup_rollingUpdate(t *testing.T) {
+	randInt := acctest.RandIntRange(100, 200)
+	instanceGroupName := fmt.Sprintf("testinstancegroup%d", randInt)
+	publicKey := strings.TrimSpace(`
+	ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQDVtuCfWKVGKaRmaRG6JQZY8YdxnDgGzVOK93IrV9R5Hl0JP1oiLLWlZQS2reAKb8lBqyDVEREpaoRUDjqDqXG8J/kR42FKN51su914pjSBc86wJ02VtT1Wm1zRbSg67kT+g8/T1jCgB5XBODqbcICHVP8Z1lXkgbiHLwlUrbz6OZkGJHo/M/kD1Eme8lctceIYNz/Ilm7ewMXZA4fsidpto9AjyarrJLufrOBl4MRVcZTDSJ7rLP982aHpu9pi5eJAjOZc7Og7n4ns3NFppiCwgVMCVUQbN5GBlWhZ1OsT84ZiTf+Zy8ew+Yg5T7Il8HuC7loWnz+esQPf0s3xhC/kTsGgZreIDoh/rxJfD67wKXetNSh5RH/n5BqjaOuXPFeNXmMhKlhj9nJ8scayx/wsvOGuocEIkbyJSLj3sLUU403OafgatEdnJOwbqg6rUNNF5RIjpJpL7eEWlKIi1j9LyhmPJ+fEO7TmOES82VpCMHpLbe4gf/MhhJ/Xy8DKh9s= root@ffd8363b1226
+	`)
+	vpcName := fmt.Sprintf("testvpc%d", randInt)
+	subnetName := fmt.Sprintf("testsubnet%d", randInt)
+	templateName := fmt.Sprintf("testtemplate%d", randInt)
+	sshKeyName := fmt.Sprintf("testsshkey%d", randInt)
+	var instances []string
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISInstanceGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISInstanceGroupRollingUpdateConfig(vpcName, subnetName, sshKeyName, publicKey, templateName, instanceGroupName, "instancetemplate1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_group.instance_group", "name", instanceGroupName),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_group.instance_group", "rolling_update.0.batch_size", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_group.instance_group", "rolling_update.0.max_unavailable", "1"),
+					testAccCheckIBMISInstanceGroupMembershipInstances("ibm_is_instance_group.instance_group", &instances),
+				),
+			},
+			{
+				Config: testAccCheckIBMISInstanceGroupRollingUpdateConfig(vpcName, subnetName, sshKeyName, publicKey, templateName, instanceGroupName, "instancetemplate2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_instance_group.instance_group", "instance_template", "ibm_is_instance_template.instancetemplate2", "id"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_instance_group.instance_group", "instances", "2"),
+					testAccCheckIBMISInstanceGroupMembershipsReplaced("ibm_is_instance_group.instance_group", &instances),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckIBMISInstanceGroupMembershipInstances records the instances of the memberships of the group
+func testAccCheckIBMISInstanceGroupMembershipInstances(n string, instances *[]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		memberships, err := listInstanceGroupMemberships(sess, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		*instances = []string{}
+		for _, membership := range memberships {
+			*instances = append(*instances, *membership.Instance.ID)
+		}
+		if len(*instances) == 0 {
+			return fmt.Errorf("Instance group %s has no memberships", rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+// testAccCheckIBMISInstanceGroupMembershipsReplaced verifies that none of the recorded instances is a
+// membership of the group anymore, and that all memberships run the current instance template
+func testAccCheckIBMISInstanceGroupMembershipsReplaced(n string, instances *[]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
+		memberships, err := listInstanceGroupMemberships(sess, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		for _, membership := range memberships {
+			for _, instance := range *instances {
+				if *membership.Instance.ID == instance {
+					return fmt.Errorf("Instance %s of membership %s was not replaced", instance, *membership.ID)
+				}
+			}
+			if membership.InstanceTemplate == nil || *membership.InstanceTemplate.ID != rs.Primary.Attributes["instance_template"] {
+				return fmt.Errorf("Membership %s does not run instance template %s", *membership.ID, rs.Primary.Attributes["instance_template"])
+			}
+		}
+		return nil
+	}
+}
+
 func testAccCheckIBMISInstanceGroupDestroy(s *terraform.State) error {
 	sess, _ := testAccProvider.Meta().(ClientSession).VpcV1API()
 	for _, rs := range s.RootModule().Resources {
@@ -106,3 +199,84 @@ func testAccCheckIBMISInstanceGroupConfig(vpcName, subnetName, sshKeyName, publi
 	`, vpcName, subnetName, sshKeyName, publicKey, templateName, instanceGroupName)
 
 }
+
+func testAccCheckIBMISInstanceGroupRollingUpdateConfig(vpcName, subnetName, sshKeyName, publicKey, templateName, instanceGroupName, instanceTemplate string) string {
+	return fmt.Sprintf(`
+	provider "ibm" {
+		generation = 2
+	}
+	
+	resource "ibm_is_vpc" "vpc2" {
+	  name = "%s"
+	}
+	
+	resource "ibm_is_subnet" "subnet2" {
+	  name            = "%s"
+	  vpc             = ibm_is_vpc.vpc2.id
+	  zone            = "us-south-2"
+	  ipv4_cidr_block = "10.240.64.0/28"
+	}
+	
+	resource "ibm_is_ssh_key" "sshkey" {
+	  name       = "%s"
+	  public_key = "%s"
+	}
+	
+	resource "ibm_is_instance_template" "instancetemplate1" {
+	   name    = "%s-1"
+	   image   = "r006-14140f94-fcc4-11e9-96e7-a72723715315"
+	   profile = "bx2-8x32"
+	
+	   primary_network_interface {
+		 subnet = ibm_is_subnet.subnet2.id
+	   }
+	
+	   vpc       = ibm_is_vpc.vpc2.id
+	   zone      = "us-south-2"
+	   keys      = [ibm_is_ssh_key.sshkey.id]
+	 }
+
+	resource "ibm_is_instance_template" "instancetemplate2" {
+	   name    = "%s-2"
+	   image   = "r006-14140f94-fcc4-11e9-96e7-a72723715315"
+	   profile = "bx2-4x16"
+	
+	   primary_network_interface {
+		 subnet = ibm_is_subnet.subnet2.id
+	   }
+	
+	   vpc       = ibm_is_vpc.vpc2.id
+	   zone      = "us-south-2"
+	   keys      = [ibm_is_ssh_key.sshkey.id]
+	 }
+		
+	resource "ibm_is_instance_group" "instance_group" {
+		name =  "%s"
+		instance_template = ibm_is_instance_template.%s.id
+		instance_count =  2
+		subnets = [ibm_is_subnet.subnet2.id]
+
+		rolling_update {
+			max_unavailable = 1
+			batch_size      = 1
+			health_wait     = 600
+		}
+	}
+	`, vpcName, subnetName, sshKeyName, publicKey, templateName, templateName, instanceGroupName, instanceTemplate)
+
+}
+
+func TestInstanceGroupRollingBatchSize(t *testing.T) {
+	healthy, pending := vpcv1.InstanceGroupMembershipStatusHealthyConst, vpcv1.InstanceGroupMembershipStatusPendingConst
+	memberships := []vpcv1.InstanceGroupMembership{{Status: &healthy}, {Status: &pending}, {Status: &healthy}}
+
+	assert.Equal(t, 1, unavailableInstanceGroupMemberships(memberships, 3))
+	// A membership deleted by the previous batch and not recreated yet is unavailable
+	assert.Equal(t, 2, unavailableInstanceGroupMemberships(memberships, 4))
+
+	assert.Equal(t, 2, instanceGroupRollingBatchSize(2, 3, 0))
+	assert.Equal(t, 1, instanceGroupRollingBatchSize(2, 1, 0))
+	assert.Equal(t, 1, instanceGroupRollingBatchSize(2, 3, 2))
+	assert.Equal(t, 0, instanceGroupRollingBatchSize(2, 1, 1))
+	assert.Equal(t, 0, instanceGroupRollingBatchSize(2, 1, 3))
+}
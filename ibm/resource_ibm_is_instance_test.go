@@ -14,6 +14,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
 )
 
 func TestAccIBMISInstance_basic(t *testing.T) {
@@ -75,6 +77,69 @@ ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVE
 	})
 }
 
+func TestAccIBMISInstance_primaryIP(t *testing.T) {
+	var instance string
+	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
+	name := fmt.Sprintf("tf-instnace-%d", acctest.RandIntRange(10, 100))
+	subnetname := fmt.Sprintf("tf-subnet-%d", acctest.RandIntRange(10, 100))
+	publicKey := strings.TrimSpace(`
+ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQCKVmnMOlHKcZK8tpt3MP1lqOLAcqcJzhsvJcjscgVERRN7/9484SOBJ3HSKxxNG5JN8owAjy5f9yYwcUg+JaUVuytn5Pv3aeYROHGGg+5G346xaq3DAwX6Y5ykr2fvjObgncQBnuU5KHWCECO/4h8uWuwh/kfniXPVjFToc+gnkqA+3RKpAecZhFXwfalQ9mMuYGFxn+fwn8cYEApsJbsEmb0iJwPiZ5hjFC8wREuiTlhPHDgkBLOiycd20op2nXzDbHfCHInquEe/gYxEitALONxm0swBOwJZwlTDOB7C6y2dzlrtxr1L59m7pCkWI4EtTRLvleehBoj3u7jB4usR
+`)
+	sshname := fmt.Sprintf("tf-ssh-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISInstanceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISInstanceConfigPrimaryIP(vpcname, subnetname, sshname, publicKey, name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISInstanceExists("ibm_is_instance.testacc_instance", instance),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_instance.testacc_instance", "primary_network_interface.0.primary_ip.0.reserved_ip",
+						"ibm_is_subnet_reserved_ip.testacc_reserved_ip", "reserved_ip"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_instance.testacc_instance", "primary_network_interface.0.primary_ipv4_address",
+						"ibm_is_subnet_reserved_ip.testacc_reserved_ip", "address"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISInstanceConfigPrimaryIP(vpcname, subnetname, sshname, publicKey, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"ibm_is_subnet_reserved_ip.testacc_reserved_ip", "target",
+						"ibm_is_instance.testacc_instance", "primary_network_interface.0.id"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_subnet_reserved_ip.testacc_reserved_ip", "target_type", "network_interface"),
+				),
+			},
+		},
+	})
+}
+
+func TestInstanceNicPrimaryIPPrototype(t *testing.T) {
+	subnet := "subnet-1"
+	instanceproto := &vpcv1.InstancePrototype{
+		PrimaryNetworkInterface: &vpcv1.NetworkInterfacePrototype{
+			Subnet: &vpcv1.SubnetIdentity{ID: &subnet},
+		},
+		NetworkInterfaces: []vpcv1.NetworkInterfacePrototype{
+			{Subnet: &vpcv1.SubnetIdentity{ID: &subnet}},
+			{Subnet: &vpcv1.SubnetIdentity{ID: &subnet}},
+		},
+	}
+
+	prototype, err := instanceNicPrimaryIPPrototype(instanceproto, "reserved-ip-1", []string{"", "reserved-ip-2"})
+	assert.NilError(t, err)
+	primnic := prototype["primary_network_interface"].(map[string]interface{})
+	assert.DeepEqual(t, primnic["primary_ip"], map[string]interface{}{"id": "reserved-ip-1"})
+	assert.DeepEqual(t, primnic["subnet"], map[string]interface{}{"id": subnet})
+	nics := prototype["network_interfaces"].([]interface{})
+	assert.Assert(t, is.Nil(nics[0].(map[string]interface{})["primary_ip"]))
+	assert.DeepEqual(t, nics[1].(map[string]interface{})["primary_ip"], map[string]interface{}{"id": "reserved-ip-2"})
+}
+
 func TestAccIBMISInstance_VolumeAutoDelete(t *testing.T) {
 	var instance string
 	vpcname := fmt.Sprintf("tf-vpc-%d", acctest.RandIntRange(10, 100))
@@ -311,6 +376,44 @@ func testAccCheckIBMISInstanceConfigwithipv4(vpcname, subnetname, sshname, publi
 	  }`, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ipv4address, ISZoneName)
 }
 
+func testAccCheckIBMISInstanceConfigPrimaryIP(vpcname, subnetname, sshname, publicKey, name string) string {
+	return fmt.Sprintf(`
+	resource "ibm_is_vpc" "testacc_vpc" {
+		name = "%s"
+	  }
+	  
+	  resource "ibm_is_subnet" "testacc_subnet" {
+		name            = "%s"
+		vpc             = ibm_is_vpc.testacc_vpc.id
+		zone            = "%s"
+		ipv4_cidr_block = "%s"
+	  }
+	  
+	  resource "ibm_is_subnet_reserved_ip" "testacc_reserved_ip" {
+		subnet = ibm_is_subnet.testacc_subnet.id
+	  }
+	  
+	  resource "ibm_is_ssh_key" "testacc_sshkey" {
+		name       = "%s"
+		public_key = "%s"
+	  }
+	  
+	  resource "ibm_is_instance" "testacc_instance" {
+		name    = "%s"
+		image   = "%s"
+		profile = "%s"
+		primary_network_interface {
+		  subnet = ibm_is_subnet.testacc_subnet.id
+		  primary_ip {
+			reserved_ip = ibm_is_subnet_reserved_ip.testacc_reserved_ip.reserved_ip
+		  }
+		}
+		vpc  = ibm_is_vpc.testacc_vpc.id
+		zone = "%s"
+		keys = [ibm_is_ssh_key.testacc_sshkey.id]
+	  }`, vpcname, subnetname, ISZoneName, ISCIDR, sshname, publicKey, name, isImage, instanceProfileName, ISZoneName)
+}
+
 func testAccCheckIBMISInstanceVolume(vpcname, subnetname, sshname, publicKey, volName, name string) string {
 	return fmt.Sprintf(`
 	resource "ibm_is_vpc" "testacc_vpc" {
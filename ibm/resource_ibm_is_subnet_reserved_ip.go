@@ -5,6 +5,7 @@ package ibm
 
 import (
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/IBM/go-sdk-core/v4/core"
@@ -56,6 +57,13 @@ func resourceIBMISReservedIP() *schema.Resource {
 				ValidateFunc: InvokeValidator("ibm_is_subnet_reserved_ip", isReservedIPName),
 				Description:  "The user-defined or system-provided name for this reserved IP.",
 			},
+			isReservedIPTarget: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The unique identifier for the endpoint gateway to bind the reserved IP to.",
+			},
 			/*
 				Response Parameters
 				===================
@@ -94,6 +102,11 @@ func resourceIBMISReservedIP() *schema.Resource {
 				Computed:    true,
 				Description: "The resource type.",
 			},
+			isReservedIPTargetType: {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The resource type of the reserved IP target.",
+			},
 		},
 	}
 }
@@ -132,7 +145,17 @@ func resourceIBMISReservedIPCreate(d *schema.ResourceData, meta interface{}) err
 		options.Name = &nameStr
 	}
 
+	target := d.Get(isReservedIPTarget).(string)
+	if target != "" {
+		options.Target = &vpcv1.ReservedIPTargetPrototypeEndpointGatewayIdentity{
+			ID: &target,
+		}
+	}
+
 	autoDeleteBool := d.Get(isReservedIPAutoDelete).(bool)
+	if autoDeleteBool && target == "" {
+		log.Printf("[WARN] %s only takes effect once the reserved IP in subnet %s is bound to a %s", isReservedIPAutoDelete, subnetID, isReservedIPTarget)
+	}
 	options.AutoDelete = &autoDeleteBool
 
 	rip, response, err := sess.CreateSubnetReservedIP(options)
@@ -168,6 +191,8 @@ func resourceIBMISReservedIPRead(d *schema.ResourceData, meta interface{}) error
 		d.Set(isReservedIPName, *rip.Name)
 		d.Set(isReservedIPOwner, *rip.Owner)
 		d.Set(isReservedIPType, *rip.ResourceType)
+		d.Set(isReservedIPTarget, flattenReservedIPTarget(rip.Target))
+		d.Set(isReservedIPTargetType, flattenReservedIPTargetType(rip.Target))
 	}
 	return nil
 }
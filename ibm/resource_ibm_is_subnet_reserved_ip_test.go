@@ -44,6 +44,31 @@ func TestAccIBMISSubnetReservedIPResource_basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMISSubnetReservedIPResource_target(t *testing.T) {
+	var reservedIPID string
+	vpcName := fmt.Sprintf("tfresip-vpc-%d", acctest.RandIntRange(10, 100))
+	subnetName := fmt.Sprintf("tfresip-subnet-%d", acctest.RandIntRange(10, 100))
+	gatewayName := fmt.Sprintf("tfresip-gateway-%d", acctest.RandIntRange(10, 100))
+	reservedIPName := fmt.Sprintf("tfresip-reservedip-%d", acctest.RandIntRange(10, 100))
+	terraformTag := "ibm_is_subnet_reserved_ip.resIP1"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckisSubnetReservedIPDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckISSubnetReservedIPConfigTarget(vpcName, subnetName, gatewayName, reservedIPName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckISSubnetReservedIPExists(terraformTag, &reservedIPID),
+					resource.TestCheckResourceAttrPair(terraformTag, isReservedIPTarget, "ibm_is_virtual_endpoint_gateway.endpoint_gateway", "id"),
+					resource.TestCheckResourceAttr(terraformTag, isReservedIPTargetType, "endpoint_gateway"),
+					resource.TestCheckResourceAttr(terraformTag, isReservedIPAutoDelete, "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckisSubnetReservedIPDestroy(s *terraform.State) error {
 	sess, err := testAccProvider.Meta().(ClientSession).VpcV1API()
 	if err != nil {
@@ -117,3 +142,34 @@ func testAccCheckISSubnetReservedIPConfigBasic(vpcName, subnetName, resIPName st
 	  }
 	`, vpcName, subnetName, resIPName)
 }
+
+func testAccCheckISSubnetReservedIPConfigTarget(vpcName, subnetName, gatewayName, resIPName string) string {
+	return fmt.Sprintf(`
+	  resource "ibm_is_vpc" "vpc1" {
+		name = "%s"
+	  }
+
+	  resource "ibm_is_subnet" "subnet1" {
+		name                     = "%s"
+		vpc                      = ibm_is_vpc.vpc1.id
+		zone                     = "us-south-1"
+		total_ipv4_address_count = 256
+	  }
+
+	  resource "ibm_is_virtual_endpoint_gateway" "endpoint_gateway" {
+		name = "%s"
+		target {
+		  name          = "ibm-dns-server2"
+		  resource_type = "provider_infrastructure_service"
+		}
+		vpc = ibm_is_vpc.vpc1.id
+	  }
+
+	  resource "ibm_is_subnet_reserved_ip" "resIP1" {
+		subnet      = ibm_is_subnet.subnet1.id
+		name        = "%s"
+		target      = ibm_is_virtual_endpoint_gateway.endpoint_gateway.id
+		auto_delete = true
+	  }
+	`, vpcName, subnetName, gatewayName, resIPName)
+}
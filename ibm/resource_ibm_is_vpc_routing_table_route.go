@@ -10,7 +10,7 @@ import (
 	"strings"
 	"time"
 
-	"github.com/IBM/go-sdk-core/core"
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -22,6 +22,7 @@ const (
 	rNextHop     = "next_hop"
 	rName        = "name"
 	rZone        = "zone"
+	rPriority    = "priority"
 )
 
 func resourceIBMISVPCRoutingTableRoute() *schema.Resource {
@@ -66,7 +67,8 @@ func resourceIBMISVPCRoutingTableRoute() *schema.Resource {
 			},
 			rNextHop: {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
 				Description: "If action is deliver, the next hop that packets will be delivered to. For other action values, its address will be 0.0.0.0.",
 			},
@@ -78,6 +80,13 @@ func resourceIBMISVPCRoutingTableRoute() *schema.Resource {
 				Description:  "The action to perform with a packet matching the route.",
 				ValidateFunc: InvokeValidator("ibm_is_vpc_routing_table_route", rAction),
 			},
+			rPriority: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      2,
+				Description:  "The priority of the route, from 0 (highest) to 4 (lowest). Routes with the same destination and priority in a zone share the traffic (ECMP).",
+				ValidateFunc: InvokeValidator("ibm_is_vpc_routing_table_route", rPriority),
+			},
 			rName: {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -138,6 +147,14 @@ func resourceIBMISVPCRoutingTableRouteValidator() *ResourceValidator {
 			Required:                   false,
 			AllowedValues:              actionAllowedValues})
 
+	validateSchema = append(validateSchema,
+		ValidateSchema{
+			Identifier:                 rPriority,
+			ValidateFunctionIdentifier: IntBetween,
+			Type:                       TypeInt,
+			MinValue:                   "0",
+			MaxValue:                   "4"})
+
 	ibmVPCRoutingTableRouteValidator := ResourceValidator{ResourceName: "ibm_is_vpc_routing_table_route", Schema: validateSchema}
 	return &ibmVPCRoutingTableRouteValidator
 }
@@ -152,47 +169,43 @@ func resourceIBMISVPCRoutingTableRouteCreate(d *schema.ResourceData, meta interf
 	tableID := d.Get(rtID).(string)
 	destination := d.Get(rDestination).(string)
 	zone := d.Get(rZone).(string)
-	z := &vpcv1.ZoneIdentityByName{
-		Name: core.StringPtr(zone),
+
+	routeAction := d.Get(rAction).(string)
+	nextHop := d.Get(rNextHop).(string)
+	if routeAction == "deliver" && (nextHop == "" || nextHop == "0.0.0.0") {
+		return fmt.Errorf("%s is required when %s is deliver", rNextHop, rAction)
+	}
+	if routeAction != "deliver" && nextHop != "" && nextHop != "0.0.0.0" {
+		return fmt.Errorf("%s can only be set when %s is deliver, found %s", rNextHop, rAction, routeAction)
 	}
 
-	createVpcRoutingTableRouteOptions := sess.NewCreateVPCRoutingTableRouteOptions(vpcID, tableID, destination, z)
-	createVpcRoutingTableRouteOptions.SetZone(z)
-	createVpcRoutingTableRouteOptions.SetDestination(destination)
-
-	if add, ok := d.GetOk(rNextHop); ok {
-		item := add.(string)
-		if net.ParseIP(item) == nil {
-			nhConnectionID := &vpcv1.RouteNextHopPrototypeVPNGatewayConnectionIdentity{
-				ID: core.StringPtr(item),
-			}
-			createVpcRoutingTableRouteOptions.SetNextHop(nhConnectionID)
+	// The route prototype of the vpc sdk has no priority, so the route is created with a plain request
+	routePrototype := map[string]interface{}{
+		"destination": destination,
+		"zone":        map[string]string{"name": zone},
+		"action":      routeAction,
+		"priority":    d.Get(rPriority).(int),
+	}
+	if routeAction == "deliver" {
+		if net.ParseIP(nextHop) == nil {
+			routePrototype["next_hop"] = map[string]string{"id": nextHop}
 		} else {
-			nh := &vpcv1.RouteNextHopPrototypeRouteNextHopIP{
-				Address: core.StringPtr(item),
-			}
-			createVpcRoutingTableRouteOptions.SetNextHop(nh)
+			routePrototype["next_hop"] = map[string]string{"address": nextHop}
 		}
 	}
-
-	if action, ok := d.GetOk(rAction); ok {
-		routeAction := action.(string)
-		createVpcRoutingTableRouteOptions.SetAction(routeAction)
-	}
-
 	if name, ok := d.GetOk(rName); ok {
-		routeName := name.(string)
-		createVpcRoutingTableRouteOptions.SetName(routeName)
+		routePrototype["name"] = name.(string)
 	}
 
-	route, response, err := sess.CreateVPCRoutingTableRoute(createVpcRoutingTableRouteOptions)
+	route := vpcRoutingTableRoute{}
+	response, err := vpcRoutingTableRouteRequest(sess, core.POST, vpcID, tableID, "", "", routePrototype, &route)
 	if err != nil {
 		log.Printf("[DEBUG] Create VPC Routing table route err %s\n%s", err, response)
 		return err
 	}
 
-	d.SetId(fmt.Sprintf("%s/%s/%s", vpcID, tableID, *route.ID))
-	d.Set(rID, *route.ID)
+	d.SetId(fmt.Sprintf("%s/%s/%s", vpcID, tableID, route.ID))
+	d.Set(rID, route.ID)
 	return resourceIBMISVPCRoutingTableRouteRead(d, meta)
 }
 
@@ -232,6 +245,14 @@ func resourceIBMISVPCRoutingTableRouteRead(d *schema.ResourceData, meta interfac
 	d.Set(rtLifecycleState, route.LifecycleState)
 	d.Set(rtCreateAt, route.CreatedAt.String())
 
+	details := vpcRoutingTableRoute{}
+	response, err = vpcRoutingTableRouteRequest(sess, core.GET, idSet[0], idSet[1], idSet[2], "", nil, &details)
+	if err != nil {
+		return fmt.Errorf("Error Getting the action and priority of VPC Routing table route: %s\n%s", err, response)
+	}
+	d.Set(rAction, details.Action)
+	d.Set(rPriority, details.Priority)
+
 	return nil
 }
 
@@ -264,6 +285,16 @@ func resourceIBMISVPCRoutingTableRouteUpdate(d *schema.ResourceData, meta interf
 		}
 	}
 
+	if d.HasChange(rPriority) {
+		// The route patch of the vpc sdk has no priority
+		routePatch := map[string]interface{}{"priority": d.Get(rPriority).(int)}
+		response, err := vpcRoutingTableRouteRequest(sess, core.PATCH, idSet[0], idSet[1], idSet[2], "", routePatch, &vpcRoutingTableRoute{})
+		if err != nil {
+			log.Printf("[DEBUG] Update VPC Routing table route priority err %s\n%s", err, response)
+			return err
+		}
+	}
+
 	return resourceIBMISVPCRoutingTableRouteRead(d, meta)
 }
 
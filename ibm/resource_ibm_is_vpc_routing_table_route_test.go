@@ -4,12 +4,19 @@
 package ibm
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IBM/go-sdk-core/v5/core"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"testing"
+	"gotest.tools/assert"
 )
 
 func TestAccIBMISVPCRoutingTableRoute_basic(t *testing.T) {
@@ -46,6 +53,96 @@ func TestAccIBMISVPCRoutingTableRoute_basic(t *testing.T) {
 	})
 }
 
+func TestAccIBMISVPCRoutingTableRoute_drop(t *testing.T) {
+	var vpcRouteTables string
+	name1 := fmt.Sprintf("tfvpcuat-drop-%d", acctest.RandIntRange(10, 100))
+	subnetName := fmt.Sprintf("tfsubnet-drop-%d", acctest.RandIntRange(10, 100))
+	routeName := fmt.Sprintf("tfvpcuat-drop-%d", acctest.RandIntRange(10, 100))
+	routeTableName := fmt.Sprintf("tfvpcrt-drop-%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISVPCRouteTableRouteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCRouteTableRouteDropConfig(routeTableName, name1, subnetName, routeName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMISVPCRouteTableRouteExists("ibm_is_vpc_routing_table_route.test_drop_route", vpcRouteTables),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table_route.test_drop_route", "action", "drop"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table_route.test_drop_route", "next_hop", "0.0.0.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIBMISVPCRoutingTableRoute_ecmp(t *testing.T) {
+	name1 := fmt.Sprintf("tfvpcuat-create-%d", acctest.RandIntRange(10, 100))
+	subnetName := fmt.Sprintf("tfsubnet-%d", acctest.RandIntRange(10, 100))
+	routeTableName := fmt.Sprintf("tfvpcrt-%d", acctest.RandIntRange(10, 100))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMISVPCRouteTableRouteDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMISVPCRouteTableRouteECMPConfig(routeTableName, name1, subnetName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table_route.test_ecmp_route.0", "priority", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table_route.test_ecmp_route.1", "priority", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table_route.test_ecmp_route.0", "action", "deliver"),
+				),
+			},
+			{
+				Config: testAccCheckIBMISVPCRouteTableRouteECMPConfig(routeTableName, name1, subnetName, 3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table_route.test_ecmp_route.0", "priority", "3"),
+					resource.TestCheckResourceAttr(
+						"ibm_is_vpc_routing_table_route.test_ecmp_route.1", "priority", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestVPCRoutingTableRouteRequest(t *testing.T) {
+	var method, path, contentType string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		body = nil
+		json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": "r006-route", "action": "deliver", "priority": 1}`)
+	}))
+	defer server.Close()
+	sess, err := vpcv1.NewVpcV1(&vpcv1.VpcV1Options{URL: server.URL, Authenticator: &core.NoAuthAuthenticator{}})
+	assert.NilError(t, err)
+
+	route := vpcRoutingTableRoute{}
+	_, err = vpcRoutingTableRouteRequest(sess, core.POST, "vpc", "table", "", "", map[string]interface{}{"priority": 1}, &route)
+	assert.NilError(t, err)
+	assert.Equal(t, "POST", method)
+	assert.Equal(t, "/vpcs/vpc/routing_tables/table/routes", path)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, float64(1), body["priority"])
+	assert.Equal(t, vpcRoutingTableRoute{ID: "r006-route", Action: "deliver", Priority: 1}, route)
+
+	_, err = vpcRoutingTableRouteRequest(sess, core.PATCH, "vpc", "table", "r006-route", "", map[string]interface{}{"priority": 3}, &route)
+	assert.NilError(t, err)
+	assert.Equal(t, "PATCH", method)
+	assert.Equal(t, "/vpcs/vpc/routing_tables/table/routes/r006-route", path)
+	assert.Equal(t, "application/merge-patch+json", contentType)
+	assert.Equal(t, float64(3), body["priority"])
+}
+
 func testAccCheckIBMISVPCRouteTableRouteDestroy(s *terraform.State) error {
 	//userDetails, _ := testAccProvider.Meta().(ClientSession).BluemixUserDetails()
 
@@ -140,3 +237,59 @@ resource "ibm_is_vpc_routing_table_route" "test_custom_route1" {
 }
 `, name, rtName, subnetName, ISZoneName, ISCIDR, routeName, ISZoneName, ISRouteNextHop)
 }
+
+func testAccCheckIBMISVPCRouteTableRouteDropConfig(rtName, name, subnetName, routeName string) string {
+	return fmt.Sprintf(`
+resource "ibm_is_vpc" "testacc_vpc" {
+    name = "%s"
+}
+resource "ibm_is_vpc_routing_table" "test_ibm_is_vpc_routing_table" {
+	vpc = ibm_is_vpc.testacc_vpc.id
+	name = "%s"
+	route_transit_gateway_ingress = true
+}
+resource "ibm_is_subnet" "test_cr_subnet1" {
+	name = "%s"
+	vpc = ibm_is_vpc.testacc_vpc.id
+	zone = "%s"
+	ipv4_cidr_block = "%s"
+}
+resource "ibm_is_vpc_routing_table_route" "test_drop_route" {
+  vpc = ibm_is_vpc.testacc_vpc.id
+  routing_table = ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table.routing_table
+  name = "%s"
+  zone = "%s"
+  action = "drop"
+  destination = ibm_is_subnet.test_cr_subnet1.ipv4_cidr_block
+}
+`, name, rtName, subnetName, ISZoneName, ISCIDR, routeName, ISZoneName)
+}
+
+func testAccCheckIBMISVPCRouteTableRouteECMPConfig(rtName, name, subnetName string, priority int) string {
+	return fmt.Sprintf(`
+resource "ibm_is_vpc" "testacc_vpc" {
+    name = "%s"
+}
+resource "ibm_is_vpc_routing_table" "test_ibm_is_vpc_routing_table" {
+	vpc = ibm_is_vpc.testacc_vpc.id
+	name = "%s"
+	route_transit_gateway_ingress = true
+}
+resource "ibm_is_subnet" "test_cr_subnet1" {
+	name = "%s"
+	vpc = ibm_is_vpc.testacc_vpc.id
+	zone = "%s"
+	ipv4_cidr_block = "%s"
+}
+// Two routes with the same destination and priority share the traffic
+resource "ibm_is_vpc_routing_table_route" "test_ecmp_route" {
+  count = 2
+  vpc = ibm_is_vpc.testacc_vpc.id
+  routing_table = ibm_is_vpc_routing_table.test_ibm_is_vpc_routing_table.routing_table
+  zone = "%s"
+  destination = "192.168.4.0/24"
+  next_hop = cidrhost(ibm_is_subnet.test_cr_subnet1.ipv4_cidr_block, count.index + 4)
+  priority = %d
+}
+`, name, rtName, subnetName, ISZoneName, ISCIDR, ISZoneName, priority)
+}
@@ -0,0 +1,306 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/minsikl/netscaler-nitro-go/client"
+	dt "github.com/minsikl/netscaler-nitro-go/datatypes"
+	"github.com/minsikl/netscaler-nitro-go/op"
+)
+
+type cspolicy struct {
+	Policyname *string `json:"policyname,omitempty"`
+	Rule       *string `json:"rule,omitempty"`
+}
+
+type cspolicyReq struct {
+	Cspolicy *cspolicy `json:"cspolicy,omitempty"`
+}
+
+type cspolicyRes struct {
+	dt.BaseRes
+	Cspolicy []cspolicy `json:"cspolicy,omitempty"`
+}
+
+type csvserverCspolicyBinding struct {
+	Name            *string `json:"name,omitempty"`
+	Policyname      *string `json:"policyname,omitempty"`
+	Targetlbvserver *string `json:"targetlbvserver,omitempty"`
+	Priority        *int    `json:"priority,omitempty"`
+}
+
+type csvserverCspolicyBindingReq struct {
+	CsvserverCspolicyBinding *csvserverCspolicyBinding `json:"csvserver_cspolicy_binding,omitempty"`
+}
+
+type csvserverCspolicyBindingRes struct {
+	dt.BaseRes
+	CsvserverCspolicyBinding []csvserverCspolicyBinding `json:"csvserver_cspolicy_binding,omitempty"`
+}
+
+func resourceIBMLbVpxCsPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMLbVpxCsPolicyCreate,
+		Read:     resourceIBMLbVpxCsPolicyRead,
+		Update:   resourceIBMLbVpxCsPolicyUpdate,
+		Delete:   resourceIBMLbVpxCsPolicyDelete,
+		Exists:   resourceIBMLbVpxCsPolicyExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"nad_controller_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NAD controller ID",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the content switching policy",
+			},
+			"rule": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Expression that selects the requests, e.g. HTTP.REQ.URL.STARTSWITH(\"/api\")",
+			},
+			"cs_vip_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"target_vip_name", "priority"},
+				Description:  "Name of the content switching virtual server the policy is bound to",
+			},
+			"target_vip_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"cs_vip_name"},
+				Description:  "Name of the load balancing virtual server that receives the selected requests",
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"cs_vip_name"},
+				Description:  "Priority of the policy on the content switching virtual server, lower values are evaluated first",
+			},
+		},
+	}
+}
+
+func resourceIBMLbVpxCsPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	nadcId := d.Get("nad_controller_id").(int)
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error creating content switching policy: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	if err := createVpxCsPolicy(nClient, d); err != nil {
+		return fmt.Errorf("Error creating content switching policy %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", nadcId, name))
+	log.Printf("[INFO] Netscaler VPX content switching policy ID: %s", d.Id())
+
+	return resourceIBMLbVpxCsPolicyRead(d, meta)
+}
+
+func resourceIBMLbVpxCsPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading content switching policy: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error reading content switching policy: %s", err)
+	}
+
+	d.Set("nad_controller_id", nadcId)
+	if err := readVpxCsPolicy(nClient, d, name); err != nil {
+		if isNitroNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading content switching policy %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxCsPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error updating content switching policy: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error updating content switching policy: %s", err)
+	}
+
+	if err := updateVpxCsPolicy(nClient, d); err != nil {
+		return fmt.Errorf("Error updating content switching policy %s: %s", name, err)
+	}
+
+	return resourceIBMLbVpxCsPolicyRead(d, meta)
+}
+
+func resourceIBMLbVpxCsPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting content switching policy: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error deleting content switching policy: %s", err)
+	}
+
+	if err := deleteVpxCsPolicy(nClient, d, name); err != nil {
+		return fmt.Errorf("Error deleting content switching policy %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxCsPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Error in exists: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return false, err
+	}
+
+	err = nClient.Get(&cspolicyRes{}, name)
+	if isNitroNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func createVpxCsPolicy(nClient *client.NitroClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+	err := nClient.Add(&cspolicyReq{
+		Cspolicy: &cspolicy{
+			Policyname: op.String(name),
+			Rule:       op.String(d.Get("rule").(string)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if csVipName, ok := d.GetOk("cs_vip_name"); ok {
+		err = bindVpxCsPolicy(nClient, d, csVipName.(string))
+		if err != nil {
+			// Rollback the policy creation
+			nClient.Delete(&cspolicyReq{}, name)
+		}
+	}
+	return err
+}
+
+func updateVpxCsPolicy(nClient *client.NitroClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+	if d.HasChange("rule") {
+		err := nClient.Update(&cspolicyReq{
+			Cspolicy: &cspolicy{
+				Policyname: op.String(name),
+				Rule:       op.String(d.Get("rule").(string)),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// A binding can not be changed, it is replaced
+	if d.HasChange("cs_vip_name") || d.HasChange("target_vip_name") || d.HasChange("priority") {
+		oldCsVipName, newCsVipName := d.GetChange("cs_vip_name")
+		if oldCsVipName.(string) != "" {
+			err := nClient.Delete(&csvserverCspolicyBindingReq{}, oldCsVipName.(string), "args=policyname:"+name)
+			if err != nil && !isNitroNotFound(err) {
+				return err
+			}
+		}
+		if newCsVipName.(string) != "" {
+			return bindVpxCsPolicy(nClient, d, newCsVipName.(string))
+		}
+	}
+	return nil
+}
+
+func bindVpxCsPolicy(nClient *client.NitroClient, d *schema.ResourceData, csVipName string) error {
+	return nClient.Add(&csvserverCspolicyBindingReq{
+		CsvserverCspolicyBinding: &csvserverCspolicyBinding{
+			Name:            op.String(csVipName),
+			Policyname:      op.String(d.Get("name").(string)),
+			Targetlbvserver: op.String(d.Get("target_vip_name").(string)),
+			Priority:        op.Int(d.Get("priority").(int)),
+		},
+	})
+}
+
+func readVpxCsPolicy(nClient *client.NitroClient, d *schema.ResourceData, name string) error {
+	res := cspolicyRes{}
+	if err := nClient.Get(&res, name); err != nil {
+		return err
+	}
+	if len(res.Cspolicy) == 0 {
+		return fmt.Errorf("No such resource %s", name)
+	}
+
+	d.Set("name", name)
+	if res.Cspolicy[0].Rule != nil {
+		d.Set("rule", *res.Cspolicy[0].Rule)
+	}
+
+	csVipName := d.Get("cs_vip_name").(string)
+	if csVipName == "" {
+		return nil
+	}
+	bindings := csvserverCspolicyBindingRes{}
+	err := nClient.Get(&bindings, csVipName)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+	for _, binding := range bindings.CsvserverCspolicyBinding {
+		if binding.Policyname != nil && *binding.Policyname == name {
+			if binding.Targetlbvserver != nil {
+				d.Set("target_vip_name", *binding.Targetlbvserver)
+			}
+			if binding.Priority != nil {
+				d.Set("priority", *binding.Priority)
+			}
+			return nil
+		}
+	}
+	// The binding was removed outside of terraform
+	d.Set("cs_vip_name", "")
+	d.Set("target_vip_name", "")
+	d.Set("priority", 0)
+	return nil
+}
+
+func deleteVpxCsPolicy(nClient *client.NitroClient, d *schema.ResourceData, name string) error {
+	if csVipName, ok := d.GetOk("cs_vip_name"); ok {
+		err := nClient.Delete(&csvserverCspolicyBindingReq{}, csVipName.(string), "args=policyname:"+name)
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+	}
+
+	err := nClient.Delete(&cspolicyReq{}, name)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMLbVpxCsPolicy_Basic(t *testing.T) {
+	name := fmt.Sprintf("tfcspolicy%s", acctest.RandString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMLbVpxCsPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMLbVpxCsPolicyConfig(name, `HTTP.REQ.URL.STARTSWITH(\"/api\")`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_cs_policy.testacc_policy", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_cs_policy.testacc_policy", "rule", `HTTP.REQ.URL.STARTSWITH("/api")`),
+				),
+			},
+			{
+				Config: testAccCheckIBMLbVpxCsPolicyConfig(name, `HTTP.REQ.HOSTNAME.EQ(\"api.example.com\")`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_cs_policy.testacc_policy", "rule", `HTTP.REQ.HOSTNAME.EQ("api.example.com")`),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMLbVpxCsPolicyDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(ClientSession).SoftLayerSession()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_lb_vpx_cs_policy" {
+			continue
+		}
+
+		nadcId, _ := strconv.Atoi(rs.Primary.Attributes["nad_controller_id"])
+		nClient, err := getVPXNitroClient(sess, nadcId)
+		if err != nil {
+			// The VPX is already gone
+			continue
+		}
+
+		err = nClient.Get(&cspolicyRes{}, rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Netscaler VPX content switching policy still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMLbVpxCsPolicyConfig(name, rule string) string {
+	return fmt.Sprintf(`
+resource "ibm_lb_vpx" "testacc_foobar_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "ibm_lb_vpx_cs_policy" "testacc_policy" {
+    nad_controller_id = "${ibm_lb_vpx.testacc_foobar_nadc.id}"
+    name = "%s"
+    rule = "%s"
+}
+`, name, rule)
+}
+
+func TestVpxCsPolicy(t *testing.T) {
+	stub := newNitroStub()
+	defer stub.close()
+	nClient := stub.client()
+
+	d := schema.TestResourceDataRaw(t, resourceIBMLbVpxCsPolicy().Schema, map[string]interface{}{
+		"nad_controller_id": 1,
+		"name":              "api",
+		"rule":              `HTTP.REQ.URL.STARTSWITH("/api")`,
+		"cs_vip_name":       "frontend",
+		"target_vip_name":   "api-backend",
+		"priority":          10,
+	})
+	assert.NilError(t, createVpxCsPolicy(nClient, d))
+	bindings := stub.find("csvserver_cspolicy_binding", "frontend", map[string]string{"policyname": "api"})
+	assert.Assert(t, is.Len(bindings, 1))
+	assert.Equal(t, "api-backend", bindings[0]["targetlbvserver"])
+
+	// Creating the same policy again fails and keeps the existing one
+	assert.Assert(t, createVpxCsPolicy(nClient, d) != nil)
+	assert.Assert(t, is.Len(stub.find("cspolicy", "api", nil), 1))
+
+	read := schema.TestResourceDataRaw(t, resourceIBMLbVpxCsPolicy().Schema, map[string]interface{}{
+		"cs_vip_name": "frontend",
+	})
+	assert.NilError(t, readVpxCsPolicy(nClient, read, "api"))
+	assert.Equal(t, `HTTP.REQ.URL.STARTSWITH("/api")`, read.Get("rule"))
+	assert.Equal(t, "api-backend", read.Get("target_vip_name"))
+	assert.Equal(t, 10, read.Get("priority"))
+
+	// A binding removed outside of terraform is dropped from the state
+	stub.objects["csvserver_cspolicy_binding"] = nil
+	assert.NilError(t, readVpxCsPolicy(nClient, read, "api"))
+	assert.Equal(t, "", read.Get("cs_vip_name"))
+	assert.Equal(t, 0, read.Get("priority"))
+
+	assert.NilError(t, deleteVpxCsPolicy(nClient, d, "api"))
+	assert.Assert(t, is.Len(stub.find("cspolicy", "api", nil), 0))
+	assert.Assert(t, isNitroNotFound(readVpxCsPolicy(nClient, read, "api")))
+}
@@ -0,0 +1,298 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/minsikl/netscaler-nitro-go/client"
+	dt "github.com/minsikl/netscaler-nitro-go/datatypes"
+	"github.com/minsikl/netscaler-nitro-go/op"
+)
+
+type lbmonitor struct {
+	Monitorname *string  `json:"monitorname,omitempty"`
+	Type        *string  `json:"type,omitempty"`
+	Interval    *int     `json:"interval,omitempty"`
+	Resptimeout *int     `json:"resptimeout,omitempty"`
+	Downtime    *int     `json:"downtime,omitempty"`
+	Retries     *int     `json:"retries,omitempty"`
+	Destport    *int     `json:"destport,omitempty"`
+	Httprequest *string  `json:"httprequest,omitempty"`
+	Respcode    []string `json:"respcode,omitempty"`
+	Send        *string  `json:"send,omitempty"`
+	Recv        *string  `json:"recv,omitempty"`
+	Secure      *string  `json:"secure,omitempty"`
+}
+
+type lbmonitorReq struct {
+	Lbmonitor *lbmonitor `json:"lbmonitor,omitempty"`
+}
+
+type lbmonitorRes struct {
+	dt.BaseRes
+	Lbmonitor []lbmonitor `json:"lbmonitor,omitempty"`
+}
+
+func resourceIBMLbVpxMonitor() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMLbVpxMonitorCreate,
+		Read:     resourceIBMLbVpxMonitorRead,
+		Update:   resourceIBMLbVpxMonitorUpdate,
+		Delete:   resourceIBMLbVpxMonitorDelete,
+		Exists:   resourceIBMLbVpxMonitorExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"nad_controller_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NAD controller ID",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the monitor",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Type of the monitor, e.g. PING, TCP, HTTP, TCP-ECV or HTTP-ECV",
+			},
+			"interval": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     5,
+				Description: "Time in seconds between two probes",
+			},
+			"response_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     2,
+				Description: "Time in seconds to wait for a response, must be less than the interval",
+			},
+			"down_time": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     30,
+				Description: "Time in seconds to wait before probing a service marked DOWN",
+			},
+			"retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Number of failed probes before a service is marked DOWN",
+			},
+			"destination_port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Port the probes are sent to, the port of the service by default",
+			},
+			"http_request": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "HTTP request of HTTP monitors, e.g. HEAD /health",
+			},
+			"response_codes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "HTTP response codes or ranges that mark the service UP, e.g. 200 or 200-299",
+			},
+			"send": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Data sent by ECV monitors",
+			},
+			"receive": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Data expected in the response of ECV monitors",
+			},
+			"secure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send the probes over TLS",
+			},
+		},
+	}
+}
+
+func resourceIBMLbVpxMonitorCreate(d *schema.ResourceData, meta interface{}) error {
+	nadcId := d.Get("nad_controller_id").(int)
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error creating monitor: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	if err := nClient.Add(&lbmonitorReq{Lbmonitor: expandVpxMonitor(d)}); err != nil {
+		return fmt.Errorf("Error creating monitor %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", nadcId, name))
+	log.Printf("[INFO] Netscaler VPX monitor ID: %s", d.Id())
+
+	return resourceIBMLbVpxMonitorRead(d, meta)
+}
+
+func resourceIBMLbVpxMonitorRead(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading monitor: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error reading monitor: %s", err)
+	}
+
+	d.Set("nad_controller_id", nadcId)
+	if err := readVpxMonitor(nClient, d, name); err != nil {
+		if isNitroNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading monitor %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error updating monitor: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error updating monitor: %s", err)
+	}
+
+	if err := nClient.Update(&lbmonitorReq{Lbmonitor: expandVpxMonitor(d)}); err != nil {
+		return fmt.Errorf("Error updating monitor %s: %s", name, err)
+	}
+
+	return resourceIBMLbVpxMonitorRead(d, meta)
+}
+
+func resourceIBMLbVpxMonitorDelete(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting monitor: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error deleting monitor: %s", err)
+	}
+
+	err = nClient.Delete(&lbmonitorReq{}, name, "args=type:"+d.Get("type").(string))
+	if err != nil && !isNitroNotFound(err) {
+		return fmt.Errorf("Error deleting monitor %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxMonitorExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Error in exists: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return false, err
+	}
+
+	err = nClient.Get(&lbmonitorRes{}, name)
+	if isNitroNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func expandVpxMonitor(d *schema.ResourceData) *lbmonitor {
+	monitor := &lbmonitor{
+		Monitorname: op.String(d.Get("name").(string)),
+		Type:        op.String(d.Get("type").(string)),
+		Interval:    op.Int(d.Get("interval").(int)),
+		Resptimeout: op.Int(d.Get("response_timeout").(int)),
+		Downtime:    op.Int(d.Get("down_time").(int)),
+		Retries:     op.Int(d.Get("retries").(int)),
+		Secure:      op.String("NO"),
+	}
+	if d.Get("secure").(bool) {
+		monitor.Secure = op.String("YES")
+	}
+	if v, ok := d.GetOk("destination_port"); ok {
+		monitor.Destport = op.Int(v.(int))
+	}
+	if v, ok := d.GetOk("http_request"); ok {
+		monitor.Httprequest = op.String(v.(string))
+	}
+	if v, ok := d.GetOk("response_codes"); ok {
+		monitor.Respcode = expandStringList(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("send"); ok {
+		monitor.Send = op.String(v.(string))
+	}
+	if v, ok := d.GetOk("receive"); ok {
+		monitor.Recv = op.String(v.(string))
+	}
+	return monitor
+}
+
+func readVpxMonitor(nClient *client.NitroClient, d *schema.ResourceData, name string) error {
+	res := lbmonitorRes{}
+	if err := nClient.Get(&res, name); err != nil {
+		return err
+	}
+	if len(res.Lbmonitor) == 0 {
+		return fmt.Errorf("No such resource %s", name)
+	}
+	monitor := res.Lbmonitor[0]
+
+	d.Set("name", name)
+	if monitor.Type != nil {
+		d.Set("type", *monitor.Type)
+	}
+	if monitor.Interval != nil {
+		d.Set("interval", *monitor.Interval)
+	}
+	if monitor.Resptimeout != nil {
+		d.Set("response_timeout", *monitor.Resptimeout)
+	}
+	if monitor.Downtime != nil {
+		d.Set("down_time", *monitor.Downtime)
+	}
+	if monitor.Retries != nil {
+		d.Set("retries", *monitor.Retries)
+	}
+	if monitor.Destport != nil {
+		d.Set("destination_port", *monitor.Destport)
+	}
+	if monitor.Httprequest != nil {
+		d.Set("http_request", *monitor.Httprequest)
+	}
+	d.Set("response_codes", monitor.Respcode)
+	if monitor.Send != nil {
+		d.Set("send", *monitor.Send)
+	}
+	if monitor.Recv != nil {
+		d.Set("receive", *monitor.Recv)
+	}
+	d.Set("secure", monitor.Secure != nil && *monitor.Secure == "YES")
+	return nil
+}
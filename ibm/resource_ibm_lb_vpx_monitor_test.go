@@ -0,0 +1,127 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMLbVpxMonitor_Basic(t *testing.T) {
+	name := fmt.Sprintf("tfmonitor%s", acctest.RandString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMLbVpxMonitorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMLbVpxMonitorConfig(name, 5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_monitor.testacc_monitor", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_monitor.testacc_monitor", "type", "HTTP"),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_monitor.testacc_monitor", "interval", "5"),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_monitor.testacc_monitor", "http_request", "HEAD /health"),
+				),
+			},
+			{
+				Config: testAccCheckIBMLbVpxMonitorConfig(name, 10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_monitor.testacc_monitor", "interval", "10"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMLbVpxMonitorDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(ClientSession).SoftLayerSession()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_lb_vpx_monitor" {
+			continue
+		}
+
+		nadcId, _ := strconv.Atoi(rs.Primary.Attributes["nad_controller_id"])
+		nClient, err := getVPXNitroClient(sess, nadcId)
+		if err != nil {
+			// The VPX is already gone
+			continue
+		}
+
+		err = nClient.Get(&lbmonitorRes{}, rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Netscaler VPX monitor still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMLbVpxMonitorConfig(name string, interval int) string {
+	return fmt.Sprintf(`
+resource "ibm_lb_vpx" "testacc_foobar_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "ibm_lb_vpx_monitor" "testacc_monitor" {
+    nad_controller_id = "${ibm_lb_vpx.testacc_foobar_nadc.id}"
+    name = "%s"
+    type = "HTTP"
+    interval = %d
+    http_request = "HEAD /health"
+    response_codes = ["200-299"]
+}
+`, name, interval)
+}
+
+func TestVpxMonitor(t *testing.T) {
+	stub := newNitroStub()
+	defer stub.close()
+	nClient := stub.client()
+
+	d := schema.TestResourceDataRaw(t, resourceIBMLbVpxMonitor().Schema, map[string]interface{}{
+		"nad_controller_id": 1,
+		"name":              "health",
+		"type":              "HTTP",
+		"http_request":      "HEAD /health",
+		"response_codes":    []interface{}{"200-299"},
+		"secure":            true,
+	})
+	assert.NilError(t, nClient.Add(&lbmonitorReq{Lbmonitor: expandVpxMonitor(d)}))
+	monitors := stub.find("lbmonitor", "health", nil)
+	assert.Assert(t, is.Len(monitors, 1))
+	assert.Equal(t, "YES", monitors[0]["secure"])
+	assert.Assert(t, is.Nil(monitors[0]["send"]))
+
+	read := schema.TestResourceDataRaw(t, resourceIBMLbVpxMonitor().Schema, map[string]interface{}{})
+	assert.NilError(t, readVpxMonitor(nClient, read, "health"))
+	assert.Equal(t, "HTTP", read.Get("type"))
+	assert.Equal(t, 5, read.Get("interval"))
+	assert.Equal(t, 2, read.Get("response_timeout"))
+	assert.Equal(t, "HEAD /health", read.Get("http_request"))
+	assert.DeepEqual(t, []interface{}{"200-299"}, read.Get("response_codes"))
+	assert.Equal(t, true, read.Get("secure"))
+
+	// Monitors are deleted by name and type
+	assert.Assert(t, nClient.Delete(&lbmonitorReq{}, "health", "args=type:TCP") != nil)
+	assert.NilError(t, nClient.Delete(&lbmonitorReq{}, "health", "args=type:HTTP"))
+	assert.Assert(t, isNitroNotFound(readVpxMonitor(nClient, read, "health")))
+}
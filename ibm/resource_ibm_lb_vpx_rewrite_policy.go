@@ -0,0 +1,420 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	"github.com/minsikl/netscaler-nitro-go/client"
+	dt "github.com/minsikl/netscaler-nitro-go/datatypes"
+	"github.com/minsikl/netscaler-nitro-go/op"
+)
+
+type rewriteaction struct {
+	Name              *string `json:"name,omitempty"`
+	Type              *string `json:"type,omitempty"`
+	Target            *string `json:"target,omitempty"`
+	Stringbuilderexpr *string `json:"stringbuilderexpr,omitempty"`
+}
+
+type rewriteactionReq struct {
+	Rewriteaction *rewriteaction `json:"rewriteaction,omitempty"`
+}
+
+type rewriteactionRes struct {
+	dt.BaseRes
+	Rewriteaction []rewriteaction `json:"rewriteaction,omitempty"`
+}
+
+type rewritepolicy struct {
+	Name   *string `json:"name,omitempty"`
+	Rule   *string `json:"rule,omitempty"`
+	Action *string `json:"action,omitempty"`
+}
+
+type rewritepolicyReq struct {
+	Rewritepolicy *rewritepolicy `json:"rewritepolicy,omitempty"`
+}
+
+type rewritepolicyRes struct {
+	dt.BaseRes
+	Rewritepolicy []rewritepolicy `json:"rewritepolicy,omitempty"`
+}
+
+type lbvserverRewritepolicyBinding struct {
+	Name                   *string `json:"name,omitempty"`
+	Policyname             *string `json:"policyname,omitempty"`
+	Priority               *int    `json:"priority,omitempty"`
+	Bindpoint              *string `json:"bindpoint,omitempty"`
+	Gotopriorityexpression *string `json:"gotopriorityexpression,omitempty"`
+}
+
+type lbvserverRewritepolicyBindingReq struct {
+	LbvserverRewritepolicyBinding *lbvserverRewritepolicyBinding `json:"lbvserver_rewritepolicy_binding,omitempty"`
+}
+
+type lbvserverRewritepolicyBindingRes struct {
+	dt.BaseRes
+	LbvserverRewritepolicyBinding []lbvserverRewritepolicyBinding `json:"lbvserver_rewritepolicy_binding,omitempty"`
+}
+
+func resourceIBMLbVpxRewritePolicy() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMLbVpxRewritePolicyCreate,
+		Read:     resourceIBMLbVpxRewritePolicyRead,
+		Update:   resourceIBMLbVpxRewritePolicyUpdate,
+		Delete:   resourceIBMLbVpxRewritePolicyDelete,
+		Exists:   resourceIBMLbVpxRewritePolicyExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"nad_controller_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NAD controller ID",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the rewrite policy",
+			},
+			"rule": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Expression that selects the requests or responses to rewrite, e.g. HTTP.REQ.URL.STARTSWITH(\"/old\")",
+			},
+			"action_type": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Type of the rewrite action, e.g. replace, insert_http_header or delete_http_header",
+			},
+			"action_target": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Expression of the part of the request or response to rewrite, or the name of the header to insert or delete",
+			},
+			"action_expression": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Expression of the new value, required by the replace and insert action types",
+			},
+			"vip_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"priority"},
+				Description:  "Name of the load balancing virtual server the policy is bound to",
+			},
+			"priority": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"vip_name"},
+				Description:  "Priority of the policy on the virtual server, lower values are evaluated first",
+			},
+			"bind_point": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "REQUEST",
+				ValidateFunc: validation.StringInSlice([]string{"REQUEST", "RESPONSE"}, false),
+				Description:  "Whether the policy rewrites the requests or the responses of the virtual server",
+			},
+			"action_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the rewrite action of the policy",
+			},
+		},
+	}
+}
+
+// vpxRewriteActionName returns the name of the rewrite action managed with the policy
+func vpxRewriteActionName(policyName string) string {
+	return policyName + "_action"
+}
+
+func resourceIBMLbVpxRewritePolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	nadcId := d.Get("nad_controller_id").(int)
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error creating rewrite policy: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	if err := createVpxRewritePolicy(nClient, d); err != nil {
+		return fmt.Errorf("Error creating rewrite policy %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", nadcId, name))
+	log.Printf("[INFO] Netscaler VPX rewrite policy ID: %s", d.Id())
+
+	return resourceIBMLbVpxRewritePolicyRead(d, meta)
+}
+
+func resourceIBMLbVpxRewritePolicyRead(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading rewrite policy: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error reading rewrite policy: %s", err)
+	}
+
+	d.Set("nad_controller_id", nadcId)
+	if err := readVpxRewritePolicy(nClient, d, name); err != nil {
+		if isNitroNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading rewrite policy %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxRewritePolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error updating rewrite policy: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error updating rewrite policy: %s", err)
+	}
+
+	if err := updateVpxRewritePolicy(nClient, d); err != nil {
+		return fmt.Errorf("Error updating rewrite policy %s: %s", name, err)
+	}
+
+	return resourceIBMLbVpxRewritePolicyRead(d, meta)
+}
+
+func resourceIBMLbVpxRewritePolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting rewrite policy: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error deleting rewrite policy: %s", err)
+	}
+
+	if err := deleteVpxRewritePolicy(nClient, d, name); err != nil {
+		return fmt.Errorf("Error deleting rewrite policy %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxRewritePolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Error in exists: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return false, err
+	}
+
+	err = nClient.Get(&rewritepolicyRes{}, name)
+	if isNitroNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func createVpxRewritePolicy(nClient *client.NitroClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+	actionName := vpxRewriteActionName(name)
+	action := &rewriteaction{
+		Name:   op.String(actionName),
+		Type:   op.String(d.Get("action_type").(string)),
+		Target: op.String(d.Get("action_target").(string)),
+	}
+	if expression, ok := d.GetOk("action_expression"); ok {
+		action.Stringbuilderexpr = op.String(expression.(string))
+	}
+	if err := nClient.Add(&rewriteactionReq{Rewriteaction: action}); err != nil {
+		return err
+	}
+
+	err := nClient.Add(&rewritepolicyReq{
+		Rewritepolicy: &rewritepolicy{
+			Name:   op.String(name),
+			Rule:   op.String(d.Get("rule").(string)),
+			Action: op.String(actionName),
+		},
+	})
+	if err != nil {
+		// Rollback the action creation
+		nClient.Delete(&rewriteactionReq{}, actionName)
+		return err
+	}
+
+	if vipName, ok := d.GetOk("vip_name"); ok {
+		err = bindVpxRewritePolicy(nClient, d, vipName.(string))
+		if err != nil {
+			// Rollback the policy and action creation
+			nClient.Delete(&rewritepolicyReq{}, name)
+			nClient.Delete(&rewriteactionReq{}, actionName)
+		}
+	}
+	return err
+}
+
+func updateVpxRewritePolicy(nClient *client.NitroClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+	if d.HasChange("action_target") || d.HasChange("action_expression") {
+		action := &rewriteaction{
+			Name:   op.String(d.Get("action_name").(string)),
+			Target: op.String(d.Get("action_target").(string)),
+		}
+		if expression, ok := d.GetOk("action_expression"); ok {
+			action.Stringbuilderexpr = op.String(expression.(string))
+		}
+		err := nClient.Update(&rewriteactionReq{Rewriteaction: action})
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("rule") {
+		err := nClient.Update(&rewritepolicyReq{
+			Rewritepolicy: &rewritepolicy{
+				Name: op.String(name),
+				Rule: op.String(d.Get("rule").(string)),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// A binding can not be changed, it is replaced
+	if d.HasChange("vip_name") || d.HasChange("priority") || d.HasChange("bind_point") {
+		oldVipName, newVipName := d.GetChange("vip_name")
+		oldBindPoint, _ := d.GetChange("bind_point")
+		if oldVipName.(string) != "" {
+			err := unbindVpxRewritePolicy(nClient, oldVipName.(string), name, oldBindPoint.(string))
+			if err != nil && !isNitroNotFound(err) {
+				return err
+			}
+		}
+		if newVipName.(string) != "" {
+			return bindVpxRewritePolicy(nClient, d, newVipName.(string))
+		}
+	}
+	return nil
+}
+
+func bindVpxRewritePolicy(nClient *client.NitroClient, d *schema.ResourceData, vipName string) error {
+	return nClient.Add(&lbvserverRewritepolicyBindingReq{
+		LbvserverRewritepolicyBinding: &lbvserverRewritepolicyBinding{
+			Name:                   op.String(vipName),
+			Policyname:             op.String(d.Get("name").(string)),
+			Priority:               op.Int(d.Get("priority").(int)),
+			Bindpoint:              op.String(d.Get("bind_point").(string)),
+			Gotopriorityexpression: op.String("END"),
+		},
+	})
+}
+
+func unbindVpxRewritePolicy(nClient *client.NitroClient, vipName, name, bindPoint string) error {
+	return nClient.Delete(&lbvserverRewritepolicyBindingReq{}, vipName, "args=policyname:"+name+",bindpoint:"+bindPoint)
+}
+
+func readVpxRewritePolicy(nClient *client.NitroClient, d *schema.ResourceData, name string) error {
+	res := rewritepolicyRes{}
+	if err := nClient.Get(&res, name); err != nil {
+		return err
+	}
+	if len(res.Rewritepolicy) == 0 {
+		return fmt.Errorf("No such resource %s", name)
+	}
+
+	d.Set("name", name)
+	policy := res.Rewritepolicy[0]
+	if policy.Rule != nil {
+		d.Set("rule", *policy.Rule)
+	}
+
+	actionName := vpxRewriteActionName(name)
+	if policy.Action != nil {
+		actionName = *policy.Action
+	}
+	d.Set("action_name", actionName)
+	actions := rewriteactionRes{}
+	if err := nClient.Get(&actions, actionName); err != nil {
+		return err
+	}
+	if len(actions.Rewriteaction) > 0 {
+		action := actions.Rewriteaction[0]
+		if action.Type != nil {
+			d.Set("action_type", *action.Type)
+		}
+		if action.Target != nil {
+			d.Set("action_target", *action.Target)
+		}
+		if action.Stringbuilderexpr != nil {
+			d.Set("action_expression", *action.Stringbuilderexpr)
+		}
+	}
+
+	vipName := d.Get("vip_name").(string)
+	if vipName == "" {
+		return nil
+	}
+	bindings := lbvserverRewritepolicyBindingRes{}
+	err := nClient.Get(&bindings, vipName)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+	for _, binding := range bindings.LbvserverRewritepolicyBinding {
+		if binding.Policyname != nil && *binding.Policyname == name {
+			if binding.Priority != nil {
+				d.Set("priority", *binding.Priority)
+			}
+			if binding.Bindpoint != nil {
+				d.Set("bind_point", *binding.Bindpoint)
+			}
+			return nil
+		}
+	}
+	// The binding was removed outside of terraform
+	d.Set("vip_name", "")
+	d.Set("priority", 0)
+	return nil
+}
+
+func deleteVpxRewritePolicy(nClient *client.NitroClient, d *schema.ResourceData, name string) error {
+	if vipName, ok := d.GetOk("vip_name"); ok {
+		err := unbindVpxRewritePolicy(nClient, vipName.(string), name, d.Get("bind_point").(string))
+		if err != nil && !isNitroNotFound(err) {
+			return err
+		}
+	}
+
+	err := nClient.Delete(&rewritepolicyReq{}, name)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+
+	actionName := d.Get("action_name").(string)
+	if actionName == "" {
+		actionName = vpxRewriteActionName(name)
+	}
+	err = nClient.Delete(&rewriteactionReq{}, actionName)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+	return nil
+}
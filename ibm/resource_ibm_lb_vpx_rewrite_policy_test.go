@@ -0,0 +1,146 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMLbVpxRewritePolicy_Basic(t *testing.T) {
+	name := fmt.Sprintf("tfrewrite%s", acctest.RandString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMLbVpxRewritePolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMLbVpxRewritePolicyConfig(name, `\"v1\"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_rewrite_policy.testacc_policy", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_rewrite_policy.testacc_policy", "action_name", name+"_action"),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_rewrite_policy.testacc_policy", "action_expression", `"v1"`),
+				),
+			},
+			{
+				Config: testAccCheckIBMLbVpxRewritePolicyConfig(name, `\"v2\"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_rewrite_policy.testacc_policy", "action_expression", `"v2"`),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMLbVpxRewritePolicyDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(ClientSession).SoftLayerSession()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_lb_vpx_rewrite_policy" {
+			continue
+		}
+
+		nadcId, _ := strconv.Atoi(rs.Primary.Attributes["nad_controller_id"])
+		nClient, err := getVPXNitroClient(sess, nadcId)
+		if err != nil {
+			// The VPX is already gone
+			continue
+		}
+
+		err = nClient.Get(&rewritepolicyRes{}, rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Netscaler VPX rewrite policy still exists")
+		}
+		err = nClient.Get(&rewriteactionRes{}, rs.Primary.Attributes["action_name"])
+		if err == nil {
+			return fmt.Errorf("Netscaler VPX rewrite action still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMLbVpxRewritePolicyConfig(name, version string) string {
+	return fmt.Sprintf(`
+resource "ibm_lb_vpx" "testacc_foobar_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "ibm_lb_vpx_rewrite_policy" "testacc_policy" {
+    nad_controller_id = "${ibm_lb_vpx.testacc_foobar_nadc.id}"
+    name = "%s"
+    rule = "true"
+    action_type = "insert_http_header"
+    action_target = "X-Api-Version"
+    action_expression = "%s"
+}
+`, name, version)
+}
+
+func TestVpxRewritePolicy(t *testing.T) {
+	stub := newNitroStub()
+	defer stub.close()
+	nClient := stub.client()
+
+	d := schema.TestResourceDataRaw(t, resourceIBMLbVpxRewritePolicy().Schema, map[string]interface{}{
+		"nad_controller_id": 1,
+		"name":              "old-api",
+		"rule":              `HTTP.REQ.URL.STARTSWITH("/old")`,
+		"action_type":       "replace",
+		"action_target":     "HTTP.REQ.URL.PATH",
+		"action_expression": `"/api"`,
+		"vip_name":          "frontend",
+		"priority":          10,
+	})
+	assert.NilError(t, createVpxRewritePolicy(nClient, d))
+	actions := stub.find("rewriteaction", "old-api_action", nil)
+	assert.Assert(t, is.Len(actions, 1))
+	assert.Equal(t, "HTTP.REQ.URL.PATH", actions[0]["target"])
+	assert.Equal(t, "old-api_action", stub.find("rewritepolicy", "old-api", nil)[0]["action"])
+	bindings := stub.find("lbvserver_rewritepolicy_binding", "frontend", map[string]string{"policyname": "old-api"})
+	assert.Assert(t, is.Len(bindings, 1))
+	assert.Equal(t, "REQUEST", bindings[0]["bindpoint"])
+
+	// Creating the same policy again fails and keeps the existing one
+	assert.Assert(t, createVpxRewritePolicy(nClient, d) != nil)
+	assert.Assert(t, is.Len(stub.find("rewriteaction", "old-api_action", nil), 1))
+	assert.Assert(t, is.Len(stub.find("rewritepolicy", "old-api", nil), 1))
+
+	read := schema.TestResourceDataRaw(t, resourceIBMLbVpxRewritePolicy().Schema, map[string]interface{}{
+		"vip_name": "frontend",
+	})
+	assert.NilError(t, readVpxRewritePolicy(nClient, read, "old-api"))
+	assert.Equal(t, `HTTP.REQ.URL.STARTSWITH("/old")`, read.Get("rule"))
+	assert.Equal(t, "replace", read.Get("action_type"))
+	assert.Equal(t, `"/api"`, read.Get("action_expression"))
+	assert.Equal(t, "old-api_action", read.Get("action_name"))
+	assert.Equal(t, 10, read.Get("priority"))
+
+	// A binding removed outside of terraform is dropped from the state
+	stub.objects["lbvserver_rewritepolicy_binding"] = nil
+	assert.NilError(t, readVpxRewritePolicy(nClient, read, "old-api"))
+	assert.Equal(t, "", read.Get("vip_name"))
+	assert.Equal(t, 0, read.Get("priority"))
+
+	assert.NilError(t, deleteVpxRewritePolicy(nClient, read, "old-api"))
+	assert.Assert(t, is.Len(stub.find("rewritepolicy", "old-api", nil), 0))
+	assert.Assert(t, is.Len(stub.find("rewriteaction", "old-api_action", nil), 0))
+	assert.Assert(t, isNitroNotFound(readVpxRewritePolicy(nClient, read, "old-api")))
+}
@@ -0,0 +1,502 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"github.com/minsikl/netscaler-nitro-go/client"
+	dt "github.com/minsikl/netscaler-nitro-go/datatypes"
+	"github.com/minsikl/netscaler-nitro-go/op"
+)
+
+// NITRO resources that are not part of netscaler-nitro-go. The NITRO client derives the
+// resource name from the type name, e.g. sslprofileReq is sent to /nitro/v1/config/sslprofile.
+
+type sslprofile struct {
+	Name           *string `json:"name,omitempty"`
+	Sslprofiletype *string `json:"sslprofiletype,omitempty"`
+	Ssl3           *string `json:"ssl3,omitempty"`
+	Tls1           *string `json:"tls1,omitempty"`
+	Tls11          *string `json:"tls11,omitempty"`
+	Tls12          *string `json:"tls12,omitempty"`
+	Sessreuse      *string `json:"sessreuse,omitempty"`
+	Sesstimeout    *int    `json:"sesstimeout,omitempty"`
+	Hsts           *string `json:"hsts,omitempty"`
+	Maxage         *int    `json:"maxage,omitempty"`
+	Denysslreneg   *string `json:"denysslreneg,omitempty"`
+}
+
+type sslprofileReq struct {
+	Sslprofile *sslprofile `json:"sslprofile,omitempty"`
+}
+
+type sslprofileRes struct {
+	dt.BaseRes
+	Sslprofile []sslprofile `json:"sslprofile,omitempty"`
+}
+
+type sslprofileSslcipherBinding struct {
+	Name           *string `json:"name,omitempty"`
+	Ciphername     *string `json:"ciphername,omitempty"`
+	Cipherpriority *int    `json:"cipherpriority,omitempty"`
+}
+
+type sslprofileSslcipherBindingReq struct {
+	SslprofileSslcipherBinding *sslprofileSslcipherBinding `json:"sslprofile_sslcipher_binding,omitempty"`
+}
+
+type sslprofileSslcipherBindingRes struct {
+	dt.BaseRes
+	SslprofileSslcipherBinding []sslprofileSslcipherBinding `json:"sslprofile_sslcipher_binding,omitempty"`
+}
+
+type sslvserver struct {
+	Vservername *string     `json:"vservername,omitempty"`
+	Sslprofile  interface{} `json:"sslprofile,omitempty"`
+}
+
+type sslvserverReq struct {
+	Sslvserver *sslvserver `json:"sslvserver,omitempty"`
+}
+
+type sslvserverRes struct {
+	dt.BaseRes
+	Sslvserver []sslvserver `json:"sslvserver,omitempty"`
+}
+
+// vpxSSLProtocols maps the protocol names to the sslprofile parameters
+var vpxSSLProtocols = map[string]func(*sslprofile) **string{
+	"SSLv3":   func(p *sslprofile) **string { return &p.Ssl3 },
+	"TLSv1":   func(p *sslprofile) **string { return &p.Tls1 },
+	"TLSv1.1": func(p *sslprofile) **string { return &p.Tls11 },
+	"TLSv1.2": func(p *sslprofile) **string { return &p.Tls12 },
+}
+
+func resourceIBMLbVpxSslProfile() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMLbVpxSslProfileCreate,
+		Read:     resourceIBMLbVpxSslProfileRead,
+		Update:   resourceIBMLbVpxSslProfileUpdate,
+		Delete:   resourceIBMLbVpxSslProfileDelete,
+		Exists:   resourceIBMLbVpxSslProfileExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"nad_controller_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "NAD controller ID",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the SSL profile",
+			},
+			"profile_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "FrontEnd",
+				ValidateFunc: validation.StringInSlice([]string{"FrontEnd", "BackEnd"}, false),
+				Description:  "Type of the SSL profile: FrontEnd or BackEnd",
+			},
+			"protocols": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"SSLv3", "TLSv1", "TLSv1.1", "TLSv1.2"}, false),
+				},
+				Set:         schema.HashString,
+				Description: "The enabled protocols",
+			},
+			"ciphers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The bound ciphers or cipher groups, in priority order",
+			},
+			"session_reuse": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Reuse SSL sessions",
+			},
+			"session_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Session timeout in seconds",
+			},
+			"hsts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Send the Strict-Transport-Security header",
+			},
+			"hsts_max_age": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "max-age of the Strict-Transport-Security header in seconds",
+			},
+			"deny_ssl_renegotiation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"NO", "FRONTEND_CLIENT", "FRONTEND_CLIENTSERVER", "ALL", "NONSECURE"}, false),
+				Description:  "Which SSL renegotiations are denied",
+			},
+			"vip_names": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Names of the SSL virtual servers that use the profile",
+			},
+		},
+	}
+}
+
+func resourceIBMLbVpxSslProfileCreate(d *schema.ResourceData, meta interface{}) error {
+	nadcId := d.Get("nad_controller_id").(int)
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error creating SSL profile: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	if err := createVpxSslProfile(nClient, d); err != nil {
+		return fmt.Errorf("Error creating SSL profile %s: %s", name, err)
+	}
+
+	d.SetId(fmt.Sprintf("%d:%s", nadcId, name))
+	log.Printf("[INFO] Netscaler VPX SSL profile ID: %s", d.Id())
+
+	return resourceIBMLbVpxSslProfileRead(d, meta)
+}
+
+func resourceIBMLbVpxSslProfileRead(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error reading SSL profile: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error reading SSL profile: %s", err)
+	}
+
+	d.Set("nad_controller_id", nadcId)
+	if err := readVpxSslProfile(nClient, d, name); err != nil {
+		if isNitroNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error reading SSL profile %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxSslProfileUpdate(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error updating SSL profile: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error updating SSL profile: %s", err)
+	}
+
+	if err := updateVpxSslProfile(nClient, d); err != nil {
+		return fmt.Errorf("Error updating SSL profile %s: %s", name, err)
+	}
+
+	return resourceIBMLbVpxSslProfileRead(d, meta)
+}
+
+func resourceIBMLbVpxSslProfileDelete(d *schema.ResourceData, meta interface{}) error {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return fmt.Errorf("Error deleting SSL profile: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return fmt.Errorf("Error deleting SSL profile: %s", err)
+	}
+
+	if err := deleteVpxSslProfile(nClient, d, name); err != nil {
+		return fmt.Errorf("Error deleting SSL profile %s: %s", name, err)
+	}
+	return nil
+}
+
+func resourceIBMLbVpxSslProfileExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	nadcId, name, err := parseId(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Error in exists: %s", err)
+	}
+
+	nClient, err := getVPXNitroClient(meta.(ClientSession).SoftLayerSession(), nadcId)
+	if err != nil {
+		return false, err
+	}
+
+	err = nClient.Get(&sslprofileRes{}, name)
+	if isNitroNotFound(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func createVpxSslProfile(nClient *client.NitroClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+	err := nClient.Add(&sslprofileReq{
+		Sslprofile: &sslprofile{
+			Name:           op.String(name),
+			Sslprofiletype: op.String(d.Get("profile_type").(string)),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	err = updateVpxSslProfile(nClient, d)
+	if err != nil {
+		// Rollback the profile creation
+		nClient.Delete(&sslprofileReq{}, name)
+	}
+	return err
+}
+
+func updateVpxSslProfile(nClient *client.NitroClient, d *schema.ResourceData) error {
+	name := d.Get("name").(string)
+	profile := &sslprofile{
+		Name:      op.String(name),
+		Sessreuse: op.String(nitroEnabled(d.Get("session_reuse").(bool))),
+		Hsts:      op.String(nitroEnabled(d.Get("hsts").(bool))),
+	}
+	protocols := d.Get("protocols").(*schema.Set)
+	for protocol, field := range vpxSSLProtocols {
+		*field(profile) = op.String(nitroEnabled(protocols.Contains(protocol)))
+	}
+	if v, ok := d.GetOk("session_timeout"); ok {
+		profile.Sesstimeout = op.Int(v.(int))
+	}
+	if v, ok := d.GetOk("hsts_max_age"); ok {
+		profile.Maxage = op.Int(v.(int))
+	}
+	if v, ok := d.GetOk("deny_ssl_renegotiation"); ok {
+		profile.Denysslreneg = op.String(v.(string))
+	}
+	if err := nClient.Update(&sslprofileReq{Sslprofile: profile}); err != nil {
+		return err
+	}
+
+	if _, ok := d.GetOk("ciphers"); ok && d.HasChange("ciphers") {
+		if err := bindVpxSslProfileCiphers(nClient, name, expandStringList(d.Get("ciphers").([]interface{}))); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("vip_names") {
+		o, n := d.GetChange("vip_names")
+		for _, vip := range o.(*schema.Set).Difference(n.(*schema.Set)).List() {
+			if err := unsetVpxSslProfile(nClient, vip.(string)); err != nil {
+				return err
+			}
+		}
+		for _, vip := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+			err := nClient.Update(&sslvserverReq{
+				Sslvserver: &sslvserver{Vservername: op.String(vip.(string)), Sslprofile: name},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bindVpxSslProfileCiphers binds the ciphers with their priorities before the stale bindings are
+// removed, so that the profile always has a cipher.
+func bindVpxSslProfileCiphers(nClient *client.NitroClient, name string, ciphers []string) error {
+	res := sslprofileSslcipherBindingRes{}
+	if err := nClient.Get(&res, name); err != nil {
+		return err
+	}
+	bound := map[string]int{}
+	for _, binding := range res.SslprofileSslcipherBinding {
+		if binding.Ciphername != nil && binding.Cipherpriority != nil {
+			bound[*binding.Ciphername] = *binding.Cipherpriority
+		}
+	}
+
+	wanted := map[string]int{}
+	for i, cipher := range ciphers {
+		wanted[cipher] = i + 1
+	}
+
+	for cipher, priority := range bound {
+		if wanted[cipher] != priority && wanted[cipher] != 0 {
+			// The priority of a binding can not be changed
+			if err := nClient.Delete(&sslprofileSslcipherBindingReq{}, name, "args=ciphername:"+cipher); err != nil {
+				return err
+			}
+			delete(bound, cipher)
+		}
+	}
+	for _, cipher := range ciphers {
+		if _, ok := bound[cipher]; ok {
+			continue
+		}
+		err := nClient.Add(&sslprofileSslcipherBindingReq{
+			SslprofileSslcipherBinding: &sslprofileSslcipherBinding{
+				Name:           op.String(name),
+				Ciphername:     op.String(cipher),
+				Cipherpriority: op.Int(wanted[cipher]),
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for cipher := range bound {
+		if wanted[cipher] == 0 {
+			if err := nClient.Delete(&sslprofileSslcipherBindingReq{}, name, "args=ciphername:"+cipher); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readVpxSslProfile(nClient *client.NitroClient, d *schema.ResourceData, name string) error {
+	res := sslprofileRes{}
+	if err := nClient.Get(&res, name); err != nil {
+		return err
+	}
+	if len(res.Sslprofile) == 0 {
+		return fmt.Errorf("No such resource %s", name)
+	}
+	profile := res.Sslprofile[0]
+
+	protocols := []string{}
+	for protocol, field := range vpxSSLProtocols {
+		if value := *field(&profile); value != nil && *value == "ENABLED" {
+			protocols = append(protocols, protocol)
+		}
+	}
+
+	d.Set("name", name)
+	if profile.Sslprofiletype != nil {
+		d.Set("profile_type", *profile.Sslprofiletype)
+	}
+	d.Set("protocols", protocols)
+	d.Set("session_reuse", profile.Sessreuse != nil && *profile.Sessreuse == "ENABLED")
+	d.Set("hsts", profile.Hsts != nil && *profile.Hsts == "ENABLED")
+	if profile.Sesstimeout != nil {
+		d.Set("session_timeout", *profile.Sesstimeout)
+	}
+	if profile.Maxage != nil {
+		d.Set("hsts_max_age", *profile.Maxage)
+	}
+	if profile.Denysslreneg != nil {
+		d.Set("deny_ssl_renegotiation", *profile.Denysslreneg)
+	}
+
+	bindings := sslprofileSslcipherBindingRes{}
+	if err := nClient.Get(&bindings, name); err != nil {
+		return err
+	}
+	sort.SliceStable(bindings.SslprofileSslcipherBinding, func(i, j int) bool {
+		return sl.Get(bindings.SslprofileSslcipherBinding[i].Cipherpriority, 0).(int) <
+			sl.Get(bindings.SslprofileSslcipherBinding[j].Cipherpriority, 0).(int)
+	})
+	ciphers := []string{}
+	for _, binding := range bindings.SslprofileSslcipherBinding {
+		if binding.Ciphername != nil {
+			ciphers = append(ciphers, *binding.Ciphername)
+		}
+	}
+	d.Set("ciphers", ciphers)
+
+	vservers := sslvserverRes{}
+	if err := nClient.Get(&vservers, ""); err != nil {
+		return err
+	}
+	vipNames := []string{}
+	for _, vserver := range vservers.Sslvserver {
+		if vserver.Vservername != nil && vserver.Sslprofile == name {
+			vipNames = append(vipNames, *vserver.Vservername)
+		}
+	}
+	d.Set("vip_names", vipNames)
+
+	return nil
+}
+
+func deleteVpxSslProfile(nClient *client.NitroClient, d *schema.ResourceData, name string) error {
+	for _, vip := range d.Get("vip_names").(*schema.Set).List() {
+		if err := unsetVpxSslProfile(nClient, vip.(string)); err != nil && !isNitroNotFound(err) {
+			return err
+		}
+	}
+
+	err := nClient.Delete(&sslprofileReq{}, name)
+	if err != nil && !isNitroNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// unsetVpxSslProfile restores the default SSL profile of the virtual server
+func unsetVpxSslProfile(nClient *client.NitroClient, vipName string) error {
+	return nitroAction(nClient, "sslvserver", "unset", &sslvserverReq{
+		Sslvserver: &sslvserver{Vservername: op.String(vipName), Sslprofile: true},
+	})
+}
+
+// nitroAction runs an action such as unset, which netscaler-nitro-go does not support
+func nitroAction(nClient *client.NitroClient, resource, action string, req interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	responseBody, _, err := client.HTTPRequest(nClient, resource+"?action="+action, "POST", body)
+	if err != nil {
+		return err
+	}
+	if len(responseBody) > 0 {
+		res := dt.BaseRes{}
+		if err := json.Unmarshal(responseBody, &res); err != nil {
+			return fmt.Errorf("Error in Unmarshal '%s'", err.Error())
+		}
+		if res.Severity != nil && *res.Severity == "ERROR" {
+			return fmt.Errorf("Error in POST : Errorcode '%d' Message '%s' Severity '%s'",
+				sl.Get(res.Errorcode, 0), sl.Get(res.Message, ""), *res.Severity)
+		}
+	}
+	return nil
+}
+
+func nitroEnabled(enabled bool) string {
+	if enabled {
+		return "ENABLED"
+	}
+	return "DISABLED"
+}
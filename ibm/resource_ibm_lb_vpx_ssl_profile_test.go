@@ -0,0 +1,143 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMLbVpxSslProfile_Basic(t *testing.T) {
+	name := fmt.Sprintf("tfsslprofile%s", acctest.RandString(6))
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMLbVpxSslProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMLbVpxSslProfileConfig(name, `"TLSv1.2"`, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_ssl_profile.testacc_profile", "name", name),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_ssl_profile.testacc_profile", "protocols.#", "1"),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_ssl_profile.testacc_profile", "hsts", "false"),
+				),
+			},
+			{
+				Config: testAccCheckIBMLbVpxSslProfileConfig(name, `"TLSv1.1", "TLSv1.2"`, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_ssl_profile.testacc_profile", "protocols.#", "2"),
+					resource.TestCheckResourceAttr(
+						"ibm_lb_vpx_ssl_profile.testacc_profile", "hsts", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMLbVpxSslProfileDestroy(s *terraform.State) error {
+	sess := testAccProvider.Meta().(ClientSession).SoftLayerSession()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_lb_vpx_ssl_profile" {
+			continue
+		}
+
+		nadcId, _ := strconv.Atoi(rs.Primary.Attributes["nad_controller_id"])
+		nClient, err := getVPXNitroClient(sess, nadcId)
+		if err != nil {
+			// The VPX is already gone
+			continue
+		}
+
+		err = nClient.Get(&sslprofileRes{}, rs.Primary.Attributes["name"])
+		if err == nil {
+			return fmt.Errorf("Netscaler VPX SSL profile still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckIBMLbVpxSslProfileConfig(name, protocols string, hsts bool) string {
+	return fmt.Sprintf(`
+resource "ibm_lb_vpx" "testacc_foobar_nadc" {
+    datacenter = "dal09"
+    speed = 10
+    version = "10.5"
+    plan = "Standard"
+    ip_count = 2
+}
+
+resource "ibm_lb_vpx_ssl_profile" "testacc_profile" {
+    nad_controller_id = "${ibm_lb_vpx.testacc_foobar_nadc.id}"
+    name = "%s"
+    protocols = [%s]
+    hsts = %t
+}
+`, name, protocols, hsts)
+}
+
+func TestVpxSslProfile(t *testing.T) {
+	stub := newNitroStub()
+	defer stub.close()
+	nClient := stub.client()
+	stub.objects["sslvserver"] = []map[string]interface{}{
+		{"vservername": "vip1"},
+		{"vservername": "vip2"},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceIBMLbVpxSslProfile().Schema, map[string]interface{}{
+		"nad_controller_id": 1,
+		"name":              "profile1",
+		"protocols":         []interface{}{"TLSv1.1", "TLSv1.2"},
+		"ciphers":           []interface{}{"TLS1.2-AES256-GCM-SHA384", "TLS1.2-AES128-GCM-SHA256"},
+		"hsts":              true,
+		"hsts_max_age":      86400,
+		"vip_names":         []interface{}{"vip1"},
+	})
+	assert.NilError(t, createVpxSslProfile(nClient, d))
+
+	profiles := stub.find("sslprofile", "profile1", nil)
+	assert.Assert(t, is.Len(profiles, 1))
+	assert.Equal(t, "DISABLED", profiles[0]["tls1"])
+	assert.Equal(t, "ENABLED", profiles[0]["tls12"])
+	assert.Equal(t, "ENABLED", profiles[0]["hsts"])
+	assert.Equal(t, "profile1", stub.find("sslvserver", "vip1", nil)[0]["sslprofile"])
+	assert.Assert(t, is.Nil(stub.find("sslvserver", "vip2", nil)[0]["sslprofile"]))
+
+	read := schema.TestResourceDataRaw(t, resourceIBMLbVpxSslProfile().Schema, map[string]interface{}{})
+	assert.NilError(t, readVpxSslProfile(nClient, read, "profile1"))
+	assert.Equal(t, "FrontEnd", read.Get("profile_type"))
+	assert.Equal(t, 2, read.Get("protocols").(*schema.Set).Len())
+	assert.Assert(t, read.Get("protocols").(*schema.Set).Contains("TLSv1.1"))
+	assert.DeepEqual(t, []interface{}{"TLS1.2-AES256-GCM-SHA384", "TLS1.2-AES128-GCM-SHA256"}, read.Get("ciphers"))
+	assert.Equal(t, true, read.Get("hsts"))
+	assert.Equal(t, 86400, read.Get("hsts_max_age"))
+	assert.DeepEqual(t, []interface{}{"vip1"}, read.Get("vip_names").(*schema.Set).List())
+
+	// Reordering the ciphers rebinds them with the new priorities
+	ciphers := []string{"TLS1.2-AES128-GCM-SHA256", "TLS1.2-ECDHE-RSA-AES256-GCM-SHA384"}
+	assert.NilError(t, bindVpxSslProfileCiphers(nClient, "profile1", ciphers))
+	assert.NilError(t, readVpxSslProfile(nClient, read, "profile1"))
+	assert.DeepEqual(t, []interface{}{ciphers[0], ciphers[1]}, read.Get("ciphers"))
+
+	assert.NilError(t, deleteVpxSslProfile(nClient, d, "profile1"))
+	assert.Assert(t, is.Len(stub.find("sslprofile", "profile1", nil), 0))
+	assert.Assert(t, is.Nil(stub.find("sslvserver", "vip1", nil)[0]["sslprofile"]))
+
+	err := readVpxSslProfile(nClient, read, "profile1")
+	assert.Assert(t, isNitroNotFound(err))
+}
@@ -670,6 +670,24 @@ func getNitroClient(sess *session.Session, nadcId int) (*client.NitroClient, err
 		"root", *nadc.Password.Password, true), nil
 }
 
+// getVPXNitroClient returns the NITRO client of a VPX that supports the NITRO configuration API.
+// VPX 10.1 is only managed through the SoftLayer API.
+func getVPXNitroClient(sess *session.Session, nadcId int) (*client.NitroClient, error) {
+	version, err := getVPXVersion(nadcId, sess)
+	if err != nil {
+		return nil, err
+	}
+	if version == VPX_VERSION_10_1 {
+		return nil, fmt.Errorf("VPX %s does not support the NITRO API, VPX 10.5 or later is required", version)
+	}
+	return getNitroClient(sess, nadcId)
+}
+
+// isNitroNotFound reports whether the NITRO error is about a missing resource
+func isNitroNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "No such resource")
+}
+
 func configureSecurityCertificate(nClient *client.NitroClient, sess *session.Session, vipName string, securityCertificateId int) error {
 	// Read security_certificate
 	service := services.GetSecurityCertificateService(sess)
@@ -4,13 +4,20 @@
 package ibm
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 
+	"github.com/minsikl/netscaler-nitro-go/client"
+	dt "github.com/minsikl/netscaler-nitro-go/datatypes"
 	"github.com/softlayer/softlayer-go/helpers/network"
 )
 
@@ -183,3 +190,150 @@ resource "ibm_lb_vpx_vip" "testacc_vip" {
 	tags = ["one", "two", "three"]
 }
 `
+
+// nitroStub is an in-memory NITRO configuration API. Resources are identified by the first of
+// nitroStubKeys they have, bindings are stored as a list per bound resource.
+type nitroStub struct {
+	mu      sync.Mutex
+	server  *httptest.Server
+	objects map[string][]map[string]interface{}
+}
+
+var nitroStubKeys = []string{"name", "policyname", "monitorname", "vservername"}
+
+func newNitroStub() *nitroStub {
+	n := &nitroStub{objects: map[string][]map[string]interface{}{}}
+	n.server = httptest.NewServer(http.HandlerFunc(n.handle))
+	return n
+}
+
+func (n *nitroStub) close() {
+	n.server.Close()
+}
+
+func (n *nitroStub) client() *client.NitroClient {
+	return client.NewNitroClient("http", strings.TrimPrefix(n.server.URL, "http://"), dt.CONFIG, "root", "secret", false)
+}
+
+func nitroStubKey(object map[string]interface{}) interface{} {
+	for _, key := range nitroStubKeys {
+		if v, ok := object[key]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+// find returns the objects of the resource with the name that match the args
+func (n *nitroStub) find(resource, name string, args map[string]string) []map[string]interface{} {
+	found := []map[string]interface{}{}
+	for _, object := range n.objects[resource] {
+		if name != "" && nitroStubKey(object) != name {
+			continue
+		}
+		matches := true
+		for k, v := range args {
+			if fmt.Sprint(object[strings.ToLower(k)]) != v {
+				matches = false
+			}
+		}
+		if matches {
+			found = append(found, object)
+		}
+	}
+	return found
+}
+
+func (n *nitroStub) handle(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/nitro/v1/config/"), "/")
+	parts := strings.SplitN(path, "/", 2)
+	resource, name := parts[0], ""
+	if len(parts) == 2 {
+		name = parts[1]
+	}
+	binding := strings.HasSuffix(resource, "_binding")
+	args := map[string]string{}
+	if a := r.URL.Query().Get("args"); a != "" {
+		for _, arg := range strings.Split(a, ",") {
+			kv := strings.SplitN(arg, ":", 2)
+			args[kv[0]] = kv[1]
+		}
+	}
+
+	reply := func(status int, body map[string]interface{}) {
+		if body == nil {
+			body = map[string]interface{}{}
+		}
+		body["errorcode"], body["message"], body["severity"] = 0, "Done", "NONE"
+		if status != http.StatusOK && status != http.StatusCreated {
+			body["errorcode"], body["message"], body["severity"] = 258, "No such resource", "ERROR"
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}
+
+	switch r.Method {
+	case "GET":
+		found := n.find(resource, name, nil)
+		if len(found) == 0 && name != "" && !binding {
+			reply(http.StatusNotFound, nil)
+			return
+		}
+		reply(http.StatusOK, map[string]interface{}{resource: found})
+	case "DELETE":
+		found := n.find(resource, name, args)
+		if len(found) == 0 {
+			reply(http.StatusNotFound, nil)
+			return
+		}
+		kept := []map[string]interface{}{}
+		for _, object := range n.objects[resource] {
+			removed := false
+			for _, f := range found {
+				if fmt.Sprint(f) == fmt.Sprint(object) {
+					removed = true
+				}
+			}
+			if !removed {
+				kept = append(kept, object)
+			}
+		}
+		n.objects[resource] = kept
+		reply(http.StatusOK, nil)
+	case "POST", "PUT":
+		body := map[string]map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		object := body[resource]
+		existing := n.find(resource, fmt.Sprint(nitroStubKey(object)), nil)
+		switch {
+		case r.URL.Query().Get("action") == "unset" || r.Method == "PUT":
+			if len(existing) == 0 {
+				reply(http.StatusNotFound, nil)
+				return
+			}
+			for k, v := range object {
+				if r.Method == "PUT" {
+					existing[0][k] = v
+				} else if v == true {
+					delete(existing[0], k)
+				}
+			}
+			reply(http.StatusOK, nil)
+		case len(existing) > 0 && !binding:
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"errorcode": 273, "message": "Resource already exists", "severity": "ERROR"})
+		default:
+			n.objects[resource] = append(n.objects[resource], object)
+			// New SSL profiles come with the DEFAULT cipher group
+			if resource == "sslprofile" {
+				n.objects["sslprofile_sslcipher_binding"] = append(n.objects["sslprofile_sslcipher_binding"],
+					map[string]interface{}{"name": object["name"], "ciphername": "DEFAULT", "cipherpriority": 1})
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+	}
+}
@@ -0,0 +1,469 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	gatewayConfigMemberMask = "members[hardware[id,hostname,primaryIpAddress,primaryBackendIpAddress," +
+		"operatingSystem[passwords[username,password]]]]"
+
+	vyattaScriptTemplate = "source /opt/vyatta/etc/functions/script-template"
+)
+
+// gatewayConfigMember is a gateway member reachable over SSH
+type gatewayConfigMember struct {
+	id       int
+	hostname string
+	address  string
+	password string
+}
+
+func resourceIBMNetworkGatewayConfig() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIBMNetworkGatewayConfigCreate,
+		Read:   resourceIBMNetworkGatewayConfigRead,
+		Update: resourceIBMNetworkGatewayConfigUpdate,
+		Delete: resourceIBMNetworkGatewayConfigDelete,
+		Exists: resourceIBMNetworkGatewayConfigExists,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"gateway_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the network gateway",
+			},
+			"commands": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateRegexp(`^set\s+\S`),
+				},
+				Description: "Configuration commands applied to every gateway member, e.g. set firewall name WAN default-action drop",
+			},
+			"user": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "vyatta",
+				Description: "SSH user on the gateway members",
+			},
+			"password": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"private_key"},
+				Description:   "SSH password of the user. Defaults to the password of the user stored for the member operating system",
+			},
+			"private_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"password"},
+				Description:   "PEM encoded SSH private key of the user",
+			},
+			"use_public_ip": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Connect to the public IP of the members instead of the private management IP",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     22,
+				Description: "SSH port of the gateway members",
+			},
+			"host_keys": {
+				Type:        schema.TypeMap,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "SSH host keys of the gateway members in authorized_keys format, by member hostname",
+			},
+			"preexisting_commands": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Commands that were already configured on a gateway member before they were applied, they are not deleted",
+			},
+			"member_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "Hardware IDs of the configured gateway members",
+			},
+		},
+	}
+}
+
+func resourceIBMNetworkGatewayConfigCreate(d *schema.ResourceData, meta interface{}) error {
+	gatewayID := d.Get("gateway_id").(int)
+	members, err := getGatewayConfigMembers(d, meta)
+	if err != nil {
+		return err
+	}
+
+	commands := expandStringList(d.Get("commands").([]interface{}))
+	preexisting, err := gatewayPreexistingCommands(d, members, commands)
+	if err != nil {
+		return err
+	}
+
+	owned := vyattaCommandsDifference(commands, preexisting)
+	err = applyGatewayConfig(d, members, commands, nil, nil, vyattaDeleteCommands(owned))
+	if err != nil {
+		return fmt.Errorf("Error configuring network gateway %d: %s", gatewayID, err)
+	}
+
+	d.SetId(strconv.Itoa(gatewayID))
+	d.Set("preexisting_commands", preexisting)
+	return resourceIBMNetworkGatewayConfigRead(d, meta)
+}
+
+func resourceIBMNetworkGatewayConfigRead(d *schema.ResourceData, meta interface{}) error {
+	members, err := getGatewayConfigMembers(d, meta)
+	if err != nil {
+		return err
+	}
+
+	declared := expandStringList(d.Get("commands").([]interface{}))
+	inSync := declared
+	memberIDs := make([]int, 0, len(members))
+	for _, member := range members {
+		config, err := gatewaySSHConfig(d, member)
+		if err != nil {
+			return err
+		}
+		output, err := runVyattaScript(member.address, config, vyattaShowConfigScript())
+		if err != nil {
+			return fmt.Errorf("Error reading configuration of gateway member %s: %s", member.hostname, err)
+		}
+		// Commands missing on any member are dropped, so that the plan re-applies them
+		inSync = vyattaCommandsPresent(inSync, output)
+		memberIDs = append(memberIDs, member.id)
+	}
+
+	d.Set("commands", inSync)
+	d.Set("member_ids", memberIDs)
+	return nil
+}
+
+func resourceIBMNetworkGatewayConfigUpdate(d *schema.ResourceData, meta interface{}) error {
+	if !d.HasChange("commands") {
+		return resourceIBMNetworkGatewayConfigRead(d, meta)
+	}
+
+	members, err := getGatewayConfigMembers(d, meta)
+	if err != nil {
+		return err
+	}
+
+	o, n := d.GetChange("commands")
+	oldCommands := expandStringList(o.([]interface{}))
+	newCommands := expandStringList(n.([]interface{}))
+	addedCommands := vyattaCommandsDifference(newCommands, oldCommands)
+	addedPreexisting, err := gatewayPreexistingCommands(d, members, addedCommands)
+	if err != nil {
+		return err
+	}
+
+	// Only the commands applied by the resource are deleted, on removal and on rollback
+	preexisting := append(expandStringList(d.Get("preexisting_commands").([]interface{})), addedPreexisting...)
+	removed := vyattaDeleteCommands(vyattaCommandsDifference(vyattaCommandsDifference(oldCommands, newCommands), preexisting))
+	added := vyattaDeleteCommands(vyattaCommandsDifference(addedCommands, preexisting))
+
+	err = applyGatewayConfig(d, members, newCommands, removed, oldCommands, added)
+	if err != nil {
+		return fmt.Errorf("Error updating configuration of network gateway %s: %s", d.Id(), err)
+	}
+	d.Set("preexisting_commands", vyattaCommandsIntersection(preexisting, newCommands))
+
+	return resourceIBMNetworkGatewayConfigRead(d, meta)
+}
+
+func resourceIBMNetworkGatewayConfigDelete(d *schema.ResourceData, meta interface{}) error {
+	members, err := getGatewayConfigMembers(d, meta)
+	if err != nil {
+		return err
+	}
+
+	commands := expandStringList(d.Get("commands").([]interface{}))
+	owned := vyattaCommandsDifference(commands, expandStringList(d.Get("preexisting_commands").([]interface{})))
+	err = applyGatewayConfig(d, members, nil, vyattaDeleteCommands(owned), commands, nil)
+	if err != nil {
+		return fmt.Errorf("Error removing configuration of network gateway %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMNetworkGatewayConfigExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	service := services.GetNetworkGatewayService(meta.(ClientSession).SoftLayerSession())
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = service.Id(id).GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error trying to retrieve Network Gateway: %s", err)
+	}
+	return true, nil
+}
+
+func getGatewayConfigMembers(d *schema.ResourceData, meta interface{}) ([]gatewayConfigMember, error) {
+	gatewayID := d.Get("gateway_id").(int)
+	service := services.GetNetworkGatewayService(meta.(ClientSession).SoftLayerSession())
+
+	gw, err := service.Id(gatewayID).Mask(gatewayConfigMemberMask).GetObject()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving network gateway %d: %s", gatewayID, err)
+	}
+
+	user := d.Get("user").(string)
+	port := strconv.Itoa(d.Get("port").(int))
+	members := make([]gatewayConfigMember, 0, len(gw.Members))
+	for _, m := range gw.Members {
+		hw := m.Hardware
+		if hw == nil || hw.Id == nil {
+			continue
+		}
+		member := gatewayConfigMember{
+			id: *hw.Id,
+		}
+		if hw.Hostname != nil {
+			member.hostname = *hw.Hostname
+		}
+
+		ip := hw.PrimaryBackendIpAddress
+		if d.Get("use_public_ip").(bool) {
+			ip = hw.PrimaryIpAddress
+		}
+		if ip == nil {
+			return nil, fmt.Errorf("Gateway member %s has no IP address to connect to", member.hostname)
+		}
+		member.address = net.JoinHostPort(*ip, port)
+
+		if hw.OperatingSystem != nil {
+			for _, p := range hw.OperatingSystem.Passwords {
+				if p.Username != nil && *p.Username == user && p.Password != nil {
+					member.password = *p.Password
+				}
+			}
+		}
+		members = append(members, member)
+	}
+
+	if len(members) == 0 {
+		return nil, fmt.Errorf("Network gateway %d has no members", gatewayID)
+	}
+	return members, nil
+}
+
+func gatewaySSHConfig(d *schema.ResourceData, member gatewayConfigMember) (*ssh.ClientConfig, error) {
+	hostKey, ok := d.Get("host_keys").(map[string]interface{})[member.hostname]
+	if !ok {
+		return nil, fmt.Errorf("No host key in host_keys for gateway member %s", member.hostname)
+	}
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey.(string)))
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing the host key of gateway member %s: %s", member.hostname, err)
+	}
+
+	config := &ssh.ClientConfig{
+		User:            d.Get("user").(string),
+		HostKeyCallback: ssh.FixedHostKey(publicKey),
+		Timeout:         30 * time.Second,
+	}
+
+	if key, ok := d.GetOk("private_key"); ok {
+		signer, err := ssh.ParsePrivateKey([]byte(key.(string)))
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing private_key: %s", err)
+		}
+		config.Auth = []ssh.AuthMethod{ssh.PublicKeys(signer)}
+		return config, nil
+	}
+
+	password := member.password
+	if p, ok := d.GetOk("password"); ok {
+		password = p.(string)
+	}
+	if password == "" {
+		return nil, fmt.Errorf("No password or private_key for user %s on gateway member %s", config.User, member.hostname)
+	}
+	config.Auth = []ssh.AuthMethod{ssh.Password(password)}
+	return config, nil
+}
+
+// gatewayPreexistingCommands returns the commands that are already in the running configuration of any member.
+func gatewayPreexistingCommands(d *schema.ResourceData, members []gatewayConfigMember, commands []string) ([]string, error) {
+	preexisting := []string{}
+	for _, member := range members {
+		config, err := gatewaySSHConfig(d, member)
+		if err != nil {
+			return nil, err
+		}
+		output, err := runVyattaScript(member.address, config, vyattaShowConfigScript())
+		if err != nil {
+			return nil, fmt.Errorf("Error reading configuration of gateway member %s: %s", member.hostname, err)
+		}
+		present := vyattaCommandsPresent(vyattaCommandsDifference(commands, preexisting), output)
+		if len(present) > 0 {
+			log.Printf("[INFO] Gateway member %s already has commands %q, they are not deleted", member.hostname, present)
+		}
+		preexisting = append(preexisting, present...)
+	}
+	return preexisting, nil
+}
+
+// applyGatewayConfig sets and deletes the commands on every member in one commit per member.
+// If a member fails, the members already committed are rolled back with the rollback commands.
+func applyGatewayConfig(d *schema.ResourceData, members []gatewayConfigMember, set, del, rollbackSet, rollbackDel []string) error {
+	for i, member := range members {
+		config, err := gatewaySSHConfig(d, member)
+		if err == nil {
+			log.Printf("[INFO] Committing configuration to gateway member %s", member.hostname)
+			_, err = runVyattaScript(member.address, config, vyattaConfigureScript(set, del))
+		}
+		if err == nil {
+			continue
+		}
+
+		for _, committed := range members[:i] {
+			log.Printf("[WARN] Rolling back configuration of gateway member %s", committed.hostname)
+			rollbackConfig, rerr := gatewaySSHConfig(d, committed)
+			if rerr == nil {
+				_, rerr = runVyattaScript(committed.address, rollbackConfig, vyattaConfigureScript(rollbackSet, rollbackDel))
+			}
+			if rerr != nil {
+				return fmt.Errorf("%s on member %s, and rolling back member %s failed: %s", err, member.hostname, committed.hostname, rerr)
+			}
+		}
+		return fmt.Errorf("%s on member %s", err, member.hostname)
+	}
+	return nil
+}
+
+// runVyattaScript runs the script with vbash on the member and returns its output.
+func runVyattaScript(address string, config *ssh.ClientConfig, script string) (string, error) {
+	client, err := ssh.Dial("tcp", address, config)
+	if err != nil {
+		return "", fmt.Errorf("Error connecting to %s: %s", address, err)
+	}
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return "", fmt.Errorf("Error opening SSH session to %s: %s", address, err)
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdin = strings.NewReader(script)
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if err := session.Run("vbash -s"); err != nil {
+		return stdout.String(), fmt.Errorf("%s: %s%s", err, stdout.String(), stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// vyattaConfigureScript applies the commands in one commit. A failed commit discards the
+// staged changes, so the running configuration is left untouched.
+func vyattaConfigureScript(set, del []string) string {
+	var b strings.Builder
+	b.WriteString(vyattaScriptTemplate + "\n")
+	b.WriteString("configure\n")
+	for _, c := range del {
+		b.WriteString(c + "\n")
+	}
+	for _, c := range set {
+		b.WriteString(c + "\n")
+	}
+	b.WriteString("commit || { discard; exit 1; }\n")
+	b.WriteString("save\n")
+	b.WriteString("exit\n")
+	return b.String()
+}
+
+func vyattaShowConfigScript() string {
+	return vyattaScriptTemplate + "\nrun show configuration commands\n"
+}
+
+// normalizeVyattaCommand drops the quoting and extra whitespace that
+// show configuration commands adds to values.
+func normalizeVyattaCommand(command string) string {
+	return strings.Join(strings.Fields(strings.Replace(command, "'", "", -1)), " ")
+}
+
+// vyattaCommandsPresent returns the commands found in the output of show configuration commands
+func vyattaCommandsPresent(commands []string, output string) []string {
+	running := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		running[normalizeVyattaCommand(line)] = true
+	}
+	present := make([]string, 0, len(commands))
+	for _, c := range commands {
+		if running[normalizeVyattaCommand(c)] {
+			present = append(present, c)
+		}
+	}
+	return present
+}
+
+// vyattaCommandsDifference returns the commands of a that are not in b
+func vyattaCommandsDifference(a, b []string) []string {
+	inB := map[string]bool{}
+	for _, c := range b {
+		inB[normalizeVyattaCommand(c)] = true
+	}
+	diff := []string{}
+	for _, c := range a {
+		if !inB[normalizeVyattaCommand(c)] {
+			diff = append(diff, c)
+		}
+	}
+	return diff
+}
+
+// vyattaCommandsIntersection returns the commands of a that are also in b
+func vyattaCommandsIntersection(a, b []string) []string {
+	return vyattaCommandsDifference(a, vyattaCommandsDifference(a, b))
+}
+
+// vyattaDeleteCommands turns set commands into the delete commands that undo them
+func vyattaDeleteCommands(commands []string) []string {
+	deletes := make([]string, 0, len(commands))
+	for _, c := range commands {
+		deletes = append(deletes, "delete"+strings.TrimPrefix(strings.TrimSpace(c), "set"))
+	}
+	return deletes
+}
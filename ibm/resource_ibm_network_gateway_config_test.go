@@ -0,0 +1,306 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/crypto/ssh"
+	"gotest.tools/assert"
+	is "gotest.tools/assert/cmp"
+)
+
+func TestAccIBMNetworkGatewayConfig_Basic(t *testing.T) {
+	config := "ibm_network_gateway_config.config"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMNetworkGatewayConfigConfig("drop"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(config, "commands.#", "2"),
+					resource.TestCheckResourceAttr(config, "member_ids.#", "1"),
+				),
+			},
+			{
+				Config: testAccCheckIBMNetworkGatewayConfigConfig("reject"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(config, "commands.0", "set firewall name TF-WAN default-action reject"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMNetworkGatewayConfigConfig(action string) string {
+	return fmt.Sprintf(`
+resource "ibm_network_gateway_config" "config" {
+	gateway_id    = %s
+	use_public_ip = true
+	host_keys = {
+		"%s" = "%s"
+	}
+	commands = [
+		"set firewall name TF-WAN default-action %s",
+		"set firewall name TF-WAN description 'managed by terraform'",
+	]
+}
+`, networkGatewayID, networkGatewayMemberHostname, networkGatewayMemberHostKey, action)
+}
+
+// vyattaStandIn is an SSH server that understands the vbash scripts sent to gateway members
+type vyattaStandIn struct {
+	mu         sync.Mutex
+	config     []string
+	failCommit bool
+	address    string
+	hostKey    string
+	listener   net.Listener
+}
+
+func newVyattaStandIn(t *testing.T, password string) *vyattaStandIn {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+			if c.User() == "vyatta" && string(pass) == password {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("password rejected for %s", c.User())
+		},
+	}
+	serverConfig.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v := &vyattaStandIn{
+		address:  listener.Addr().String(),
+		hostKey:  string(ssh.MarshalAuthorizedKey(signer.PublicKey())),
+		listener: listener,
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go v.serve(conn, serverConfig)
+		}
+	}()
+	return v
+}
+
+func (v *vyattaStandIn) close() {
+	v.listener.Close()
+}
+
+func (v *vyattaStandIn) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer channel.Close()
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+				req.Reply(true, nil)
+				script, _ := ioutil.ReadAll(channel)
+				output, status := v.run(string(script))
+				io.WriteString(channel, output)
+				channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+				return
+			}
+		}()
+	}
+}
+
+func (v *vyattaStandIn) run(script string) (string, uint32) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	staged := append([]string{}, v.config...)
+	var output strings.Builder
+	scanner := bufio.NewScanner(strings.NewReader(script))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "set "):
+			staged = append(vyattaStandInDelete(staged, line), line)
+		case strings.HasPrefix(line, "delete "):
+			staged = vyattaStandInDelete(staged, "set"+strings.TrimPrefix(line, "delete"))
+		case strings.HasPrefix(line, "commit"):
+			if v.failCommit {
+				return "Commit failed\n", 1
+			}
+			v.config = staged
+		case line == "run show configuration commands":
+			for _, c := range v.config {
+				output.WriteString(c + "\n")
+			}
+		}
+	}
+	return output.String(), 0
+}
+
+func vyattaStandInDelete(config []string, command string) []string {
+	kept := []string{}
+	for _, c := range config {
+		if normalizeVyattaCommand(c) != normalizeVyattaCommand(command) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+func testGatewayConfigResourceData(t *testing.T, commands []interface{}, memberA, memberB *vyattaStandIn) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, resourceIBMNetworkGatewayConfig().Schema, map[string]interface{}{
+		"gateway_id": 1,
+		"commands":   commands,
+		"host_keys": map[string]interface{}{
+			"gw-a": memberA.hostKey,
+			"gw-b": memberB.hostKey,
+		},
+	})
+}
+
+func TestApplyGatewayConfig(t *testing.T) {
+	memberA := newVyattaStandIn(t, "secret")
+	defer memberA.close()
+	memberB := newVyattaStandIn(t, "secret")
+	defer memberB.close()
+	members := []gatewayConfigMember{
+		{id: 1, hostname: "gw-a", address: memberA.address, password: "secret"},
+		{id: 2, hostname: "gw-b", address: memberB.address, password: "secret"},
+	}
+	commands := []string{
+		"set firewall name WAN default-action drop",
+		"set system ntp server 10.0.80.11",
+	}
+	d := testGatewayConfigResourceData(t, []interface{}{commands[0], commands[1]}, memberA, memberB)
+
+	err := applyGatewayConfig(d, members, commands, nil, nil, vyattaDeleteCommands(commands))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, commands, memberA.config)
+	assert.DeepEqual(t, commands, memberB.config)
+
+	// Drift on one member drops the command from the commands in sync
+	memberB.config = []string{"set firewall name WAN default-action 'drop'"}
+	config, err := gatewaySSHConfig(d, members[1])
+	assert.NilError(t, err)
+	output, err := runVyattaScript(memberB.address, config, vyattaShowConfigScript())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, commands[:1], vyattaCommandsPresent(commands, output))
+
+	err = applyGatewayConfig(d, members, nil, vyattaDeleteCommands(commands), commands, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, is.Len(memberA.config, 0))
+	assert.Assert(t, is.Len(memberB.config, 0))
+}
+
+func TestApplyGatewayConfigRollback(t *testing.T) {
+	memberA := newVyattaStandIn(t, "secret")
+	defer memberA.close()
+	memberB := newVyattaStandIn(t, "secret")
+	defer memberB.close()
+	memberB.failCommit = true
+	members := []gatewayConfigMember{
+		{id: 1, hostname: "gw-a", address: memberA.address, password: "secret"},
+		{id: 2, hostname: "gw-b", address: memberB.address, password: "secret"},
+	}
+	oldCommands := []string{"set firewall name WAN default-action drop"}
+	newCommands := []string{"set firewall name WAN default-action accept"}
+	memberA.config = oldCommands
+	memberB.config = oldCommands
+	d := testGatewayConfigResourceData(t, []interface{}{newCommands[0]}, memberA, memberB)
+
+	err := applyGatewayConfig(d, members, newCommands,
+		vyattaDeleteCommands(vyattaCommandsDifference(oldCommands, newCommands)),
+		oldCommands,
+		vyattaDeleteCommands(vyattaCommandsDifference(newCommands, oldCommands)))
+	assert.ErrorContains(t, err, "gw-b")
+	assert.DeepEqual(t, oldCommands, memberA.config)
+	assert.DeepEqual(t, oldCommands, memberB.config)
+}
+
+func TestGatewayConfigHostKey(t *testing.T) {
+	memberA := newVyattaStandIn(t, "secret")
+	defer memberA.close()
+	memberB := newVyattaStandIn(t, "secret")
+	defer memberB.close()
+	member := gatewayConfigMember{id: 1, hostname: "gw-a", address: memberA.address, password: "secret"}
+
+	// The host key of another member is rejected
+	d := testGatewayConfigResourceData(t, []interface{}{"set system ntp server 10.0.80.11"}, memberB, memberA)
+	config, err := gatewaySSHConfig(d, member)
+	assert.NilError(t, err)
+	_, err = runVyattaScript(member.address, config, vyattaShowConfigScript())
+	assert.ErrorContains(t, err, "host key")
+
+	_, err = gatewaySSHConfig(d, gatewayConfigMember{hostname: "gw-c", password: "secret"})
+	assert.ErrorContains(t, err, "No host key")
+}
+
+func TestGatewayPreexistingCommands(t *testing.T) {
+	memberA := newVyattaStandIn(t, "secret")
+	defer memberA.close()
+	memberB := newVyattaStandIn(t, "secret")
+	defer memberB.close()
+	members := []gatewayConfigMember{
+		{id: 1, hostname: "gw-a", address: memberA.address, password: "secret"},
+		{id: 2, hostname: "gw-b", address: memberB.address, password: "secret"},
+	}
+	commands := []string{
+		"set firewall name WAN default-action drop",
+		"set system ntp server 10.0.80.11",
+	}
+	memberB.config = []string{"set system ntp server '10.0.80.11'"}
+	d := testGatewayConfigResourceData(t, []interface{}{commands[0], commands[1]}, memberA, memberB)
+
+	preexisting, err := gatewayPreexistingCommands(d, members, commands)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, commands[1:], preexisting)
+
+	// Removing the configuration keeps the command that was there before
+	owned := vyattaCommandsDifference(commands, preexisting)
+	assert.NilError(t, applyGatewayConfig(d, members, commands, nil, nil, vyattaDeleteCommands(owned)))
+	assert.NilError(t, applyGatewayConfig(d, members, nil, vyattaDeleteCommands(owned), commands, nil))
+	assert.DeepEqual(t, commands[1:], memberA.config)
+	assert.DeepEqual(t, commands[1:], memberB.config)
+}
+
+func TestVyattaDeleteCommands(t *testing.T) {
+	assert.DeepEqual(t,
+		[]string{"delete firewall name WAN default-action drop"},
+		vyattaDeleteCommands([]string{"set firewall name WAN default-action drop"}))
+}
@@ -0,0 +1,320 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+
+	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/internal/hashcode"
+)
+
+func resourceIBMSecurityGroupRules() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMSecurityGroupRulesCreate,
+		Read:     resourceIBMSecurityGroupRulesRead,
+		Update:   resourceIBMSecurityGroupRulesUpdate,
+		Delete:   resourceIBMSecurityGroupRulesDelete,
+		Exists:   resourceIBMSecurityGroupRulesExists,
+		Importer: &schema.ResourceImporter{},
+
+		Schema: map[string]*schema.Schema{
+			"security_group_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Security group ID",
+			},
+			"rule": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Set:         resourceIBMSecurityGroupRulesHash,
+				Description: "The complete set of rules of the security group, rules that are not declared are removed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"direction": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Direction of rule: ingress or egress",
+							ValidateFunc: validateSecurityRuleDirection,
+						},
+						"ether_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "IPv4",
+							Description:  "IP version IPv4 or IPv6",
+							ValidateFunc: validateSecurityRuleEtherType,
+						},
+						"port_range_min": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Port number minimum range",
+						},
+						"port_range_max": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Port number max range",
+						},
+						"remote_group_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "remote group ID",
+						},
+						"remote_ip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateRemoteIP,
+							Description:  "Remote IP Address",
+						},
+						"protocol": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "icmp, tcp or udp",
+							ValidateFunc: validateSecurityRuleProtocol,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceIBMSecurityGroupRulesCreate(d *schema.ResourceData, meta interface{}) error {
+	sgID := d.Get("security_group_id").(int)
+
+	if err := applySecurityGroupRules(d, meta, sgID); err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(sgID))
+
+	return resourceIBMSecurityGroupRulesRead(d, meta)
+}
+
+func resourceIBMSecurityGroupRulesRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	sgID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	rules, err := service.Id(sgID).GetRules()
+	if err != nil {
+		if err, ok := err.(sl.Error); ok && err.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Security Group Rules: %s", err)
+	}
+
+	// Every rule of the group is reported, so rules added out of band show up as drift
+	ruleList := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		ruleList = append(ruleList, flattenSecurityGroupRule(rule))
+	}
+
+	d.Set("security_group_id", sgID)
+	d.Set("rule", schema.NewSet(resourceIBMSecurityGroupRulesHash, ruleList))
+
+	return nil
+}
+
+func resourceIBMSecurityGroupRulesUpdate(d *schema.ResourceData, meta interface{}) error {
+	sgID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.HasChange("rule") {
+		if err := applySecurityGroupRules(d, meta, sgID); err != nil {
+			return err
+		}
+	}
+
+	return resourceIBMSecurityGroupRulesRead(d, meta)
+}
+
+func resourceIBMSecurityGroupRulesDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	sgID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	rules, err := service.Id(sgID).GetRules()
+	if err != nil {
+		if err, ok := err.(sl.Error); ok && err.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Security Group Rules: %s", err)
+	}
+
+	remove, _ := securityGroupRulesDifference(rules, nil)
+	if len(remove) > 0 {
+		log.Printf("[INFO] Removing %d rules from Security Group %d", len(remove), sgID)
+		if _, err := service.Id(sgID).RemoveRules(remove); err != nil {
+			return fmt.Errorf("Error deleting Security Group Rules: %s", err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMSecurityGroupRulesExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	sgID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	group, err := service.Id(sgID).GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving Security Group: %s", err)
+	}
+
+	return group.Id != nil && *group.Id == sgID, nil
+}
+
+// applySecurityGroupRules makes the rules of the group match the declared rules, with one
+// removeRules and one addRules call.
+func applySecurityGroupRules(d *schema.ResourceData, meta interface{}, sgID int) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	service := services.GetNetworkSecurityGroupService(sess)
+
+	existing, err := service.Id(sgID).GetRules()
+	if err != nil {
+		return fmt.Errorf("Error retrieving Security Group Rules: %s", err)
+	}
+
+	declared := []datatypes.Network_SecurityGroup_Rule{}
+	for _, r := range d.Get("rule").(*schema.Set).List() {
+		declared = append(declared, expandSecurityGroupRule(r.(map[string]interface{})))
+	}
+
+	remove, add := securityGroupRulesDifference(existing, declared)
+	if len(remove) > 0 {
+		log.Printf("[INFO] Removing %d rules from Security Group %d", len(remove), sgID)
+		if _, err := service.Id(sgID).RemoveRules(remove); err != nil {
+			return fmt.Errorf("Error removing Security Group Rules: %s", err)
+		}
+	}
+	if len(add) > 0 {
+		log.Printf("[INFO] Adding %d rules to Security Group %d", len(add), sgID)
+		if _, err := service.Id(sgID).AddRules(add); err != nil {
+			return fmt.Errorf("Error adding Security Group Rules: %s", err)
+		}
+	}
+	return nil
+}
+
+// securityGroupRulesDifference returns the IDs of the existing rules that are not declared and
+// the declared rules that do not exist yet. Duplicate existing rules are removed as well.
+func securityGroupRulesDifference(existing, declared []datatypes.Network_SecurityGroup_Rule) ([]int, []datatypes.Network_SecurityGroup_Rule) {
+	wanted := make(map[string]bool, len(declared))
+	for _, rule := range declared {
+		wanted[securityGroupRuleKey(rule)] = true
+	}
+
+	remove := []int{}
+	found := make(map[string]bool, len(existing))
+	for _, rule := range existing {
+		key := securityGroupRuleKey(rule)
+		if wanted[key] && !found[key] {
+			found[key] = true
+			continue
+		}
+		if rule.Id != nil {
+			remove = append(remove, *rule.Id)
+		}
+	}
+
+	add := []datatypes.Network_SecurityGroup_Rule{}
+	for _, rule := range declared {
+		key := securityGroupRuleKey(rule)
+		if !found[key] {
+			found[key] = true
+			add = append(add, rule)
+		}
+	}
+	return remove, add
+}
+
+func expandSecurityGroupRule(r map[string]interface{}) datatypes.Network_SecurityGroup_Rule {
+	rule := datatypes.Network_SecurityGroup_Rule{
+		Direction: sl.String(r["direction"].(string)),
+	}
+	if v := r["ether_type"].(string); v != "" {
+		rule.Ethertype = sl.String(v)
+	}
+	if v := r["port_range_min"].(int); v != 0 {
+		rule.PortRangeMin = sl.Int(v)
+	}
+	if v := r["port_range_max"].(int); v != 0 {
+		rule.PortRangeMax = sl.Int(v)
+	}
+	if v := r["protocol"].(string); v != "" {
+		rule.Protocol = sl.String(v)
+	}
+	if v := r["remote_group_id"].(int); v != 0 {
+		rule.RemoteGroupId = sl.Int(v)
+	}
+	if v := r["remote_ip"].(string); v != "" {
+		rule.RemoteIp = sl.String(v)
+	}
+
+	// if only one of min/max is provided, set the other one to the provided
+	if rule.PortRangeMin != nil && rule.PortRangeMax == nil {
+		rule.PortRangeMax = rule.PortRangeMin
+	}
+	if rule.PortRangeMax != nil && rule.PortRangeMin == nil {
+		rule.PortRangeMin = rule.PortRangeMax
+	}
+	return rule
+}
+
+func flattenSecurityGroupRule(rule datatypes.Network_SecurityGroup_Rule) map[string]interface{} {
+	return map[string]interface{}{
+		"direction":       sl.Get(rule.Direction, ""),
+		"ether_type":      sl.Get(rule.Ethertype, ""),
+		"port_range_min":  sl.Get(rule.PortRangeMin, 0),
+		"port_range_max":  sl.Get(rule.PortRangeMax, 0),
+		"protocol":        sl.Get(rule.Protocol, ""),
+		"remote_group_id": sl.Get(rule.RemoteGroupId, 0),
+		"remote_ip":       sl.Get(rule.RemoteIp, ""),
+	}
+}
+
+// securityGroupRuleKey identifies a rule by its properties, rules have no name of their own
+func securityGroupRuleKey(rule datatypes.Network_SecurityGroup_Rule) string {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("%s-", sl.Get(rule.Direction, "").(string)))
+	buf.WriteString(fmt.Sprintf("%s-", sl.Get(rule.Ethertype, "IPv4").(string)))
+	buf.WriteString(fmt.Sprintf("%d-", sl.Get(rule.PortRangeMin, 0).(int)))
+	buf.WriteString(fmt.Sprintf("%d-", sl.Get(rule.PortRangeMax, 0).(int)))
+	buf.WriteString(fmt.Sprintf("%s-", sl.Get(rule.Protocol, "").(string)))
+	buf.WriteString(fmt.Sprintf("%d-", sl.Get(rule.RemoteGroupId, 0).(int)))
+	buf.WriteString(fmt.Sprintf("%s-", sl.Get(rule.RemoteIp, "").(string)))
+	return buf.String()
+}
+
+func resourceIBMSecurityGroupRulesHash(v interface{}) int {
+	return hashcode.String(securityGroupRuleKey(expandSecurityGroupRule(v.(map[string]interface{}))))
+}
@@ -0,0 +1,141 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+	"gotest.tools/assert"
+)
+
+func TestAccIBMSecurityGroupRules_basic(t *testing.T) {
+	name := fmt.Sprintf("terraformsguat_rules_%d", acctest.RandIntRange(10, 100))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMSecurityGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckIBMSecurityGroupRulesConfig(name, 22),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_security_group_rules.rules", "rule.#", "2"),
+					testAccCheckIBMSecurityGroupRulesCount("ibm_security_group_rules.rules", 2),
+				),
+			},
+			{
+				// A rule added out of band is removed
+				PreConfig: func() { testAccAddSecurityGroupRuleOutOfBand(t, "ibm_security_group_rules.rules") },
+				Config:    testAccCheckIBMSecurityGroupRulesConfig(name, 22),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMSecurityGroupRulesCount("ibm_security_group_rules.rules", 2),
+				),
+			},
+			{
+				Config: testAccCheckIBMSecurityGroupRulesConfig(name, 2222),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ibm_security_group_rules.rules", "rule.#", "2"),
+					testAccCheckIBMSecurityGroupRulesCount("ibm_security_group_rules.rules", 2),
+				),
+			},
+		},
+	})
+}
+
+var testAccSecurityGroupRulesState *terraform.State
+
+func testAccAddSecurityGroupRuleOutOfBand(t *testing.T, n string) {
+	rs := testAccSecurityGroupRulesState.RootModule().Resources[n]
+	sgID, _ := strconv.Atoi(rs.Primary.ID)
+	service := services.GetNetworkSecurityGroupService(testAccProvider.Meta().(ClientSession).SoftLayerSession())
+	_, err := service.Id(sgID).AddRules([]datatypes.Network_SecurityGroup_Rule{
+		{Direction: sl.String("ingress"), Protocol: sl.String("udp"), PortRangeMin: sl.Int(53), PortRangeMax: sl.Int(53)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testAccCheckIBMSecurityGroupRulesCount(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		testAccSecurityGroupRulesState = s
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		sgID, _ := strconv.Atoi(rs.Primary.ID)
+		service := services.GetNetworkSecurityGroupService(testAccProvider.Meta().(ClientSession).SoftLayerSession())
+		rules, err := service.Id(sgID).GetRules()
+		if err != nil {
+			return err
+		}
+		if len(rules) != count {
+			return fmt.Errorf("Security group %d has %d rules, expected %d", sgID, len(rules), count)
+		}
+		return nil
+	}
+}
+
+func testAccCheckIBMSecurityGroupRulesConfig(name string, port int) string {
+	return fmt.Sprintf(`
+resource "ibm_security_group" "testacc_sg" {
+	name        = "%s"
+	description = "rules managed by ibm_security_group_rules"
+}
+
+resource "ibm_security_group_rules" "rules" {
+	security_group_id = ibm_security_group.testacc_sg.id
+
+	rule {
+		direction      = "ingress"
+		protocol       = "tcp"
+		port_range_min = %d
+	}
+
+	rule {
+		direction = "egress"
+		remote_ip = "0.0.0.0/0"
+	}
+}
+`, name, port)
+}
+
+func TestSecurityGroupRulesDifference(t *testing.T) {
+	ssh := expandSecurityGroupRule(map[string]interface{}{
+		"direction": "ingress", "ether_type": "IPv4", "port_range_min": 22, "port_range_max": 0,
+		"protocol": "tcp", "remote_group_id": 0, "remote_ip": "",
+	})
+	https := expandSecurityGroupRule(map[string]interface{}{
+		"direction": "ingress", "ether_type": "IPv4", "port_range_min": 443, "port_range_max": 443,
+		"protocol": "tcp", "remote_group_id": 0, "remote_ip": "",
+	})
+
+	existingSSH := ssh
+	existingSSH.Id = sl.Int(1)
+	duplicateSSH := ssh
+	duplicateSSH.Id = sl.Int(2)
+	outOfBand := datatypes.Network_SecurityGroup_Rule{Id: sl.Int(3), Direction: sl.String("egress")}
+
+	remove, add := securityGroupRulesDifference(
+		[]datatypes.Network_SecurityGroup_Rule{existingSSH, duplicateSSH, outOfBand},
+		[]datatypes.Network_SecurityGroup_Rule{ssh, https})
+	assert.DeepEqual(t, []int{2, 3}, remove)
+	assert.DeepEqual(t, []datatypes.Network_SecurityGroup_Rule{https}, add)
+
+	// A rule with a single port matches the rule read back with both ends of the range
+	assert.Equal(t, 22, *ssh.PortRangeMax)
+	assert.Equal(t, resourceIBMSecurityGroupRulesHash(flattenSecurityGroupRule(existingSSH)),
+		resourceIBMSecurityGroupRulesHash(map[string]interface{}{
+			"direction": "ingress", "ether_type": "IPv4", "port_range_min": 22, "port_range_max": 0,
+			"protocol": "tcp", "remote_group_id": 0, "remote_ip": "",
+		}))
+}
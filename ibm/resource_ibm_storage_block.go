@@ -4,6 +4,7 @@
 package ibm
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
@@ -11,10 +12,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
 	"github.com/softlayer/softlayer-go/helpers/network"
 	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
 	"github.com/softlayer/softlayer-go/sl"
 )
 
@@ -33,6 +36,15 @@ func resourceIBMStorageBlock() *schema.Resource {
 			Delete: schema.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceIBMStorageBlockEstimateCost(diff, v)
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceStorageRestoreCustomizeDiff(diff)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 			"type": {
 				Type:        schema.TypeString,
@@ -181,6 +193,64 @@ func resourceIBMStorageBlock() *schema.Resource {
 				Deprecated: "Please use 'allowed_host_info' instead",
 			},
 
+			"snapshot_schedule": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				MaxItems: 3,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateScheduleType,
+							Description:  "schedule type",
+						},
+
+						"retention_count": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "Retention count",
+						},
+
+						"minute": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateMinute(0, 59),
+							Description:  "Time duration in minutes",
+						},
+
+						"hour": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validateHour(0, 23),
+							Description:  "Time duration in hour",
+						},
+
+						"day_of_week": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateDayOfWeek,
+							Description:  "Day of the week",
+						},
+
+						"enable": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+				Set: resourceIBMFilSnapshotHash,
+			},
+			"restore_from_snapshot_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of a snapshot of this volume to restore the volume from",
+			},
+			"restore_trigger": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Change the value to restore the volume from restore_from_snapshot_id again",
+			},
 			"allowed_ip_addresses": {
 				Type:        schema.TypeSet,
 				Optional:    true,
@@ -242,6 +312,16 @@ func resourceIBMStorageBlock() *schema.Resource {
 				Computed:    true,
 				Description: "The name of the resource",
 			},
+			estimatedHourlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated hourly cost of the order, quoted by verifyOrder at plan time",
+			},
+			estimatedMonthlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated monthly cost of the order, quoted by verifyOrder at plan time",
+			},
 		},
 	}
 }
@@ -429,6 +509,7 @@ func resourceIBMStorageBlockRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("hourly_billing", storage.BillingItem.HourlyFlag)
 	}
 
+	d.Set("snapshot_schedule", flattenStorageSnapshotSchedules(storage.Schedules))
 	d.Set("target_address", storage.IscsiTargetIpAddresses)
 	d.Set(ResourceControllerURL, fmt.Sprintf("https://cloud.ibm.com/classic/storage/block/%s", d.Id()))
 	d.Set(ResourceName, *storage.ServiceResourceName)
@@ -492,6 +573,22 @@ func resourceIBMStorageBlockUpdate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
+	// Enable Storage Snapshot Schedule
+	if d.HasChange("snapshot_schedule") {
+		err := enableStorageSnapshot(d, sess, storage)
+		if err != nil {
+			return fmt.Errorf("Error creating storage snapshot schedule: %s", err)
+		}
+	}
+
+	// Restore the volume from a snapshot
+	if (d.HasChange("restore_from_snapshot_id") || d.HasChange("restore_trigger")) && !d.IsNewResource() {
+		err := restoreStorageFromSnapshot(d, meta, storage)
+		if err != nil {
+			return fmt.Errorf("Error restoring storage from snapshot: %s", err)
+		}
+	}
+
 	if (d.HasChange("capacity") || d.HasChange("iops")) && !d.IsNewResource() {
 		size := d.Get("capacity").(int)
 		iops := d.Get("iops").(float64)
@@ -527,3 +624,37 @@ func resourceIBMStorageBlockDelete(d *schema.ResourceData, meta interface{}) err
 func resourceIBMStorageBlockExists(d *schema.ResourceData, meta interface{}) (bool, error) {
 	return resourceIBMStorageFileExists(d, meta)
 }
+
+// resourceIBMStorageBlockEstimateCost quotes the cost of a new block storage
+// volume, and of a volume whose capacity, iops or snapshot capacity changes,
+// at plan time.
+func resourceIBMStorageBlockEstimateCost(diff *schema.ResourceDiff, meta interface{}) error {
+	dependsOn := []string{"datacenter", "type", "capacity", "iops", "snapshot_capacity", "os_format_type", "hourly_billing"}
+	return resourceClassicOrderEstimateCustomizeDiff(diff, meta, dependsOn, func(sess *session.Session) (interface{}, error) {
+		storageType := diff.Get("type").(string)
+		iops := diff.Get("iops").(float64)
+		capacity := diff.Get("capacity").(int)
+
+		osType, err := network.GetOsTypeByName(sess, diff.Get("os_format_type").(string))
+		if err != nil {
+			return nil, err
+		}
+		storageOrderContainer, err := buildStorageProductOrderContainer(sess, storageType, iops, capacity,
+			diff.Get("snapshot_capacity").(int), blockStorage, diff.Get("datacenter").(string), diff.Get("hourly_billing").(bool))
+		if err != nil {
+			return nil, err
+		}
+		order := datatypes.Container_Product_Order_Network_Storage_AsAService{
+			Container_Product_Order: storageOrderContainer,
+			OsFormatType: &datatypes.Network_Storage_Iscsi_OS_Type{
+				Id:      osType.Id,
+				KeyName: osType.KeyName,
+			},
+			VolumeSize: &capacity,
+		}
+		if storageType == performanceType {
+			order.Iops = sl.Int(int(iops))
+		}
+		return &order, nil
+	})
+}
@@ -39,6 +39,7 @@ func TestAccIBMStorageBlock_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"ibm_storage_block.bs_endurance", "hourly_billing", "false"),
 					resource.TestCheckResourceAttrSet("ibm_storage_block.bs_endurance", "target_address.#"),
+					resource.TestCheckResourceAttrSet("ibm_storage_block.bs_endurance", "estimated_monthly_cost"),
 					testAccCheckIBMResources("ibm_storage_block.bs_endurance", "datacenter",
 						"ibm_compute_vm_instance.storagevm2", "datacenter"),
 					// Performance Storage
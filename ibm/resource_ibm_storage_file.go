@@ -5,6 +5,7 @@ package ibm
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"regexp"
@@ -13,6 +14,7 @@ import (
 	"time"
 
 	"github.com/IBM-Cloud/terraform-provider-ibm/ibm/internal/hashcode"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -28,7 +30,7 @@ const (
 	storagePackageType = "STORAGE_AS_A_SERVICE"
 	storageMask        = "id,billingItem.orderItem.order.id"
 	storageDetailMask  = "id,billingItem[location],storageTierLevel,provisionedIops,capacityGb,iops,lunId,storageType[keyName,description],username,serviceResourceBackendIpAddress,properties[type]" +
-		",serviceResourceName,allowedIpAddresses[id,ipAddress,subnetId,allowedHost[name,credential[username,password]]],allowedSubnets[allowedHost[name,credential[username,password]]],allowedHardware[allowedHost[name,credential[username,password]]],allowedVirtualGuests[id,allowedHost[name,credential[username,password]]],snapshotCapacityGb,osType,notes,billingItem[hourlyFlag],serviceResource[datacenter[name]],schedules[dayOfWeek,hour,minute,retentionCount,type[keyname,name]],iscsiTargetIpAddresses"
+		",serviceResourceName,allowedIpAddresses[id,ipAddress,subnetId,allowedHost[name,credential[username,password]]],allowedSubnets[allowedHost[name,credential[username,password]]],allowedHardware[allowedHost[name,credential[username,password]]],allowedVirtualGuests[id,allowedHost[name,credential[username,password]]],snapshotCapacityGb,osType,notes,billingItem[hourlyFlag],serviceResource[datacenter[name]],schedules[active,dayOfWeek,hour,minute,retentionCount,type[keyname,name]],iscsiTargetIpAddresses"
 	itemMask        = "id,capacity,description,units,keyName,capacityMinimum,capacityMaximum,prices[id,categories[id,name,categoryCode],capacityRestrictionMinimum,capacityRestrictionMaximum,capacityRestrictionType,locationGroupId],itemCategory[categoryCode]"
 	enduranceType   = "Endurance"
 	performanceType = "Performance"
@@ -80,6 +82,15 @@ func resourceIBMStorageFile() *schema.Resource {
 			Delete: schema.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: customdiff.Sequence(
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceIBMStorageFileEstimateCost(diff, v)
+			},
+			func(_ context.Context, diff *schema.ResourceDiff, v interface{}) error {
+				return resourceStorageRestoreCustomizeDiff(diff)
+			},
+		),
+
 		Schema: map[string]*schema.Schema{
 
 			"type": {
@@ -218,6 +229,16 @@ func resourceIBMStorageFile() *schema.Resource {
 				},
 				Set: resourceIBMFilSnapshotHash,
 			},
+			"restore_from_snapshot_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "ID of a snapshot of this volume to restore the volume from",
+			},
+			"restore_trigger": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Change the value to restore the volume from restore_from_snapshot_id again",
+			},
 			"mountpoint": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -252,6 +273,16 @@ func resourceIBMStorageFile() *schema.Resource {
 				Computed:    true,
 				Description: "The status of the resource",
 			},
+			estimatedHourlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated hourly cost of the order, quoted by verifyOrder at plan time",
+			},
+			estimatedMonthlyCost: {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Estimated monthly cost of the order, quoted by verifyOrder at plan time",
+			},
 		},
 	}
 }
@@ -419,33 +450,7 @@ func resourceIBMStorageFileRead(d *schema.ResourceData, meta interface{}) error
 		d.Set("hourly_billing", storage.BillingItem.HourlyFlag)
 	}
 
-	schds := make([]interface{}, len(storage.Schedules))
-	for i, schd := range storage.Schedules {
-		s := make(map[string]interface{})
-		s["retention_count"], _ = strconv.Atoi(*schd.RetentionCount)
-		if *schd.Minute != "-1" {
-
-			s["minute"], _ = strconv.Atoi(*schd.Minute)
-		}
-		if *schd.Hour != "-1" {
-			s["hour"], _ = strconv.Atoi(*schd.Hour)
-		}
-		if *schd.Active > 0 {
-			s["enable"], _ = strconv.ParseBool("true")
-		} else {
-			s["enable"], _ = strconv.ParseBool("false")
-		}
-
-		if *schd.DayOfWeek != "-1" {
-			s["day_of_week"] = snapshotDay[*schd.DayOfWeek]
-		}
-
-		stype := *schd.Type.Keyname
-		stype = stype[strings.LastIndex(stype, "_")+1:]
-		s["schedule_type"] = stype
-		schds[i] = s
-	}
-	d.Set("snapshot_schedule", schds)
+	d.Set("snapshot_schedule", flattenStorageSnapshotSchedules(storage.Schedules))
 	d.Set(ResourceControllerURL, fmt.Sprintf("https://cloud.ibm.com/classic/storage/file/%s", d.Id()))
 
 	d.Set(ResourceName, *storage.ServiceResourceName)
@@ -519,6 +524,14 @@ func resourceIBMStorageFileUpdate(d *schema.ResourceData, meta interface{}) erro
 		}
 	}
 
+	// Restore the volume from a snapshot
+	if (d.HasChange("restore_from_snapshot_id") || d.HasChange("restore_trigger")) && !d.IsNewResource() {
+		err := restoreStorageFromSnapshot(d, meta, storage)
+		if err != nil {
+			return fmt.Errorf("Error restoring storage from snapshot: %s", err)
+		}
+	}
+
 	if (d.HasChange("capacity") || d.HasChange("iops")) && !d.IsNewResource() {
 		size := d.Get("capacity").(int)
 		iops := d.Get("iops").(float64)
@@ -1175,6 +1188,102 @@ func resourceIBMFilSnapshotHash(v interface{}) int {
 	return hashcode.String(buf.String())
 }
 
+// flattenStorageSnapshotSchedules converts the snapshot schedules of a block
+// or file storage volume into snapshot_schedule blocks.
+func flattenStorageSnapshotSchedules(schedules []datatypes.Network_Storage_Schedule) []interface{} {
+	schds := make([]interface{}, len(schedules))
+	for i, schd := range schedules {
+		s := make(map[string]interface{})
+		s["retention_count"], _ = strconv.Atoi(*schd.RetentionCount)
+		if *schd.Minute != "-1" {
+
+			s["minute"], _ = strconv.Atoi(*schd.Minute)
+		}
+		if *schd.Hour != "-1" {
+			s["hour"], _ = strconv.Atoi(*schd.Hour)
+		}
+		if schd.Active != nil && *schd.Active > 0 {
+			s["enable"], _ = strconv.ParseBool("true")
+		} else {
+			s["enable"], _ = strconv.ParseBool("false")
+		}
+
+		if *schd.DayOfWeek != "-1" {
+			s["day_of_week"] = snapshotDay[*schd.DayOfWeek]
+		}
+
+		stype := *schd.Type.Keyname
+		stype = stype[strings.LastIndex(stype, "_")+1:]
+		s["schedule_type"] = stype
+		schds[i] = s
+	}
+	return schds
+}
+
+// resourceStorageRestoreCustomizeDiff rejects restore_from_snapshot_id on a new volume,
+// there is no snapshot of the volume to restore from before it exists.
+func resourceStorageRestoreCustomizeDiff(diff *schema.ResourceDiff) error {
+	if diff.Id() != "" {
+		return nil
+	}
+	if _, ok := diff.GetOk("restore_from_snapshot_id"); ok {
+		return fmt.Errorf("restore_from_snapshot_id can only be set on an existing volume")
+	}
+	return nil
+}
+
+// restoreStorageFromSnapshot restores a block or file storage volume from
+// the snapshot in restore_from_snapshot_id and waits for the restore to end.
+func restoreStorageFromSnapshot(d *schema.ResourceData, meta interface{}, storage datatypes.Network_Storage) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	snapshotID := d.Get("restore_from_snapshot_id").(int)
+	if snapshotID == 0 {
+		return nil
+	}
+
+	log.Printf("[INFO] Restoring storage (%d) from snapshot %d", *storage.Id, snapshotID)
+	success, err := services.GetNetworkStorageService(sess).
+		Id(*storage.Id).
+		RestoreFromSnapshot(sl.Int(snapshotID))
+	if err != nil {
+		return err
+	}
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful restore of snapshot %d", snapshotID)
+	}
+
+	_, err = waitForStorageTransactions(*storage.Id, d.Timeout(schema.TimeoutUpdate), meta)
+	return err
+}
+
+// waitForStorageTransactions waits until a block or file storage volume has
+// no active transactions.
+func waitForStorageTransactions(id int, timeout time.Duration, meta interface{}) (interface{}, error) {
+	log.Printf("Waiting for storage (%d) to have zero active transactions", id)
+	sess := meta.(ClientSession).SoftLayerSession()
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{"active"},
+		Target:  []string{"idle"},
+		Refresh: func() (interface{}, string, error) {
+			transactions, err := services.GetNetworkStorageService(sess).
+				Id(id).
+				GetActiveTransactions()
+			if err != nil {
+				return nil, "", fmt.Errorf("Error retrieving storage transactions: %s", err)
+			}
+			if len(transactions) > 0 {
+				return transactions, "active", nil
+			}
+			return transactions, "idle", nil
+		},
+		Timeout:    timeout,
+		Delay:      10 * time.Second,
+		MinTimeout: 10 * time.Second,
+	}
+
+	return stateConf.WaitForState()
+}
+
 func getPrice(prices []datatypes.Product_Item_Price, category, restrictionType string, restrictionValue int) datatypes.Product_Item_Price {
 	for _, price := range prices {
 
@@ -1557,3 +1666,29 @@ func WaitForStorageUpdate(d *schema.ResourceData, meta interface{}) (interface{}
 
 	return stateConf.WaitForState()
 }
+
+// resourceIBMStorageFileEstimateCost quotes the cost of a new file storage
+// volume, and of a volume whose capacity, iops or snapshot capacity changes,
+// at plan time.
+func resourceIBMStorageFileEstimateCost(diff *schema.ResourceDiff, meta interface{}) error {
+	dependsOn := []string{"datacenter", "type", "capacity", "iops", "snapshot_capacity", "hourly_billing"}
+	return resourceClassicOrderEstimateCustomizeDiff(diff, meta, dependsOn, func(sess *session.Session) (interface{}, error) {
+		storageType := diff.Get("type").(string)
+		iops := diff.Get("iops").(float64)
+		capacity := diff.Get("capacity").(int)
+
+		storageOrderContainer, err := buildStorageProductOrderContainer(sess, storageType, iops, capacity,
+			diff.Get("snapshot_capacity").(int), fileStorage, diff.Get("datacenter").(string), diff.Get("hourly_billing").(bool))
+		if err != nil {
+			return nil, err
+		}
+		order := datatypes.Container_Product_Order_Network_Storage_AsAService{
+			Container_Product_Order: storageOrderContainer,
+			VolumeSize:              &capacity,
+		}
+		if storageType == performanceType {
+			order.Iops = sl.Int(int(iops))
+		}
+		return &order, nil
+	})
+}
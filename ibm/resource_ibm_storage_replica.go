@@ -0,0 +1,371 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/datatypes"
+	"github.com/softlayer/softlayer-go/helpers/product"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/session"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const (
+	storageReplicaOriginMask = "id,capacityGb,snapshotCapacityGb,storageTierLevel,iops,storageType[keyName]," +
+		"properties[type,value],osType[id,keyName],billingItem[hourlyFlag],schedules[id,type[keyname]]"
+	storageReplicaMask = "id,username,serviceResourceName,serviceResourceBackendIpAddress"
+)
+
+func resourceIBMStorageReplica() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceIBMStorageReplicaCreate,
+		Read:   resourceIBMStorageReplicaRead,
+		Update: resourceIBMStorageReplicaUpdate,
+		Delete: resourceIBMStorageReplicaDelete,
+		Exists: resourceIBMStorageReplicaExists,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(45 * time.Minute),
+			Update: schema.DefaultTimeout(45 * time.Minute),
+			Delete: schema.DefaultTimeout(45 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the block or file storage volume to replicate",
+			},
+			"datacenter": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Datacenter of the replica",
+			},
+			"schedule_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateScheduleType,
+				Description:  "Snapshot schedule of the origin volume that drives replication: HOURLY, DAILY or WEEKLY",
+			},
+			"failover": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set true to fail the origin volume over to the replica, and false to fail back",
+			},
+			"volumename": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the replica volume",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fully qualified domain name of the replica",
+			},
+			"replication_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Replication status of the origin volume",
+			},
+		},
+	}
+}
+
+func resourceIBMStorageReplicaCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	volumeID := d.Get("volume_id").(int)
+
+	origin, err := services.GetNetworkStorageService(sess).
+		Id(volumeID).
+		Mask(storageReplicaOriginMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage %d: %s", volumeID, err)
+	}
+
+	order, err := buildStorageReplicaOrder(sess, origin, d.Get("datacenter").(string), d.Get("schedule_type").(string))
+	if err != nil {
+		return fmt.Errorf("Error while creating storage replica: %s", err)
+	}
+
+	log.Printf("[INFO] Creating replica of storage %d", volumeID)
+	receipt, err := services.GetProductOrderService(sess.SetRetries(0)).PlaceOrder(&order, sl.Bool(false))
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+
+	replica, err := findStorageByOrderId(sess, *receipt.OrderId, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", *replica.Id))
+
+	_, err = WaitForStorageAvailable(d, meta)
+	if err != nil {
+		return fmt.Errorf(
+			"Error waiting for storage replica (%s) to become ready: %s", d.Id(), err)
+	}
+
+	// SoftLayer changes the device ID after completion of provisioning. It is necessary to refresh device ID.
+	replica, err = findStorageByOrderId(sess, *receipt.OrderId, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return fmt.Errorf("Error during creation of storage replica: %s", err)
+	}
+	d.SetId(fmt.Sprintf("%d", *replica.Id))
+
+	log.Printf("[INFO] Storage replica ID: %s", d.Id())
+
+	if d.Get("failover").(bool) {
+		err = failoverStorageReplica(d, meta, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceIBMStorageReplicaRead(d, meta)
+}
+
+func resourceIBMStorageReplicaRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	replicaID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	replica, err := services.GetNetworkStorageService(sess).
+		Id(replicaID).
+		Mask(storageReplicaMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage replica information: %s", err)
+	}
+
+	d.Set("volumename", sl.Get(replica.Username, ""))
+	d.Set("hostname", sl.Get(replica.ServiceResourceBackendIpAddress, ""))
+	if replica.ServiceResourceName != nil {
+		r, _ := regexp.Compile("[a-zA-Z]{3}[0-9]{2}")
+		d.Set("datacenter", strings.ToLower(r.FindString(*replica.ServiceResourceName)))
+	}
+
+	status, err := services.GetNetworkStorageService(sess).
+		Id(d.Get("volume_id").(int)).
+		GetReplicationStatus()
+	if err != nil {
+		return fmt.Errorf("Error retrieving replication status of storage %d: %s", d.Get("volume_id").(int), err)
+	}
+	d.Set("replication_status", status)
+
+	return nil
+}
+
+func resourceIBMStorageReplicaUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("failover") {
+		err := failoverStorageReplica(d, meta, d.Get("failover").(bool))
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceIBMStorageReplicaRead(d, meta)
+}
+
+func resourceIBMStorageReplicaDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	replicaID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	if d.Get("failover").(bool) {
+		return fmt.Errorf("Storage %d is failed over to replica %d; set failover to false to fail back before deleting the replica",
+			d.Get("volume_id").(int), replicaID)
+	}
+
+	billingItem, err := services.GetNetworkStorageService(sess).Id(replicaID).GetBillingItem()
+	if err != nil {
+		return fmt.Errorf("Error while looking up billing item associated with the storage replica: %s", err)
+	}
+
+	if billingItem.Id == nil {
+		return fmt.Errorf("Error while looking up billing item associated with the storage replica: No billing item for ID:%d", replicaID)
+	}
+
+	success, err := services.GetBillingItemService(sess).Id(*billingItem.Id).CancelService()
+	if err != nil {
+		return err
+	}
+
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful cancellation")
+	}
+	return nil
+}
+
+func resourceIBMStorageReplicaExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ClientSession).SoftLayerSession()
+	replicaID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = services.GetNetworkStorageService(sess).
+		Id(replicaID).
+		GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving storage replica information: %s", err)
+	}
+	return true, nil
+}
+
+// failoverStorageReplica fails the origin volume over to the replica, or
+// fails it back from the replica, and waits for the transaction to end.
+func failoverStorageReplica(d *schema.ResourceData, meta interface{}, failover bool) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	volumeID := d.Get("volume_id").(int)
+	replicaID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	service := services.GetNetworkStorageService(sess).Id(volumeID)
+	var success bool
+	if failover {
+		log.Printf("[INFO] Failing storage %d over to replica %d", volumeID, replicaID)
+		success, err = service.FailoverToReplicant(sl.Int(replicaID))
+	} else {
+		log.Printf("[INFO] Failing storage %d back from replica %d", volumeID, replicaID)
+		success, err = service.FailbackFromReplicant()
+	}
+	if err != nil {
+		return fmt.Errorf("Error during failover of storage %d: %s", volumeID, err)
+	}
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful failover of storage %d", volumeID)
+	}
+
+	_, err = waitForStorageTransactions(volumeID, d.Timeout(schema.TimeoutUpdate), meta)
+	return err
+}
+
+// buildStorageReplicaOrder builds the order of a replicant volume of origin
+// in datacenter, replicated on the origin's snapshot schedule of scheduleType.
+func buildStorageReplicaOrder(sess *session.Session, origin datatypes.Network_Storage, datacenter, scheduleType string) (datatypes.Container_Product_Order_Network_Storage_AsAService, error) {
+	if origin.StorageType == nil || origin.StorageType.KeyName == nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, fmt.Errorf("Unable to determine the storage type of storage %d", *origin.Id)
+	}
+	storageType, err := getStorageTypeFromKeyName(*origin.StorageType.KeyName)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, err
+	}
+	storageProtocol := fileStorage
+	if strings.Contains(*origin.StorageType.KeyName, "BLOCK") {
+		storageProtocol = blockStorage
+	}
+
+	if origin.SnapshotCapacityGb == nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, fmt.Errorf("Storage %d has no snapshot space; replication requires snapshot_capacity", *origin.Id)
+	}
+	snapshotCapacity, _ := strconv.Atoi(*origin.SnapshotCapacityGb)
+
+	var scheduleID *int
+	for _, schedule := range origin.Schedules {
+		if schedule.Type != nil && schedule.Type.Keyname != nil && *schedule.Type.Keyname == "SNAPSHOT_"+scheduleType {
+			scheduleID = schedule.Id
+		}
+	}
+	if scheduleID == nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, fmt.Errorf("Storage %d has no %s snapshot schedule", *origin.Id, scheduleType)
+	}
+
+	var iops float64
+	if storageType == performanceType {
+		iops, err = getIops(origin, storageType)
+	} else {
+		iops, err = findEnduranceTierIopsPerGb(origin)
+	}
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, err
+	}
+
+	hourlyBilling := origin.BillingItem != nil && origin.BillingItem.HourlyFlag != nil && *origin.BillingItem.HourlyFlag
+	storageOrderContainer, err := buildStorageProductOrderContainer(sess, storageType, iops, *origin.CapacityGb, snapshotCapacity, storageProtocol, datacenter, hourlyBilling)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, err
+	}
+
+	pkg, err := product.GetPackageByType(sess, storagePackageType)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, err
+	}
+	productItems, err := product.GetPackageProducts(sess, *pkg.Id, itemMask)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, err
+	}
+	price, err := getSaaSReplicationPrice(productItems, iops, storageType)
+	if err != nil {
+		return datatypes.Container_Product_Order_Network_Storage_AsAService{}, err
+	}
+	storageOrderContainer.Prices = append(storageOrderContainer.Prices, price)
+	storageOrderContainer.ComplexType = sl.String("SoftLayer_Container_Product_Order_Network_Storage_AsAService")
+
+	order := datatypes.Container_Product_Order_Network_Storage_AsAService{
+		Container_Product_Order: storageOrderContainer,
+		OriginVolumeId:          origin.Id,
+		OriginVolumeScheduleId:  scheduleID,
+		VolumeSize:              origin.CapacityGb,
+	}
+	if storageType == performanceType {
+		order.Iops = sl.Int(int(iops))
+	}
+	if storageProtocol == blockStorage && origin.OsType != nil {
+		order.OsFormatType = &datatypes.Network_Storage_Iscsi_OS_Type{
+			Id:      origin.OsType.Id,
+			KeyName: origin.OsType.KeyName,
+		}
+	}
+
+	return order, nil
+}
+
+// getSaaSReplicationPrice returns the replication price matching the IOPS of
+// a Performance volume or the tier of an Endurance volume.
+func getSaaSReplicationPrice(productItems []datatypes.Product_Item, iops float64, volumeType string) (datatypes.Product_Item_Price, error) {
+	targetKeyName := "REPLICATION_FOR_TIERBASED_PERFORMANCE"
+	targetRestrictionType := "STORAGE_TIER_LEVEL"
+	targetValue := enduranceCapacityRestrictionMap[iops]
+	if volumeType == performanceType {
+		targetKeyName = "REPLICATION_FOR_IOPSBASED_PERFORMANCE"
+		targetRestrictionType = "IOPS"
+		targetValue = int(iops)
+	}
+
+	for _, item := range productItems {
+		if item.KeyName == nil || *item.KeyName != targetKeyName {
+			continue
+		}
+
+		price := getPrice(item.Prices, "performance_storage_replication", targetRestrictionType, targetValue)
+		if price.Id != nil {
+			return price, nil
+		}
+	}
+
+	return datatypes.Product_Item_Price{},
+		fmt.Errorf("Could not find price for replication")
+}
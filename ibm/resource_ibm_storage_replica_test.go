@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func TestAccIBMStorageReplica_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMStorageReplicaConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckIBMStorageReplicaExists("ibm_storage_replica.replica"),
+					resource.TestCheckResourceAttr(
+						"ibm_storage_replica.replica", "datacenter", "dal12"),
+					resource.TestCheckResourceAttr(
+						"ibm_storage_replica.replica", "schedule_type", "HOURLY"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_storage_replica.replica", "volumename"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_storage_replica.replica", "replication_status"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMStorageReplicaConfig(true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_storage_replica.replica", "failover", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccCheckIBMStorageReplicaConfig(false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_storage_replica.replica", "failover", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMStorageReplicaExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		replicaID, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageService(testAccProvider.Meta().(ClientSession).SoftLayerSession())
+		foundReplica, err := service.Id(replicaID).GetObject()
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundReplica.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckIBMStorageReplicaConfig(failover bool) string {
+	return fmt.Sprintf(`
+resource "ibm_storage_block" "origin" {
+        type = "Endurance"
+        datacenter = "dal10"
+        capacity = 20
+        iops = 2
+        snapshot_capacity = 10
+        os_format_type = "Linux"
+        snapshot_schedule {
+          schedule_type   = "HOURLY"
+          retention_count = 3
+          minute          = 30
+          enable          = true
+        }
+}
+
+resource "ibm_storage_replica" "replica" {
+        volume_id     = ibm_storage_block.origin.id
+        datacenter    = "dal12"
+        schedule_type = "HOURLY"
+        failover      = %t
+}
+`, failover)
+}
@@ -0,0 +1,140 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/softlayer/softlayer-go/services"
+	"github.com/softlayer/softlayer-go/sl"
+)
+
+const storageSnapshotMask = "id,notes,snapshotCreationTimestamp,snapshotSizeBytes,parentVolume[id]"
+
+func resourceIBMStorageSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create:   resourceIBMStorageSnapshotCreate,
+		Read:     resourceIBMStorageSnapshotRead,
+		Delete:   resourceIBMStorageSnapshotDelete,
+		Exists:   resourceIBMStorageSnapshotExists,
+		Importer: &schema.ResourceImporter{},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"volume_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the block or file storage volume to snapshot",
+			},
+			"notes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Notes of the snapshot",
+			},
+			"snapshot_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation time of the snapshot",
+			},
+			"snapshot_size_bytes": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Size of the snapshot in bytes",
+			},
+		},
+	}
+}
+
+func resourceIBMStorageSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	volumeID := d.Get("volume_id").(int)
+
+	log.Printf("[INFO] Creating snapshot of storage %d", volumeID)
+	snapshot, err := services.GetNetworkStorageService(sess).
+		Id(volumeID).
+		CreateSnapshot(sl.String(d.Get("notes").(string)))
+	if err != nil {
+		return fmt.Errorf("Error creating snapshot of storage %d: %s", volumeID, err)
+	}
+	d.SetId(fmt.Sprintf("%d", *snapshot.Id))
+
+	_, err = waitForStorageTransactions(volumeID, d.Timeout(schema.TimeoutCreate), meta)
+	if err != nil {
+		return fmt.Errorf("Error waiting for snapshot (%s) of storage %d: %s", d.Id(), volumeID, err)
+	}
+
+	return resourceIBMStorageSnapshotRead(d, meta)
+}
+
+func resourceIBMStorageSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	snapshotID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	snapshot, err := services.GetNetworkStorageService(sess).
+		Id(snapshotID).
+		Mask(storageSnapshotMask).
+		GetObject()
+	if err != nil {
+		return fmt.Errorf("Error retrieving storage snapshot: %s", err)
+	}
+
+	if snapshot.ParentVolume != nil {
+		d.Set("volume_id", *snapshot.ParentVolume.Id)
+	}
+	d.Set("notes", sl.Get(snapshot.Notes, ""))
+	d.Set("snapshot_date", sl.Get(snapshot.SnapshotCreationTimestamp, ""))
+	d.Set("snapshot_size_bytes", sl.Get(snapshot.SnapshotSizeBytes, ""))
+
+	return nil
+}
+
+func resourceIBMStorageSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	sess := meta.(ClientSession).SoftLayerSession()
+	snapshotID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	success, err := services.GetNetworkStorageService(sess).Id(snapshotID).DeleteObject()
+	if err != nil {
+		return fmt.Errorf("Error deleting storage snapshot: %s", err)
+	}
+	if !success {
+		return fmt.Errorf("SoftLayer reported an unsuccessful deletion of snapshot %d", snapshotID)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+func resourceIBMStorageSnapshotExists(d *schema.ResourceData, meta interface{}) (bool, error) {
+	sess := meta.(ClientSession).SoftLayerSession()
+	snapshotID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return false, fmt.Errorf("Not a valid ID, must be an integer: %s", err)
+	}
+
+	_, err = services.GetNetworkStorageService(sess).
+		Id(snapshotID).
+		GetObject()
+	if err != nil {
+		if apiErr, ok := err.(sl.Error); ok && apiErr.StatusCode == 404 {
+			return false, nil
+		}
+		return false, fmt.Errorf("Error retrieving storage snapshot: %s", err)
+	}
+	return true, nil
+}
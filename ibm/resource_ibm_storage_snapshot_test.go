@@ -0,0 +1,113 @@
+// Copyright IBM Corp. 2017, 2021 All Rights Reserved.
+// Licensed under the Mozilla Public License v2.0
+
+package ibm
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/softlayer/softlayer-go/services"
+)
+
+func TestAccIBMStorageSnapshot_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckIBMStorageSnapshotDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccCheckIBMStorageSnapshotConfig_basic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"ibm_storage_block.bs_snapshot", "snapshot_schedule.#", "2"),
+					testAccCheckIBMStorageSnapshotExists("ibm_storage_snapshot.snapshot"),
+					resource.TestCheckResourceAttr(
+						"ibm_storage_snapshot.snapshot", "notes", "manual snapshot"),
+					resource.TestCheckResourceAttrPair(
+						"ibm_storage_snapshot.snapshot", "volume_id", "ibm_storage_block.bs_snapshot", "id"),
+					resource.TestCheckResourceAttrSet(
+						"ibm_storage_snapshot.snapshot", "snapshot_date"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckIBMStorageSnapshotExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No Record ID is set")
+		}
+
+		snapshotID, _ := strconv.Atoi(rs.Primary.ID)
+
+		service := services.GetNetworkStorageService(testAccProvider.Meta().(ClientSession).SoftLayerSession())
+		foundSnapshot, err := service.Id(snapshotID).GetObject()
+		if err != nil {
+			return err
+		}
+
+		if strconv.Itoa(int(*foundSnapshot.Id)) != rs.Primary.ID {
+			return fmt.Errorf("Record %s not found", rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckIBMStorageSnapshotDestroy(s *terraform.State) error {
+	service := services.GetNetworkStorageService(testAccProvider.Meta().(ClientSession).SoftLayerSession())
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ibm_storage_snapshot" {
+			continue
+		}
+
+		snapshotID, _ := strconv.Atoi(rs.Primary.ID)
+
+		_, err := service.Id(snapshotID).GetObject()
+		if err == nil {
+			return fmt.Errorf("Storage snapshot %d still exists", snapshotID)
+		}
+	}
+
+	return nil
+}
+
+const testAccCheckIBMStorageSnapshotConfig_basic = `
+resource "ibm_storage_block" "bs_snapshot" {
+        type = "Endurance"
+        datacenter = "dal10"
+        capacity = 20
+        iops = 0.25
+        snapshot_capacity = 10
+        os_format_type = "Linux"
+        snapshot_schedule {
+          schedule_type   = "HOURLY"
+          retention_count = 3
+          minute          = 30
+          enable          = true
+        }
+        snapshot_schedule {
+          schedule_type   = "DAILY"
+          retention_count = 2
+          minute          = 15
+          hour            = 2
+          enable          = true
+        }
+}
+
+resource "ibm_storage_snapshot" "snapshot" {
+        volume_id = ibm_storage_block.bs_snapshot.id
+        notes     = "manual snapshot"
+}
+`
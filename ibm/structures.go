@@ -17,9 +17,11 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/IBM/go-sdk-core/v5/core"
 	"github.com/IBM/ibm-cos-sdk-go-config/resourceconfigurationv1"
 	"github.com/IBM/ibm-cos-sdk-go/service/s3"
 	kp "github.com/IBM/keyprotect-go-client"
+	"github.com/IBM/vpc-go-sdk/vpcv1"
 	"github.com/apache/openwhisk-client-go/whisk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/softlayer/softlayer-go/datatypes"
@@ -1841,6 +1843,36 @@ func GetNext(next interface{}) string {
 	return q.Get("start")
 }
 
+// vpcPlainRequest sends a plain request to the vpc api, for the fields that the vpc sdk does not
+// support yet. start is sent as the page token when it is set.
+func vpcPlainRequest(sess *vpcv1.VpcV1, method, path string, pathParams map[string]string, start string, body, result interface{}) (*core.DetailedResponse, error) {
+	builder := core.NewRequestBuilder(method)
+	if _, err := builder.ResolveRequestURL(sess.Service.Options.URL, path, pathParams); err != nil {
+		return nil, err
+	}
+	builder.AddHeader("Accept", "application/json")
+	builder.AddQuery("version", *sess.Version)
+	builder.AddQuery("generation", "2")
+	if start != "" {
+		builder.AddQuery("start", start)
+	}
+	if body != nil {
+		contentType := "application/json"
+		if method == core.PATCH {
+			contentType = "application/merge-patch+json"
+		}
+		builder.AddHeader("Content-Type", contentType)
+		if _, err := builder.SetBodyContentJSON(body); err != nil {
+			return nil, err
+		}
+	}
+	request, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	return sess.Service.Request(request, result)
+}
+
 /* Return the default resource group */
 func defaultResourceGroup(meta interface{}) (string, error) {
 	rsMangClient, err := meta.(ClientSession).ResourceManagementAPIv2()